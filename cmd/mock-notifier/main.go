@@ -0,0 +1,33 @@
+// Mock SMS gateway and Telegram Bot API for local development.
+// Every request is printed to stdout and acknowledged.
+package main
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+func main() {
+	addr := os.Getenv("MOCK_NOTIFIER_ADDR")
+	if addr == "" {
+		addr = ":8081"
+	}
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		log.Printf("%s %s %s", r.Method, r.URL.Path, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/sendMessage") {
+			w.Write([]byte(`{"ok":true}`))
+			return
+		}
+		w.Write([]byte(`{"status":"sent"}`))
+	})
+
+	log.Printf("mock notifier listening on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, nil))
+}
@@ -19,6 +19,22 @@ type Config struct {
 	EXPIRED_REFRESH string
 
 	RUN_PORT string
+
+	SMS_GATEWAY_URL   string
+	SMS_GATEWAY_TOKEN string
+	SMS_SENDER        string
+
+	TELEGRAM_API_URL   string
+	TELEGRAM_BOT_TOKEN string
+
+	RATE_PROVIDER     string
+	RATE_PROVIDER_URL string
+
+	BUDGET_ALERT_CHANNEL    string
+	BUDGET_ALERT_RECIPIENTS string
+
+	STOCK_ALERT_CHANNEL    string
+	STOCK_ALERT_RECIPIENTS string
 }
 
 func NewConfig() Config {
@@ -41,5 +57,21 @@ func NewConfig() Config {
 	config.EXPIRED_ACCESS = os.Getenv("EXPIRED_ACCESS")
 	config.EXPIRED_REFRESH = os.Getenv("EXPIRED_REFRESH")
 
+	config.SMS_GATEWAY_URL = os.Getenv("SMS_GATEWAY_URL")
+	config.SMS_GATEWAY_TOKEN = os.Getenv("SMS_GATEWAY_TOKEN")
+	config.SMS_SENDER = os.Getenv("SMS_SENDER")
+
+	config.TELEGRAM_API_URL = os.Getenv("TELEGRAM_API_URL")
+	config.TELEGRAM_BOT_TOKEN = os.Getenv("TELEGRAM_BOT_TOKEN")
+
+	config.RATE_PROVIDER = os.Getenv("RATE_PROVIDER")
+	config.RATE_PROVIDER_URL = os.Getenv("RATE_PROVIDER_URL")
+
+	config.BUDGET_ALERT_CHANNEL = os.Getenv("BUDGET_ALERT_CHANNEL")
+	config.BUDGET_ALERT_RECIPIENTS = os.Getenv("BUDGET_ALERT_RECIPIENTS")
+
+	config.STOCK_ALERT_CHANNEL = os.Getenv("STOCK_ALERT_CHANNEL")
+	config.STOCK_ALERT_RECIPIENTS = os.Getenv("STOCK_ALERT_RECIPIENTS")
+
 	return config
 }
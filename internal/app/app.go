@@ -25,7 +25,8 @@ func Run(cfg config.Config) {
 		log.Fatal(err)
 	}
 
-	controller1 := controller.NewController(db, logger1)
+	controller1 := controller.NewController(db, cfg, logger1)
+	startJobs(controller1, logger1)
 
 	engine := gin.Default()
 	http.NewRouter(engine, logger1, controller1)
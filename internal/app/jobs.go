@@ -0,0 +1,47 @@
+package app
+
+import (
+	"crm-admin/internal/controller"
+	"log/slog"
+	"time"
+)
+
+// startJobs launches the background jobs of the service.
+func startJobs(ctr *controller.Controller, log *slog.Logger) {
+	runEvery("flag-overdue-debts", 24*time.Hour, log, ctr.Debts.FlagOverdueDebts)
+	runEvery("debt-reminders", 24*time.Hour, log, func() error {
+		_, err := ctr.Reminders.SendReminders()
+		return err
+	})
+	runEvery("exchange-rates", 24*time.Hour, log, func() error {
+		_, err := ctr.Rates.SyncRates()
+		return err
+	})
+	runEvery("recurring-expenses", time.Hour, log, func() error {
+		_, err := ctr.Recurring.PostDueExpenses()
+		return err
+	})
+	runEvery("budget-alerts", time.Hour, log, func() error {
+		_, err := ctr.Budgets.CheckBudgets()
+		return err
+	})
+	runEvery("low-stock-alerts", 24*time.Hour, log, func() error {
+		_, err := ctr.Reorder.NotifyLowStock()
+		return err
+	})
+}
+
+// runEvery runs job right away and then once per interval in its own goroutine.
+func runEvery(name string, interval time.Duration, log *slog.Logger, job func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job(); err != nil {
+				log.Error("Scheduled job failed", "job", name, "error", err.Error())
+			}
+			<-ticker.C
+		}
+	}()
+}
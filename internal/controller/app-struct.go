@@ -1,32 +1,99 @@
 package controller
 
 import (
+	"crm-admin/config"
 	"crm-admin/internal/usecase"
+	"crm-admin/internal/usecase/notifier"
+	"crm-admin/internal/usecase/rates"
 	"crm-admin/internal/usecase/repo"
 	"github.com/jmoiron/sqlx"
 	"log/slog"
 )
 
 type Controller struct {
-	Auth     *usecase.UserUseCase
-	Product  *usecase.ProductsUseCase
-	Purchase *usecase.PurchaseUseCase
-	Sales    *usecase.SalesUseCase
+	Auth           *usecase.UserUseCase
+	Product        *usecase.ProductsUseCase
+	Purchase       *usecase.PurchaseUseCase
+	Sales          *usecase.SalesUseCase
+	Debts          *usecase.DebtsUseCase
+	Clients        *usecase.ClientsUseCase
+	Reminders      *usecase.RemindersUseCase
+	Payables       *usecase.PayablesUseCase
+	Cash           *usecase.CashUseCase
+	Wallets        *usecase.WalletsUseCase
+	Shifts         *usecase.ShiftsUseCase
+	Rates          *usecase.RatesUseCase
+	Recurring      *usecase.RecurringUseCase
+	Budgets        *usecase.BudgetsUseCase
+	Reports        *usecase.ReportsUseCase
+	Bank           *usecase.BankUseCase
+	Returns        *usecase.ReturnsUseCase
+	Stocktakes     *usecase.StocktakesUseCase
+	WriteOffs      *usecase.WriteOffsUseCase
+	Reorder        *usecase.ReorderUseCase
+	PurchaseOrders *usecase.PurchaseOrdersUseCase
+	Lots           *usecase.LotsUseCase
 }
 
-func NewController(db *sqlx.DB, log *slog.Logger) *Controller {
+func NewController(db *sqlx.DB, cfg config.Config, log *slog.Logger) *Controller {
 
 	authRepo := repo.NewUserRepo(db)
 	productRepo := repo.NewProductRepo(db)
 	purchaseRepo := repo.NewPurchasesRepo(db)
 	salesRepo := repo.NewSalesRepo(db)
+	debtsRepo := repo.NewDebtsRepo(db)
+	clientsRepo := repo.NewClientsRepo(db)
+	remindersRepo := repo.NewRemindersRepo(db)
+	payablesRepo := repo.NewPayablesRepo(db)
+	cashRepo := repo.NewCashRepo(db)
+	walletsRepo := repo.NewWalletsRepo(db)
+	shiftsRepo := repo.NewShiftsRepo(db)
+	ratesRepo := repo.NewRatesRepo(db)
+	recurringRepo := repo.NewRecurringRepo(db)
+	budgetsRepo := repo.NewBudgetsRepo(db)
+	reportsRepo := repo.NewReportsRepo(db)
+	bankRepo := repo.NewBankRepo(db)
+	returnsRepo := repo.NewReturnsRepo(db)
+	stocktakesRepo := repo.NewStocktakesRepo(db)
+	writeOffsRepo := repo.NewWriteOffsRepo(db)
+	reorderRepo := repo.NewReorderRepo(db)
+	purchaseOrdersRepo := repo.NewPurchaseOrdersRepo(db)
+	lotsRepo := repo.NewLotsRepo(db)
+
+	notifiers := map[string]usecase.Notifier{
+		"sms":      notifier.NewSMS(cfg),
+		"telegram": notifier.NewTelegram(cfg),
+	}
 	productQuantityRepo := repo.NewProductQuantity(db)
 
+	var rateProvider usecase.RateProvider = rates.NewStub()
+	if cfg.RATE_PROVIDER == "cbu" {
+		rateProvider = rates.NewCBU(cfg)
+	}
+
 	ctr := &Controller{
-		Auth:     usecase.NewUserUseCase(authRepo, log),
-		Product:  usecase.NewProductsUseCase(productRepo, log),
-		Purchase: usecase.NewPurchaseUseCase(purchaseRepo, productQuantityRepo, log),
-		Sales:    usecase.NewSalesUseCase(salesRepo, productQuantityRepo, log),
+		Auth:           usecase.NewUserUseCase(authRepo, log),
+		Product:        usecase.NewProductsUseCase(productRepo, log),
+		Purchase:       usecase.NewPurchaseUseCase(purchaseRepo, productQuantityRepo, log),
+		Sales:          usecase.NewSalesUseCase(salesRepo, log),
+		Debts:          usecase.NewDebtsUseCase(debtsRepo, log),
+		Clients:        usecase.NewClientsUseCase(clientsRepo, log),
+		Reminders:      usecase.NewRemindersUseCase(remindersRepo, notifiers, log),
+		Payables:       usecase.NewPayablesUseCase(payablesRepo, log),
+		Cash:           usecase.NewCashUseCase(cashRepo, log),
+		Wallets:        usecase.NewWalletsUseCase(walletsRepo, log),
+		Shifts:         usecase.NewShiftsUseCase(shiftsRepo, log),
+		Rates:          usecase.NewRatesUseCase(ratesRepo, rateProvider, log),
+		Recurring:      usecase.NewRecurringUseCase(recurringRepo, log),
+		Budgets:        usecase.NewBudgetsUseCase(budgetsRepo, notifiers, cfg.BUDGET_ALERT_CHANNEL, cfg.BUDGET_ALERT_RECIPIENTS, log),
+		Reports:        usecase.NewReportsUseCase(reportsRepo, log),
+		Bank:           usecase.NewBankUseCase(bankRepo, log),
+		Returns:        usecase.NewReturnsUseCase(returnsRepo, log),
+		Stocktakes:     usecase.NewStocktakesUseCase(stocktakesRepo, log),
+		WriteOffs:      usecase.NewWriteOffsUseCase(writeOffsRepo, log),
+		Reorder:        usecase.NewReorderUseCase(reorderRepo, notifiers, cfg.STOCK_ALERT_CHANNEL, cfg.STOCK_ALERT_RECIPIENTS, log),
+		PurchaseOrders: usecase.NewPurchaseOrdersUseCase(purchaseOrdersRepo, log),
+		Lots:           usecase.NewLotsUseCase(lotsRepo, log),
 	}
 
 	return ctr
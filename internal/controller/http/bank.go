@@ -0,0 +1,322 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type bankRoutes struct {
+	useCase *usecase.BankUseCase
+	log     *slog.Logger
+}
+
+func newBankRoutes(router *gin.RouterGroup, us *usecase.BankUseCase, log *slog.Logger) {
+	bank := &bankRoutes{useCase: us, log: log}
+
+	// Bank reconciliation routes
+	router.POST("/statements", bank.ImportStatement)
+	router.GET("/statements", bank.GetStatements)
+	router.GET("/statements/:id", bank.GetStatement)
+	router.GET("/statements/:id/lines", bank.GetStatementLines)
+	router.POST("/statements/:id/auto-match", bank.AutoMatch)
+	router.GET("/lines/:id/candidates", bank.GetLineCandidates)
+	router.POST("/lines/:id/confirm", bank.ConfirmLine)
+	router.POST("/lines/:id/match", bank.MatchLine)
+	router.DELETE("/lines/:id/match", bank.UnmatchLine)
+	router.POST("/lines/:id/cash-flow", bank.CreateLineCashFlow)
+}
+
+// ImportStatement godoc
+// @Summary Import Bank Statement
+// @Description Upload a CSV or MT940 statement of a wallet. Lines already imported are skipped, the rest are matched automatically against the cash flow by amount, direction and date
+// @Tags Bank
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Statement file"
+// @Param wallet_id formData string true "Wallet ID"
+// @Param format formData string false "csv or mt940, guessed from the file name when empty"
+// @Param imported_by formData string true "User ID"
+// @Success 200 {object} entity.BankStatement
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /bank/statements [post]
+func (b *bankRoutes) ImportStatement(c *gin.Context) {
+	var req entity.BankStatementRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		b.log.Error("Error binding form in ImportStatement", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		b.log.Error("Error reading file in ImportStatement", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		b.log.Error("Error opening file in ImportStatement", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	req.FileName = header.Filename
+
+	res, err := b.useCase.ImportStatement(&req, file)
+	if err != nil {
+		b.log.Error("Error importing bank statement", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetStatements godoc
+// @Summary List Bank Statements
+// @Description Retrieve imported statements with the number of matched, suggested and unmatched lines
+// @Tags Bank
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.BankStatementList
+// @Failure 500 {object} entity.Error
+// @Router /bank/statements [get]
+func (b *bankRoutes) GetStatements(c *gin.Context) {
+	res, err := b.useCase.GetStatements()
+	if err != nil {
+		b.log.Error("Error retrieving bank statements", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetStatement godoc
+// @Summary Get Bank Statement
+// @Description Retrieve a statement with the number of matched, suggested and unmatched lines
+// @Tags Bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Statement ID"
+// @Success 200 {object} entity.BankStatement
+// @Failure 500 {object} entity.Error
+// @Router /bank/statements/{id} [get]
+func (b *bankRoutes) GetStatement(c *gin.Context) {
+	var req entity.BankStatementID
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.GetStatement(&req)
+	if err != nil {
+		b.log.Error("Error retrieving bank statement", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetStatementLines godoc
+// @Summary List Statement Lines
+// @Description Retrieve the lines of a statement with their matches, optionally only unmatched, suggested or matched ones
+// @Tags Bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Statement ID"
+// @Param BankLineFilter query entity.BankLineFilter false "Filter"
+// @Success 200 {object} entity.BankLineList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /bank/statements/{id}/lines [get]
+func (b *bankRoutes) GetStatementLines(c *gin.Context) {
+	var req entity.BankLineFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		b.log.Error("Error binding query parameters in GetStatementLines", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.GetStatementLines(&req)
+	if err != nil {
+		b.log.Error("Error retrieving statement lines", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// AutoMatch godoc
+// @Summary Match Bank Statement
+// @Description Suggest matches again for the unmatched lines of a statement, e.g. after recording missing cash flow entries
+// @Tags Bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Statement ID"
+// @Success 200 {object} entity.BankStatement
+// @Failure 500 {object} entity.Error
+// @Router /bank/statements/{id}/auto-match [post]
+func (b *bankRoutes) AutoMatch(c *gin.Context) {
+	var req entity.BankStatementID
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.AutoMatch(&req)
+	if err != nil {
+		b.log.Error("Error matching bank statement", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetLineCandidates godoc
+// @Summary List Line Candidates
+// @Description Retrieve unmatched cash flow entries of the same wallet, direction and amount within 30 days of a line
+// @Tags Bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Line ID"
+// @Success 200 {object} entity.CashFlowList
+// @Failure 500 {object} entity.Error
+// @Router /bank/lines/{id}/candidates [get]
+func (b *bankRoutes) GetLineCandidates(c *gin.Context) {
+	var req entity.BankLineID
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.GetLineCandidates(&req)
+	if err != nil {
+		b.log.Error("Error retrieving statement line candidates", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ConfirmLine godoc
+// @Summary Confirm Line Match
+// @Description Accept the match suggested for a line
+// @Tags Bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Line ID"
+// @Param BankLineMatch body entity.BankLineMatch true "User confirming the match"
+// @Success 200 {object} entity.BankStatementLine
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /bank/lines/{id}/confirm [post]
+func (b *bankRoutes) ConfirmLine(c *gin.Context) {
+	var req entity.BankLineMatch
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		b.log.Error("Error binding JSON in ConfirmLine", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.ConfirmLine(&req)
+	if err != nil {
+		b.log.Error("Error confirming statement line", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// MatchLine godoc
+// @Summary Match Line
+// @Description Match a line by hand to an unmatched cash flow entry of the same wallet, direction and amount
+// @Tags Bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Line ID"
+// @Param BankLineMatch body entity.BankLineMatch true "Cash flow entry and user"
+// @Success 200 {object} entity.BankStatementLine
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /bank/lines/{id}/match [post]
+func (b *bankRoutes) MatchLine(c *gin.Context) {
+	var req entity.BankLineMatch
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		b.log.Error("Error binding JSON in MatchLine", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.MatchLine(&req)
+	if err != nil {
+		b.log.Error("Error matching statement line", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UnmatchLine godoc
+// @Summary Unmatch Line
+// @Description Reject the suggested or confirmed match of a line
+// @Tags Bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Line ID"
+// @Success 200 {object} entity.BankStatementLine
+// @Failure 500 {object} entity.Error
+// @Router /bank/lines/{id}/match [delete]
+func (b *bankRoutes) UnmatchLine(c *gin.Context) {
+	var req entity.BankLineID
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.UnmatchLine(&req)
+	if err != nil {
+		b.log.Error("Error unmatching statement line", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateLineCashFlow godoc
+// @Summary Create Cash Flow From Line
+// @Description Record an unmatched line, such as a bank fee, as a manual cash flow entry of the statement wallet on the line date and match it
+// @Tags Bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Line ID"
+// @Param BankLineCashFlow body entity.BankLineCashFlow true "Cash category, description and user"
+// @Success 200 {object} entity.BankStatementLine
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /bank/lines/{id}/cash-flow [post]
+func (b *bankRoutes) CreateLineCashFlow(c *gin.Context) {
+	var req entity.BankLineCashFlow
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		b.log.Error("Error binding JSON in CreateLineCashFlow", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.CreateLineCashFlow(&req)
+	if err != nil {
+		b.log.Error("Error creating cash flow from statement line", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type budgetsRoutes struct {
+	useCase *usecase.BudgetsUseCase
+	log     *slog.Logger
+}
+
+func newBudgetsRoutes(router *gin.RouterGroup, us *usecase.BudgetsUseCase, log *slog.Logger) {
+	budgets := &budgetsRoutes{useCase: us, log: log}
+
+	// Budgets routes
+	router.GET("", budgets.GetBudgets)
+	router.POST("", budgets.CreateBudget)
+	router.GET("/report", budgets.GetBudgetReport)
+	router.GET("/alerts", budgets.GetBudgetAlerts)
+	router.POST("/check", budgets.CheckBudgets)
+	router.PUT("/:id", budgets.UpdateBudget)
+	router.DELETE("/:id", budgets.DeleteBudget)
+}
+
+// GetBudgets godoc
+// @Summary List Budgets
+// @Description Retrieve budgets filtered by month (YYYY-MM) and cash category
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param BudgetFilter query entity.BudgetFilter false "Filter"
+// @Success 200 {object} entity.BudgetList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /budgets [get]
+func (b *budgetsRoutes) GetBudgets(c *gin.Context) {
+	var req entity.BudgetFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		b.log.Error("Error binding query parameters in GetBudgets", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := b.useCase.GetBudgets(&req)
+	if err != nil {
+		b.log.Error("Error retrieving budgets", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateBudget godoc
+// @Summary Create Budget
+// @Description Set the monthly spending plan of a cash category in the base currency, replacing an existing one for the month
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param BudgetRequest body entity.BudgetRequest true "Budget data, period as YYYY-MM"
+// @Success 200 {object} entity.Budget
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /budgets [post]
+func (b *budgetsRoutes) CreateBudget(c *gin.Context) {
+	var req entity.BudgetRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		b.log.Error("Error binding JSON in CreateBudget", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := b.useCase.CreateBudget(&req)
+	if err != nil {
+		b.log.Error("Error creating budget", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetBudgetReport godoc
+// @Summary Budget vs Actual
+// @Description Compare the budgets of a month, the current one by default, with expenses from the cash flow
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param BudgetFilter query entity.BudgetFilter false "Period as YYYY-MM"
+// @Success 200 {object} entity.BudgetReport
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /budgets/report [get]
+func (b *budgetsRoutes) GetBudgetReport(c *gin.Context) {
+	var req entity.BudgetFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		b.log.Error("Error binding query parameters in GetBudgetReport", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := b.useCase.GetBudgetReport(&req)
+	if err != nil {
+		b.log.Error("Error building budget report", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetBudgetAlerts godoc
+// @Summary List Budget Alerts
+// @Description Retrieve the log of alerts sent when spending crossed 80% or 100% of a budget
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param BudgetFilter query entity.BudgetFilter false "Filter"
+// @Success 200 {object} entity.BudgetAlertList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /budgets/alerts [get]
+func (b *budgetsRoutes) GetBudgetAlerts(c *gin.Context) {
+	var req entity.BudgetFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		b.log.Error("Error binding query parameters in GetBudgetAlerts", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := b.useCase.GetBudgetAlerts(&req)
+	if err != nil {
+		b.log.Error("Error retrieving budget alerts", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CheckBudgets godoc
+// @Summary Check Budgets
+// @Description Send overspend alerts for the current month without waiting for the hourly job
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.BudgetCheckResult
+// @Failure 500 {object} entity.Error
+// @Router /budgets/check [post]
+func (b *budgetsRoutes) CheckBudgets(c *gin.Context) {
+	res, err := b.useCase.CheckBudgets()
+	if err != nil {
+		b.log.Error("Error checking budgets", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateBudget godoc
+// @Summary Update Budget
+// @Description Change the planned amount of a budget
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param id path string true "Budget ID"
+// @Param BudgetRequest body entity.BudgetRequest true "Budget amount"
+// @Success 200 {object} entity.Budget
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /budgets/{id} [put]
+func (b *budgetsRoutes) UpdateBudget(c *gin.Context) {
+	var req entity.BudgetRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		b.log.Error("Error binding JSON in UpdateBudget", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.UpdateBudget(&req)
+	if err != nil {
+		b.log.Error("Error updating budget", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteBudget godoc
+// @Summary Delete Budget
+// @Description Delete a budget with its alerts
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param id path string true "Budget ID"
+// @Success 200 {object} entity.Message
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /budgets/{id} [delete]
+func (b *budgetsRoutes) DeleteBudget(c *gin.Context) {
+	var req entity.BudgetID
+	req.ID = c.Param("id")
+
+	res, err := b.useCase.DeleteBudget(&req)
+	if err != nil {
+		b.log.Error("Error deleting budget", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,311 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type cashRoutes struct {
+	useCase *usecase.CashUseCase
+	log     *slog.Logger
+}
+
+func newCashRoutes(router *gin.RouterGroup, us *usecase.CashUseCase, log *slog.Logger) {
+	cash := &cashRoutes{useCase: us, log: log}
+
+	// Cash categories routes
+	router.GET("/categories", cash.GetCategories)
+	router.POST("/categories", cash.CreateCategory)
+	router.PUT("/categories/:id", cash.UpdateCategory)
+	router.DELETE("/categories/:id", cash.DeleteCategory)
+
+	// Cash flow routes
+	router.GET("/flow", cash.GetCashFlowList)
+	router.POST("/flow", cash.CreateCashFlow)
+	router.GET("/flow/:id", cash.GetCashFlow)
+	router.PUT("/flow/:id", cash.UpdateCashFlow)
+	router.DELETE("/flow/:id", cash.DeleteCashFlow)
+	router.GET("/balance", cash.GetCashBalance)
+}
+
+// GetCategories godoc
+// @Summary List Cash Categories
+// @Description Retrieve system and user cash categories
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.CashCategoryList
+// @Failure 500 {object} entity.Error
+// @Router /cash/categories [get]
+func (h *cashRoutes) GetCategories(c *gin.Context) {
+	res, err := h.useCase.GetCategories()
+	if err != nil {
+		h.log.Error("Error retrieving cash categories", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateCategory godoc
+// @Summary Create Cash Category
+// @Description Add a cash category such as rent, salaries or utilities
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Param CashCategoryRequest body entity.CashCategoryRequest true "Category data"
+// @Success 200 {object} entity.CashCategory
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /cash/categories [post]
+func (h *cashRoutes) CreateCategory(c *gin.Context) {
+	var req entity.CashCategoryRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Error binding JSON in CreateCategory", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.useCase.CreateCategory(&req)
+	if err != nil {
+		h.log.Error("Error creating cash category", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateCategory godoc
+// @Summary Update Cash Category
+// @Description Rename a user cash category or change its transaction type, system categories are read-only
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param CashCategoryRequest body entity.CashCategoryRequest true "Category data"
+// @Success 200 {object} entity.CashCategory
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /cash/categories/{id} [put]
+func (h *cashRoutes) UpdateCategory(c *gin.Context) {
+	var req entity.CashCategoryRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Error binding JSON in UpdateCategory", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := h.useCase.UpdateCategory(&req)
+	if err != nil {
+		h.log.Error("Error updating cash category", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteCategory godoc
+// @Summary Delete Cash Category
+// @Description Delete a user cash category that has no entries
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Success 200 {object} entity.Message
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /cash/categories/{id} [delete]
+func (h *cashRoutes) DeleteCategory(c *gin.Context) {
+	var req entity.CashCategoryID
+	req.ID = c.Param("id")
+
+	res, err := h.useCase.DeleteCategory(&req)
+	if err != nil {
+		h.log.Error("Error deleting cash category", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetCashFlowList godoc
+// @Summary List Cash Flow
+// @Description Retrieve manual and automatic cash flow entries with income and expense totals
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Param CashFlowFilter query entity.CashFlowFilter false "Cash flow filter parameters"
+// @Success 200 {object} entity.CashFlowList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /cash/flow [get]
+func (h *cashRoutes) GetCashFlowList(c *gin.Context) {
+	var req entity.CashFlowFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log.Error("Error binding query parameters in GetCashFlowList", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.useCase.GetCashFlowList(&req)
+	if err != nil {
+		h.log.Error("Error retrieving cash flow list", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateCashFlow godoc
+// @Summary Create Cash Flow Entry
+// @Description Record a manual income or expense, e.g. rent, salaries or utilities
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Param CashFlowRequest body entity.CashFlowRequest true "Entry data"
+// @Success 200 {object} entity.CashFlow
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /cash/flow [post]
+func (h *cashRoutes) CreateCashFlow(c *gin.Context) {
+	var req entity.CashFlowRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Error binding JSON in CreateCashFlow", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.useCase.CreateCashFlow(&req)
+	if err != nil {
+		h.log.Error("Error creating cash flow entry", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetCashFlow godoc
+// @Summary Get Cash Flow Entry
+// @Description Retrieve a cash flow entry by ID
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Param id path string true "Entry ID"
+// @Success 200 {object} entity.CashFlow
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /cash/flow/{id} [get]
+func (h *cashRoutes) GetCashFlow(c *gin.Context) {
+	var req entity.CashFlowID
+	req.ID = c.Param("id")
+
+	res, err := h.useCase.GetCashFlow(&req)
+	if err != nil {
+		h.log.Error("Error retrieving cash flow entry", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateCashFlow godoc
+// @Summary Update Cash Flow Entry
+// @Description Update a manual entry, entries posted from sales, debts and supplier payments are read-only
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Param id path string true "Entry ID"
+// @Param CashFlowRequest body entity.CashFlowRequest true "Entry data"
+// @Success 200 {object} entity.CashFlow
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /cash/flow/{id} [put]
+func (h *cashRoutes) UpdateCashFlow(c *gin.Context) {
+	var req entity.CashFlowRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Error binding JSON in UpdateCashFlow", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := h.useCase.UpdateCashFlow(&req)
+	if err != nil {
+		h.log.Error("Error updating cash flow entry", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteCashFlow godoc
+// @Summary Delete Cash Flow Entry
+// @Description Delete a manual cash flow entry
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Param id path string true "Entry ID"
+// @Success 200 {object} entity.Message
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /cash/flow/{id} [delete]
+func (h *cashRoutes) DeleteCashFlow(c *gin.Context) {
+	var req entity.CashFlowID
+	req.ID = c.Param("id")
+
+	res, err := h.useCase.DeleteCashFlow(&req)
+	if err != nil {
+		h.log.Error("Error deleting cash flow entry", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetCashBalance godoc
+// @Summary Cash Balance
+// @Description Income, expense and balance per payment method, optionally for a period
+// @Tags Cash
+// @Accept json
+// @Produce json
+// @Param CashFlowFilter query entity.CashFlowFilter false "Cash flow filter parameters"
+// @Success 200 {object} entity.CashBalance
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /cash/balance [get]
+func (h *cashRoutes) GetCashBalance(c *gin.Context) {
+	var req entity.CashFlowFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log.Error("Error binding query parameters in GetCashBalance", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.useCase.GetCashBalance(&req)
+	if err != nil {
+		h.log.Error("Error calculating cash balance", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type clientsRoutes struct {
+	useCase *usecase.ClientsUseCase
+	log     *slog.Logger
+}
+
+func newClientsRoutes(router *gin.RouterGroup, us *usecase.ClientsUseCase, log *slog.Logger) {
+	clients := &clientsRoutes{useCase: us, log: log}
+
+	// Clients routes
+	router.GET("/credit-report", clients.GetCreditReport)
+	router.GET("/:id", clients.GetClient)
+	router.PUT("/:id/contact", clients.UpdateClientContact)
+	router.PUT("/:id/credit-limit", clients.UpdateCreditLimit)
+	router.GET("/:id/credit-overrides", clients.GetCreditOverrides)
+	router.PUT("/:id/payment-terms", clients.UpdatePaymentTerms)
+}
+
+// GetClient godoc
+// @Summary Get Client
+// @Description Retrieve a client by ID
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Success 200 {object} entity.Client
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /clients/{id} [get]
+func (cl *clientsRoutes) GetClient(c *gin.Context) {
+	var req entity.ClientID
+	req.ID = c.Param("id")
+
+	res, err := cl.useCase.GetClient(&req)
+	if err != nil {
+		cl.log.Error("Error retrieving client", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateClientContact godoc
+// @Summary Update Client Contact
+// @Description Update the phone, Telegram chat ID and reminder language (uz or ru) of a client
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param ClientContact body entity.ClientContact true "Contact data"
+// @Success 200 {object} entity.Client
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /clients/{id}/contact [put]
+func (cl *clientsRoutes) UpdateClientContact(c *gin.Context) {
+	var req entity.ClientContact
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		cl.log.Error("Error binding JSON in UpdateClientContact", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := cl.useCase.UpdateClientContact(&req)
+	if err != nil {
+		cl.log.Error("Error updating client contact", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateCreditLimit godoc
+// @Summary Update Credit Limit
+// @Description Set the credit limit of a client, a null limit removes it
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param CreditLimitUpdate body entity.CreditLimitUpdate true "Credit limit"
+// @Success 200 {object} entity.Client
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /clients/{id}/credit-limit [put]
+func (cl *clientsRoutes) UpdateCreditLimit(c *gin.Context) {
+	var req entity.CreditLimitUpdate
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		cl.log.Error("Error binding JSON in UpdateCreditLimit", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := cl.useCase.UpdateCreditLimit(&req)
+	if err != nil {
+		cl.log.Error("Error updating credit limit", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdatePaymentTerms godoc
+// @Summary Update Payment Terms
+// @Description Set how many days after a purchase the supplier expects payment
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID"
+// @Param PaymentTerms body entity.PaymentTerms true "Payment terms"
+// @Success 200 {object} entity.Client
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /clients/{id}/payment-terms [put]
+func (cl *clientsRoutes) UpdatePaymentTerms(c *gin.Context) {
+	var req entity.PaymentTerms
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		cl.log.Error("Error binding JSON in UpdatePaymentTerms", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := cl.useCase.UpdatePaymentTerms(&req)
+	if err != nil {
+		cl.log.Error("Error updating payment terms", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetCreditOverrides godoc
+// @Summary List Credit Overrides
+// @Description Retrieve credit sales an owner allowed above the client's limit, with reasons
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Success 200 {object} entity.CreditOverrideList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /clients/{id}/credit-overrides [get]
+func (cl *clientsRoutes) GetCreditOverrides(c *gin.Context) {
+	var req entity.ClientID
+	req.ID = c.Param("id")
+
+	res, err := cl.useCase.GetCreditOverrides(&req)
+	if err != nil {
+		cl.log.Error("Error retrieving credit overrides", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetCreditReport godoc
+// @Summary Credit Limit Report
+// @Description List clients near (at or above the threshold share) or over their credit limit
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Param CreditReportFilter query entity.CreditReportFilter false "Threshold, 0.8 by default"
+// @Success 200 {object} entity.CreditReport
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /clients/credit-report [get]
+func (cl *clientsRoutes) GetCreditReport(c *gin.Context) {
+	var req entity.CreditReportFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		cl.log.Error("Error binding query parameters in GetCreditReport", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := cl.useCase.GetCreditReport(&req)
+	if err != nil {
+		cl.log.Error("Error building credit report", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
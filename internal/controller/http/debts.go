@@ -0,0 +1,259 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type debtsRoutes struct {
+	useCase *usecase.DebtsUseCase
+	log     *slog.Logger
+}
+
+func newDebtsRoutes(router *gin.RouterGroup, us *usecase.DebtsUseCase, log *slog.Logger) {
+	debts := &debtsRoutes{useCase: us, log: log}
+
+	// Debts routes
+	router.GET("", debts.GetListDebts)
+	router.GET("/aging", debts.GetDebtAging)
+	router.GET("/:id", debts.GetDebt)
+	router.POST("/:id/payments", debts.PayDebt)
+	router.GET("/:id/payments", debts.GetDebtPayments)
+	router.PUT("/:id/close", debts.CloseDebt)
+	router.POST("/:id/schedule", debts.SetDebtSchedule)
+	router.GET("/:id/schedule", debts.GetDebtSchedule)
+}
+
+// GetDebt godoc
+// @Summary Get Debt
+// @Description Retrieve a debt by ID
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param id path string true "Debt ID"
+// @Success 200 {object} entity.Debt
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /debts/{id} [get]
+func (d *debtsRoutes) GetDebt(c *gin.Context) {
+	var req entity.DebtID
+	req.ID = c.Param("id")
+
+	res, err := d.useCase.GetDebt(&req)
+	if err != nil {
+		d.log.Error("Error retrieving debt", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetListDebts godoc
+// @Summary List Debts
+// @Description Retrieve debts, e.g. all debts of a client
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param DebtFilter query entity.DebtFilter false "Debt filter parameters"
+// @Success 200 {object} entity.DebtList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /debts [get]
+func (d *debtsRoutes) GetListDebts(c *gin.Context) {
+	var req entity.DebtFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		d.log.Error("Error binding query parameters in GetListDebts", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := d.useCase.GetListDebts(&req)
+	if err != nil {
+		d.log.Error("Error retrieving debt list", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// PayDebt godoc
+// @Summary Pay Debt
+// @Description Record a payment against a debt
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param id path string true "Debt ID"
+// @Param DebtPaymentRequest body entity.DebtPaymentRequest true "Payment data"
+// @Success 201 {object} entity.Debt
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /debts/{id}/payments [post]
+func (d *debtsRoutes) PayDebt(c *gin.Context) {
+	var req entity.DebtPaymentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		d.log.Error("Error binding JSON in PayDebt", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.DebtID = c.Param("id")
+
+	res, err := d.useCase.PayDebt(&req)
+	if err != nil {
+		d.log.Error("Error paying debt", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetDebtPayments godoc
+// @Summary List Debt Payments
+// @Description Retrieve payments made against a debt
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param id path string true "Debt ID"
+// @Success 200 {object} entity.DebtPaymentList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /debts/{id}/payments [get]
+func (d *debtsRoutes) GetDebtPayments(c *gin.Context) {
+	var req entity.DebtID
+	req.ID = c.Param("id")
+
+	res, err := d.useCase.GetDebtPayments(&req)
+	if err != nil {
+		d.log.Error("Error retrieving debt payments", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CloseDebt godoc
+// @Summary Close Debt
+// @Description Settle the remaining balance of a debt and mark it fully paid
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param id path string true "Debt ID"
+// @Param DebtClose body entity.DebtClose true "Closing payment data"
+// @Success 200 {object} entity.Debt
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /debts/{id}/close [put]
+func (d *debtsRoutes) CloseDebt(c *gin.Context) {
+	var req entity.DebtClose
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		d.log.Error("Error binding JSON in CloseDebt", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := d.useCase.CloseDebt(&req)
+	if err != nil {
+		d.log.Error("Error closing debt", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SetDebtSchedule godoc
+// @Summary Set Debt Schedule
+// @Description Split the unpaid amount of a debt into monthly installments
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param id path string true "Debt ID"
+// @Param DebtScheduleRequest body entity.DebtScheduleRequest true "Schedule data"
+// @Success 201 {object} entity.DebtSchedule
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /debts/{id}/schedule [post]
+func (d *debtsRoutes) SetDebtSchedule(c *gin.Context) {
+	var req entity.DebtScheduleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		d.log.Error("Error binding JSON in SetDebtSchedule", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.DebtID = c.Param("id")
+
+	res, err := d.useCase.SetDebtSchedule(&req)
+	if err != nil {
+		d.log.Error("Error setting debt schedule", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetDebtSchedule godoc
+// @Summary Get Debt Schedule
+// @Description Retrieve the installment plan of a debt
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param id path string true "Debt ID"
+// @Success 200 {object} entity.DebtSchedule
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /debts/{id}/schedule [get]
+func (d *debtsRoutes) GetDebtSchedule(c *gin.Context) {
+	var req entity.DebtID
+	req.ID = c.Param("id")
+
+	res, err := d.useCase.GetDebtSchedule(&req)
+	if err != nil {
+		d.log.Error("Error retrieving debt schedule", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetDebtAging godoc
+// @Summary Debt Aging Report
+// @Description Unpaid debt grouped by days overdue (current, 1-30, 31-60, 61-90, 90+) per client or per seller
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param AgingFilter query entity.AgingFilter false "Grouping: client (default) or seller"
+// @Success 200 {object} entity.AgingReport
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /debts/aging [get]
+func (d *debtsRoutes) GetDebtAging(c *gin.Context) {
+	var req entity.AgingFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		d.log.Error("Error binding query parameters in GetDebtAging", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := d.useCase.GetDebtAging(&req)
+	if err != nil {
+		d.log.Error("Error building debt aging report", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type lotsRoutes struct {
+	useCase *usecase.LotsUseCase
+	log     *slog.Logger
+}
+
+func newLotsRoutes(router *gin.RouterGroup, us *usecase.LotsUseCase, log *slog.Logger) {
+	lots := &lotsRoutes{useCase: us, log: log}
+
+	// Lot routes
+	router.GET("", lots.GetLots)
+	router.GET("/expiring", lots.GetExpiringLots)
+	router.GET("/settings", lots.GetLotSettings)
+	router.PUT("/settings", lots.SetLotSettings)
+}
+
+// GetLots godoc
+// @Summary List Lots
+// @Description Retrieve the lots of products with their expiry dates and what is left of them
+// @Tags Lot
+// @Accept json
+// @Produce json
+// @Param LotFilter query entity.LotFilter false "Filter"
+// @Success 200 {object} entity.LotList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /lots [get]
+func (l *lotsRoutes) GetLots(c *gin.Context) {
+	var req entity.LotFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		l.log.Error("Error binding query parameters in GetLots", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := l.useCase.GetLots(&req)
+	if err != nil {
+		l.log.Error("Error retrieving lots", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetExpiringLots godoc
+// @Summary Expiring Lots
+// @Description Report the lots in stock expiring within N days, 30 by default, together with those already expired
+// @Tags Lot
+// @Accept json
+// @Produce json
+// @Param ExpiringLotsFilter query entity.ExpiringLotsFilter false "Filter"
+// @Success 200 {object} entity.ExpiringLots
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /lots/expiring [get]
+func (l *lotsRoutes) GetExpiringLots(c *gin.Context) {
+	var req entity.ExpiringLotsFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		l.log.Error("Error binding query parameters in GetExpiringLots", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := l.useCase.GetExpiringLots(&req)
+	if err != nil {
+		l.log.Error("Error retrieving expiring lots", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetLotSettings godoc
+// @Summary Get Lot Settings
+// @Description Retrieve whether sales from expired lots are blocked
+// @Tags Lot
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.LotSettings
+// @Failure 500 {object} entity.Error
+// @Router /lots/settings [get]
+func (l *lotsRoutes) GetLotSettings(c *gin.Context) {
+	res, err := l.useCase.GetLotSettings()
+	if err != nil {
+		l.log.Error("Error retrieving lot settings", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SetLotSettings godoc
+// @Summary Set Lot Settings
+// @Description Block or allow sales from expired lots. While blocked, goods in expired lots do not count as available
+// @Tags Lot
+// @Accept json
+// @Produce json
+// @Param LotSettings body entity.LotSettings true "Lot settings"
+// @Success 200 {object} entity.LotSettings
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /lots/settings [put]
+func (l *lotsRoutes) SetLotSettings(c *gin.Context) {
+	var req entity.LotSettings
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		l.log.Error("Error binding JSON in SetLotSettings", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := l.useCase.SetLotSettings(&req)
+	if err != nil {
+		l.log.Error("Error saving lot settings", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type payablesRoutes struct {
+	useCase *usecase.PayablesUseCase
+	log     *slog.Logger
+}
+
+func newPayablesRoutes(router *gin.RouterGroup, us *usecase.PayablesUseCase, log *slog.Logger) {
+	payables := &payablesRoutes{useCase: us, log: log}
+
+	// Payables routes
+	router.GET("", payables.GetSupplierBalances)
+	router.GET("/purchases", payables.GetPayables)
+	router.GET("/aging", payables.GetPayablesAging)
+	router.POST("/payments", payables.PaySupplier)
+	router.GET("/payments", payables.GetSupplierPayments)
+}
+
+// GetSupplierBalances godoc
+// @Summary Supplier Balances
+// @Description Retrieve the amount owed to each supplier, including the overdue part
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.SupplierBalanceList
+// @Failure 500 {object} entity.Error
+// @Router /payables [get]
+func (p *payablesRoutes) GetSupplierBalances(c *gin.Context) {
+	res, err := p.useCase.GetSupplierBalances()
+	if err != nil {
+		p.log.Error("Error retrieving supplier balances", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetPayables godoc
+// @Summary List Payables
+// @Description Retrieve purchases that are not paid in full, optionally for one supplier
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param PayableFilter query entity.PayableFilter false "Payable filter parameters"
+// @Success 200 {object} entity.PayableList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /payables/purchases [get]
+func (p *payablesRoutes) GetPayables(c *gin.Context) {
+	var req entity.PayableFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		p.log.Error("Error binding query parameters in GetPayables", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := p.useCase.GetPayables(&req)
+	if err != nil {
+		p.log.Error("Error retrieving payables", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetPayablesAging godoc
+// @Summary Payables Aging Report
+// @Description Unpaid purchase balances per supplier bucketed into current, 1-30, 31-60, 61-90 and 90+ days past due
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.AgingReport
+// @Failure 500 {object} entity.Error
+// @Router /payables/aging [get]
+func (p *payablesRoutes) GetPayablesAging(c *gin.Context) {
+	res, err := p.useCase.GetPayablesAging()
+	if err != nil {
+		p.log.Error("Error building payables aging report", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// PaySupplier godoc
+// @Summary Pay Supplier
+// @Description Record a payment to the supplier against a purchase balance
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param SupplierPaymentRequest body entity.SupplierPaymentRequest true "Payment data"
+// @Success 200 {object} entity.SupplierPayment
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /payables/payments [post]
+func (p *payablesRoutes) PaySupplier(c *gin.Context) {
+	var req entity.SupplierPaymentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		p.log.Error("Error binding JSON in PaySupplier", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := p.useCase.PaySupplier(&req)
+	if err != nil {
+		p.log.Error("Error paying supplier", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetSupplierPayments godoc
+// @Summary List Supplier Payments
+// @Description Retrieve payments made to suppliers, by supplier or purchase
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param SupplierPaymentFilter query entity.SupplierPaymentFilter false "Payment filter parameters"
+// @Success 200 {object} entity.SupplierPaymentList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /payables/payments [get]
+func (p *payablesRoutes) GetSupplierPayments(c *gin.Context) {
+	var req entity.SupplierPaymentFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		p.log.Error("Error binding query parameters in GetSupplierPayments", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := p.useCase.GetSupplierPayments(&req)
+	if err != nil {
+		p.log.Error("Error retrieving supplier payments", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
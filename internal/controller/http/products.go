@@ -28,6 +28,17 @@ func newProductRoutes(router *gin.RouterGroup, us *usecase.ProductsUseCase, log
 	router.GET("", product.GetProductList)
 	router.PUT("/:id", product.UpdateProduct)
 	router.DELETE("/:id", product.DeleteProduct)
+
+	// -------------- product variant router ------------------
+	router.POST("/:id/variants/generate", product.GenerateVariants)
+	router.POST("/:id/variants", product.CreateVariant)
+	router.PUT("/:id/price", product.SetVariantPrice)
+
+	// -------------- product costing router ------------------
+	router.GET("/costing-method", product.GetCostingMethod)
+	router.PUT("/costing-method", product.SetCostingMethod)
+	router.GET("/:id/cost-layers", product.GetCostLayers)
+	router.GET("/:id/movements", product.GetInventoryHistory)
 }
 
 // CreateCategory godoc
@@ -215,15 +226,15 @@ func (p *productRoutes) GetProduct(c *gin.Context) {
 // @Failure 500 {object} entity.Error
 // @Router /products [get]
 func (p *productRoutes) GetProductList(c *gin.Context) {
-	var req *entity.FilterProduct
+	var req entity.FilterProduct
 
-	if err := c.ShouldBindQuery(req); err != nil {
+	if err := c.ShouldBindQuery(&req); err != nil {
 		p.log.Error("Error in getting from body", "error", err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	res, err := p.useCase.GetProductList(req)
+	res, err := p.useCase.GetProductList(&req)
 	if err != nil {
 		p.log.Error("Error in getting product", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -246,9 +257,9 @@ func (p *productRoutes) GetProductList(c *gin.Context) {
 // @Failure 500 {object} entity.Error
 // @Router /products/{id} [put]
 func (p *productRoutes) UpdateProduct(c *gin.Context) {
-	var req *entity.ProductUpdate
+	var req entity.ProductUpdate
 
-	if err := c.ShouldBindJSON(req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		p.log.Error("Error in getting from body", "error", err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -257,7 +268,7 @@ func (p *productRoutes) UpdateProduct(c *gin.Context) {
 	id := c.Param("id")
 	req.ID = id
 
-	res, err := p.useCase.UpdateProduct(req)
+	res, err := p.useCase.UpdateProduct(&req)
 	if err != nil {
 		p.log.Error("Error in updating product", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -293,3 +304,204 @@ func (p *productRoutes) DeleteProduct(c *gin.Context) {
 
 	c.JSON(http.StatusOK, res)
 }
+
+// GetCostingMethod godoc
+// @Summary Get Costing Method
+// @Description Retrieve how sold goods are costed: fifo or average
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.CostingMethod
+// @Failure 500 {object} entity.Error
+// @Router /products/costing-method [get]
+func (p *productRoutes) GetCostingMethod(c *gin.Context) {
+	res, err := p.useCase.GetCostingMethod()
+	if err != nil {
+		p.log.Error("Error in getting costing method", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SetCostingMethod godoc
+// @Summary Set Costing Method
+// @Description Cost goods sold from now on by FIFO, from the oldest purchase layers, or by moving weighted average; past sales keep their cost
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param CostingMethod body entity.CostingMethod true "fifo or average"
+// @Success 200 {object} entity.CostingMethod
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /products/costing-method [put]
+func (p *productRoutes) SetCostingMethod(c *gin.Context) {
+	var req entity.CostingMethod
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		p.log.Error("Error in binding costing method", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := p.useCase.SetCostingMethod(&req)
+	if err != nil {
+		p.log.Error("Error in setting costing method", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetCostLayers godoc
+// @Summary Get Product Cost Layers
+// @Description Retrieve the stock of a product by purchase cost layer in the base currency, with its average cost and value
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} entity.CostLayerList
+// @Failure 500 {object} entity.Error
+// @Router /products/{id}/cost-layers [get]
+func (p *productRoutes) GetCostLayers(c *gin.Context) {
+	req := &entity.ProductID{ID: c.Param("id")}
+
+	res, err := p.useCase.GetCostLayers(req)
+	if err != nil {
+		p.log.Error("Error in getting cost layers", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetInventoryHistory godoc
+// @Summary Get Product Movements
+// @Description Retrieve the inventory movements of a product for a date range, the current month by default, with the opening and closing stock
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param InventoryMovementFilter query entity.InventoryMovementFilter false "Dates as YYYY-MM-DD, both included"
+// @Success 200 {object} entity.InventoryHistory
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /products/{id}/movements [get]
+func (p *productRoutes) GetInventoryHistory(c *gin.Context) {
+	var req entity.InventoryMovementFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		p.log.Error("Error in getting from query", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ProductID = c.Param("id")
+
+	res, err := p.useCase.GetInventoryHistory(&req)
+	if err != nil {
+		p.log.Error("Error in getting inventory movements", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GenerateVariants godoc
+// @Summary Generate Product Variants
+// @Description Add a variant of a product for every combination of attribute values, e.g. each size in each color. Variants get SKUs made from the product SKU and their values and follow the product prices. Existing combinations are kept
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param Variants body entity.VariantGenerateRequest true "Attribute values"
+// @Success 201 {object} entity.Product
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /products/{id}/variants/generate [post]
+func (p *productRoutes) GenerateVariants(c *gin.Context) {
+	var req entity.VariantGenerateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		p.log.Error("Error in getting from body", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ProductID = c.Param("id")
+
+	res, err := p.useCase.GenerateVariants(&req)
+	if err != nil {
+		p.log.Error("Error in generating variants", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// CreateVariant godoc
+// @Summary Create Product Variant
+// @Description Add a single variant of a product. Prices given here override the product prices, prices left out follow them
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param Variant body entity.VariantRequest true "Variant data"
+// @Success 201 {object} entity.Product
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /products/{id}/variants [post]
+func (p *productRoutes) CreateVariant(c *gin.Context) {
+	var req entity.VariantRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		p.log.Error("Error in getting from body", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ProductID = c.Param("id")
+
+	res, err := p.useCase.CreateVariant(&req)
+	if err != nil {
+		p.log.Error("Error in creating variant", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// SetVariantPrice godoc
+// @Summary Set Variant Price
+// @Description Override the prices of a variant, or set inherit to make it follow the prices of its product again
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Variant ID"
+// @Param Price body entity.VariantPrice true "Variant prices"
+// @Success 200 {object} entity.Product
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /products/{id}/price [put]
+func (p *productRoutes) SetVariantPrice(c *gin.Context) {
+	var req entity.VariantPrice
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		p.log.Error("Error in getting from body", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := p.useCase.SetVariantPrice(&req)
+	if err != nil {
+		p.log.Error("Error in setting variant price", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
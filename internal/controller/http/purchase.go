@@ -22,6 +22,12 @@ func newPurchaseRoutes(router *gin.RouterGroup, us *usecase.PurchaseUseCase, log
 	router.GET("/:id", purchase.GetPurchase)
 	router.GET("", purchase.GetListPurchase)
 	router.DELETE("/:id", purchase.DeletePurchase)
+
+	// Supplier return routes
+	router.POST("/returns", purchase.CreateSupplierReturn)
+	router.GET("/returns", purchase.GetSupplierReturnList)
+	router.GET("/returns/:id", purchase.GetSupplierReturn)
+	router.GET("/:id/returnable", purchase.GetReturnablePurchaseItems)
 }
 
 // CreatePurchase godoc
@@ -167,3 +173,111 @@ func (p *purchaseRoutes) DeletePurchase(c *gin.Context) {
 
 	c.JSON(http.StatusOK, res)
 }
+
+// CreateSupplierReturn godoc
+// @Summary Create Supplier Return
+// @Description Send lines of a purchase back to the supplier. The goods leave stock, the unpaid balance of the purchase is reduced first and the rest is recorded as a refund from the supplier. The purchase itself is kept
+// @Tags Purchase
+// @Accept json
+// @Produce json
+// @Param SupplierReturnRequest body entity.SupplierReturnRequest true "Purchase lines to return"
+// @Success 201 {object} entity.SupplierReturn
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /purchase/returns [post]
+func (p *purchaseRoutes) CreateSupplierReturn(c *gin.Context) {
+	var req entity.SupplierReturnRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		p.log.Error("Error binding JSON in CreateSupplierReturn", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := p.useCase.CreateSupplierReturn(&req)
+	if err != nil {
+		p.log.Error("Error creating supplier return", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetSupplierReturnList godoc
+// @Summary List Supplier Returns
+// @Description Retrieve supplier returns filtered by purchase, supplier and dates
+// @Tags Purchase
+// @Accept json
+// @Produce json
+// @Param SupplierReturnFilter query entity.SupplierReturnFilter false "Filter"
+// @Success 200 {object} entity.SupplierReturnList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /purchase/returns [get]
+func (p *purchaseRoutes) GetSupplierReturnList(c *gin.Context) {
+	var req entity.SupplierReturnFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		p.log.Error("Error binding query parameters in GetSupplierReturnList", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := p.useCase.GetSupplierReturnList(&req)
+	if err != nil {
+		p.log.Error("Error retrieving supplier returns", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetSupplierReturn godoc
+// @Summary Get Supplier Return
+// @Description Retrieve a supplier return with its lines
+// @Tags Purchase
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier return ID"
+// @Success 200 {object} entity.SupplierReturn
+// @Failure 500 {object} entity.Error
+// @Router /purchase/returns/{id} [get]
+func (p *purchaseRoutes) GetSupplierReturn(c *gin.Context) {
+	var req entity.SupplierReturnID
+	req.ID = c.Param("id")
+
+	res, err := p.useCase.GetSupplierReturn(&req)
+	if err != nil {
+		p.log.Error("Error retrieving supplier return", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetReturnablePurchaseItems godoc
+// @Summary List Returnable Purchase Lines
+// @Description Retrieve the lines of a purchase with how much of each can still be returned to the supplier
+// @Tags Purchase
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase ID"
+// @Success 200 {object} entity.ReturnablePurchaseItemList
+// @Failure 500 {object} entity.Error
+// @Router /purchase/{id}/returnable [get]
+func (p *purchaseRoutes) GetReturnablePurchaseItems(c *gin.Context) {
+	var req entity.PurchaseID
+	req.ID = c.Param("id")
+
+	res, err := p.useCase.GetReturnablePurchaseItems(&req)
+	if err != nil {
+		p.log.Error("Error retrieving returnable purchase lines", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
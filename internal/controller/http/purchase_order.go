@@ -0,0 +1,272 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type purchaseOrdersRoutes struct {
+	useCase *usecase.PurchaseOrdersUseCase
+	log     *slog.Logger
+}
+
+func newPurchaseOrdersRoutes(router *gin.RouterGroup, us *usecase.PurchaseOrdersUseCase, log *slog.Logger) {
+	orders := &purchaseOrdersRoutes{useCase: us, log: log}
+
+	// Purchase order routes
+	router.POST("", orders.CreatePurchaseOrder)
+	router.GET("", orders.GetPurchaseOrders)
+	router.GET("/:id", orders.GetPurchaseOrder)
+	router.PUT("/:id", orders.UpdatePurchaseOrder)
+	router.GET("/:id/print", orders.PrintPurchaseOrder)
+	router.POST("/:id/send", orders.SendPurchaseOrder)
+	router.POST("/:id/receipts", orders.ReceivePurchaseOrder)
+	router.POST("/:id/close", orders.ClosePurchaseOrder)
+	router.POST("/:id/cancel", orders.CancelPurchaseOrder)
+}
+
+// CreatePurchaseOrder godoc
+// @Summary Create Purchase Order
+// @Description Draft an order to a supplier. Stock only changes when the order is received
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param PurchaseOrderRequest body entity.PurchaseOrderRequest true "Supplier and lines"
+// @Success 201 {object} entity.PurchaseOrder
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /purchase-orders [post]
+func (p *purchaseOrdersRoutes) CreatePurchaseOrder(c *gin.Context) {
+	var req entity.PurchaseOrderRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		p.log.Error("Error binding JSON in CreatePurchaseOrder", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := p.useCase.CreatePurchaseOrder(&req)
+	if err != nil {
+		p.log.Error("Error creating purchase order", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetPurchaseOrders godoc
+// @Summary List Purchase Orders
+// @Description Retrieve purchase orders by supplier, status and dates
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param PurchaseOrderFilter query entity.PurchaseOrderFilter false "Filter"
+// @Success 200 {object} entity.PurchaseOrderList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /purchase-orders [get]
+func (p *purchaseOrdersRoutes) GetPurchaseOrders(c *gin.Context) {
+	var req entity.PurchaseOrderFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		p.log.Error("Error binding query parameters in GetPurchaseOrders", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := p.useCase.GetPurchaseOrders(&req)
+	if err != nil {
+		p.log.Error("Error retrieving purchase orders", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetPurchaseOrder godoc
+// @Summary Get Purchase Order
+// @Description Retrieve a purchase order with its lines, the quantities received and every receipt
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Success 200 {object} entity.PurchaseOrder
+// @Failure 500 {object} entity.Error
+// @Router /purchase-orders/{id} [get]
+func (p *purchaseOrdersRoutes) GetPurchaseOrder(c *gin.Context) {
+	var req entity.PurchaseOrderID
+	req.ID = c.Param("id")
+
+	res, err := p.useCase.GetPurchaseOrder(&req)
+	if err != nil {
+		p.log.Error("Error retrieving purchase order", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdatePurchaseOrder godoc
+// @Summary Update Purchase Order
+// @Description Replace the supplier, terms and lines of a draft order
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Param PurchaseOrderRequest body entity.PurchaseOrderRequest true "Supplier and lines"
+// @Success 200 {object} entity.PurchaseOrder
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /purchase-orders/{id} [put]
+func (p *purchaseOrdersRoutes) UpdatePurchaseOrder(c *gin.Context) {
+	var req entity.PurchaseOrderRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		p.log.Error("Error binding JSON in UpdatePurchaseOrder", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := p.useCase.UpdatePurchaseOrder(&req)
+	if err != nil {
+		p.log.Error("Error updating purchase order", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// PrintPurchaseOrder godoc
+// @Summary Print Purchase Order
+// @Description Render a purchase order as an HTML page to print or send to the supplier
+// @Tags Purchase Order
+// @Produce html
+// @Param id path string true "Purchase order ID"
+// @Success 200 {string} string "HTML page"
+// @Failure 500 {object} entity.Error
+// @Router /purchase-orders/{id}/print [get]
+func (p *purchaseOrdersRoutes) PrintPurchaseOrder(c *gin.Context) {
+	var req entity.PurchaseOrderID
+	req.ID = c.Param("id")
+
+	res, err := p.useCase.PrintPurchaseOrder(&req)
+	if err != nil {
+		p.log.Error("Error printing purchase order", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", res)
+}
+
+// SendPurchaseOrder godoc
+// @Summary Send Purchase Order
+// @Description Mark a draft order as sent to the supplier, after which it can be received but not changed
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Success 200 {object} entity.PurchaseOrder
+// @Failure 500 {object} entity.Error
+// @Router /purchase-orders/{id}/send [post]
+func (p *purchaseOrdersRoutes) SendPurchaseOrder(c *gin.Context) {
+	var req entity.PurchaseOrderID
+	req.ID = c.Param("id")
+
+	res, err := p.useCase.SendPurchaseOrder(&req)
+	if err != nil {
+		p.log.Error("Error sending purchase order", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary Receive Purchase Order
+// @Description Record the quantities actually delivered per line. The receipt becomes a purchase that brings the goods into stock, and lines received short or over are flagged
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Param PurchaseOrderReceiptRequest body entity.PurchaseOrderReceiptRequest true "Received quantities and payment"
+// @Success 201 {object} entity.PurchaseOrderReceipt
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /purchase-orders/{id}/receipts [post]
+func (p *purchaseOrdersRoutes) ReceivePurchaseOrder(c *gin.Context) {
+	var req entity.PurchaseOrderReceiptRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		p.log.Error("Error binding JSON in ReceivePurchaseOrder", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.OrderID = c.Param("id")
+
+	res, err := p.useCase.ReceivePurchaseOrder(&req)
+	if err != nil {
+		p.log.Error("Error receiving purchase order", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// ClosePurchaseOrder godoc
+// @Summary Close Purchase Order
+// @Description Close a partially received order without waiting for the rest of the goods
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Success 200 {object} entity.PurchaseOrder
+// @Failure 500 {object} entity.Error
+// @Router /purchase-orders/{id}/close [post]
+func (p *purchaseOrdersRoutes) ClosePurchaseOrder(c *gin.Context) {
+	var req entity.PurchaseOrderID
+	req.ID = c.Param("id")
+
+	res, err := p.useCase.ClosePurchaseOrder(&req)
+	if err != nil {
+		p.log.Error("Error closing purchase order", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CancelPurchaseOrder godoc
+// @Summary Cancel Purchase Order
+// @Description Cancel a draft or sent order nothing was received against
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Success 200 {object} entity.PurchaseOrder
+// @Failure 500 {object} entity.Error
+// @Router /purchase-orders/{id}/cancel [post]
+func (p *purchaseOrdersRoutes) CancelPurchaseOrder(c *gin.Context) {
+	var req entity.PurchaseOrderID
+	req.ID = c.Param("id")
+
+	res, err := p.useCase.CancelPurchaseOrder(&req)
+	if err != nil {
+		p.log.Error("Error cancelling purchase order", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type ratesRoutes struct {
+	useCase *usecase.RatesUseCase
+	log     *slog.Logger
+}
+
+func newRatesRoutes(router *gin.RouterGroup, us *usecase.RatesUseCase, log *slog.Logger) {
+	rates := &ratesRoutes{useCase: us, log: log}
+
+	// Exchange rates routes
+	router.GET("", rates.GetRates)
+	router.POST("", rates.AddRate)
+	router.POST("/sync", rates.SyncRates)
+	router.GET("/base-currency", rates.GetBaseCurrency)
+	router.PUT("/base-currency", rates.SetBaseCurrency)
+}
+
+// GetRates godoc
+// @Summary List Exchange Rates
+// @Description Retrieve exchange rates to the base currency, filtered by currency and date range
+// @Tags Rates
+// @Accept json
+// @Produce json
+// @Param ExchangeRateFilter query entity.ExchangeRateFilter false "Filter"
+// @Success 200 {object} entity.ExchangeRateList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /rates [get]
+func (r *ratesRoutes) GetRates(c *gin.Context) {
+	var req entity.ExchangeRateFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		r.log.Error("Error binding query parameters in GetRates", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.GetRates(&req)
+	if err != nil {
+		r.log.Error("Error retrieving exchange rates", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// AddRate godoc
+// @Summary Add Exchange Rate
+// @Description Set the rate of a currency as base currency units per unit for a day, today by default
+// @Tags Rates
+// @Accept json
+// @Produce json
+// @Param ExchangeRateRequest body entity.ExchangeRateRequest true "Exchange rate"
+// @Success 200 {object} entity.ExchangeRate
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /rates [post]
+func (r *ratesRoutes) AddRate(c *gin.Context) {
+	var req entity.ExchangeRateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		r.log.Error("Error binding JSON in AddRate", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.AddRate(&req)
+	if err != nil {
+		r.log.Error("Error adding exchange rate", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SyncRates godoc
+// @Summary Sync Exchange Rates
+// @Description Load today's rates from the configured rate provider
+// @Tags Rates
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.RateSyncResult
+// @Failure 500 {object} entity.Error
+// @Router /rates/sync [post]
+func (r *ratesRoutes) SyncRates(c *gin.Context) {
+	res, err := r.useCase.SyncRates()
+	if err != nil {
+		r.log.Error("Error syncing exchange rates", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetBaseCurrency godoc
+// @Summary Get Base Currency
+// @Description Retrieve the currency all reports are converted to
+// @Tags Rates
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.BaseCurrency
+// @Failure 500 {object} entity.Error
+// @Router /rates/base-currency [get]
+func (r *ratesRoutes) GetBaseCurrency(c *gin.Context) {
+	res, err := r.useCase.GetBaseCurrency()
+	if err != nil {
+		r.log.Error("Error retrieving base currency", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SetBaseCurrency godoc
+// @Summary Set Base Currency
+// @Description Change the base currency, allowed only before any exchange rates or foreign currency documents exist
+// @Tags Rates
+// @Accept json
+// @Produce json
+// @Param BaseCurrency body entity.BaseCurrency true "Base currency"
+// @Success 200 {object} entity.BaseCurrency
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /rates/base-currency [put]
+func (r *ratesRoutes) SetBaseCurrency(c *gin.Context) {
+	var req entity.BaseCurrency
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		r.log.Error("Error binding JSON in SetBaseCurrency", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.SetBaseCurrency(&req)
+	if err != nil {
+		r.log.Error("Error setting base currency", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
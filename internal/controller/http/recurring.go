@@ -0,0 +1,211 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type recurringRoutes struct {
+	useCase *usecase.RecurringUseCase
+	log     *slog.Logger
+}
+
+func newRecurringRoutes(router *gin.RouterGroup, us *usecase.RecurringUseCase, log *slog.Logger) {
+	recurring := &recurringRoutes{useCase: us, log: log}
+
+	// Recurring expenses routes
+	router.GET("", recurring.GetRecurringExpenses)
+	router.POST("", recurring.CreateRecurringExpense)
+	router.POST("/run", recurring.PostDueExpenses)
+	router.GET("/:id", recurring.GetRecurringExpense)
+	router.PUT("/:id", recurring.UpdateRecurringExpense)
+	router.DELETE("/:id", recurring.DeleteRecurringExpense)
+	router.GET("/:id/preview", recurring.PreviewRecurringExpense)
+}
+
+// GetRecurringExpenses godoc
+// @Summary List Recurring Expenses
+// @Description Retrieve all recurring expense templates with their last posting time
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.RecurringExpenseList
+// @Failure 500 {object} entity.Error
+// @Router /recurring-expenses [get]
+func (r *recurringRoutes) GetRecurringExpenses(c *gin.Context) {
+	res, err := r.useCase.GetRecurringExpenses()
+	if err != nil {
+		r.log.Error("Error retrieving recurring expenses", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateRecurringExpense godoc
+// @Summary Create Recurring Expense
+// @Description Add an expense template posted on a schedule: a cron expression ("0 9 1 * *") or a monthly day ("monthly:1"); days past the end of a month post on its last day
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param RecurringExpenseRequest body entity.RecurringExpenseRequest true "Recurring expense data"
+// @Success 200 {object} entity.RecurringExpense
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /recurring-expenses [post]
+func (r *recurringRoutes) CreateRecurringExpense(c *gin.Context) {
+	var req entity.RecurringExpenseRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		r.log.Error("Error binding JSON in CreateRecurringExpense", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.CreateRecurringExpense(&req)
+	if err != nil {
+		r.log.Error("Error creating recurring expense", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// PostDueExpenses godoc
+// @Summary Post Due Recurring Expenses
+// @Description Post all due recurring expenses without waiting for the hourly job
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.RecurringRunResult
+// @Failure 500 {object} entity.Error
+// @Router /recurring-expenses/run [post]
+func (r *recurringRoutes) PostDueExpenses(c *gin.Context) {
+	res, err := r.useCase.PostDueExpenses()
+	if err != nil {
+		r.log.Error("Error posting recurring expenses", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetRecurringExpense godoc
+// @Summary Get Recurring Expense
+// @Description Retrieve a recurring expense template by ID
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring expense ID"
+// @Success 200 {object} entity.RecurringExpense
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /recurring-expenses/{id} [get]
+func (r *recurringRoutes) GetRecurringExpense(c *gin.Context) {
+	var req entity.RecurringExpenseID
+	req.ID = c.Param("id")
+
+	res, err := r.useCase.GetRecurringExpense(&req)
+	if err != nil {
+		r.log.Error("Error retrieving recurring expense", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateRecurringExpense godoc
+// @Summary Update Recurring Expense
+// @Description Change a recurring expense template, set is_active to false to pause it; past postings are kept
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring expense ID"
+// @Param RecurringExpenseRequest body entity.RecurringExpenseRequest true "Recurring expense data"
+// @Success 200 {object} entity.RecurringExpense
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /recurring-expenses/{id} [put]
+func (r *recurringRoutes) UpdateRecurringExpense(c *gin.Context) {
+	var req entity.RecurringExpenseRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		r.log.Error("Error binding JSON in UpdateRecurringExpense", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := r.useCase.UpdateRecurringExpense(&req)
+	if err != nil {
+		r.log.Error("Error updating recurring expense", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteRecurringExpense godoc
+// @Summary Delete Recurring Expense
+// @Description Delete a recurring expense template that has never been posted
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring expense ID"
+// @Success 200 {object} entity.Message
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /recurring-expenses/{id} [delete]
+func (r *recurringRoutes) DeleteRecurringExpense(c *gin.Context) {
+	var req entity.RecurringExpenseID
+	req.ID = c.Param("id")
+
+	res, err := r.useCase.DeleteRecurringExpense(&req)
+	if err != nil {
+		r.log.Error("Error deleting recurring expense", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// PreviewRecurringExpense godoc
+// @Summary Preview Recurring Expense
+// @Description List the upcoming postings of a recurring expense, including runs still to be caught up
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring expense ID"
+// @Param RecurringPreviewFilter query entity.RecurringPreviewFilter false "Number of postings, 12 by default"
+// @Success 200 {object} entity.RecurringPreview
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /recurring-expenses/{id}/preview [get]
+func (r *recurringRoutes) PreviewRecurringExpense(c *gin.Context) {
+	var req entity.RecurringPreviewFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		r.log.Error("Error binding query parameters in PreviewRecurringExpense", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := r.useCase.PreviewRecurringExpense(&req)
+	if err != nil {
+		r.log.Error("Error previewing recurring expense", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
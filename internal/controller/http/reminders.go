@@ -0,0 +1,186 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type remindersRoutes struct {
+	useCase *usecase.RemindersUseCase
+	log     *slog.Logger
+}
+
+func newRemindersRoutes(router *gin.RouterGroup, us *usecase.RemindersUseCase, log *slog.Logger) {
+	reminders := &remindersRoutes{useCase: us, log: log}
+
+	// Reminder rules routes
+	router.POST("/rules", reminders.CreateReminderRule)
+	router.GET("/rules", reminders.GetReminderRuleList)
+	router.PUT("/rules/:id", reminders.UpdateReminderRule)
+	router.DELETE("/rules/:id", reminders.DeleteReminderRule)
+
+	// Delivery routes
+	router.GET("/logs", reminders.GetReminderLogs)
+	router.POST("/run", reminders.SendReminders)
+}
+
+// CreateReminderRule godoc
+// @Summary Create Reminder Rule
+// @Description Create a debt reminder rule. Kind is before_due, on_due or overdue; channel is sms or telegram.
+// @Description Templates support {client}, {amount}, {due_date} and {days_overdue} placeholders.
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Param ReminderRule body entity.ReminderRule true "Rule data"
+// @Success 201 {object} entity.ReminderRule
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /reminders/rules [post]
+func (r *remindersRoutes) CreateReminderRule(c *gin.Context) {
+	var req entity.ReminderRule
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		r.log.Error("Error binding JSON in CreateReminderRule", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.CreateReminderRule(&req)
+	if err != nil {
+		r.log.Error("Error creating reminder rule", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetReminderRuleList godoc
+// @Summary List Reminder Rules
+// @Description Retrieve all debt reminder rules
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.ReminderRuleList
+// @Failure 500 {object} entity.Error
+// @Router /reminders/rules [get]
+func (r *remindersRoutes) GetReminderRuleList(c *gin.Context) {
+	res, err := r.useCase.GetReminderRuleList()
+	if err != nil {
+		r.log.Error("Error retrieving reminder rules", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateReminderRule godoc
+// @Summary Update Reminder Rule
+// @Description Replace a debt reminder rule
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Param ReminderRule body entity.ReminderRule true "Rule data"
+// @Success 200 {object} entity.ReminderRule
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /reminders/rules/{id} [put]
+func (r *remindersRoutes) UpdateReminderRule(c *gin.Context) {
+	var req entity.ReminderRule
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		r.log.Error("Error binding JSON in UpdateReminderRule", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := r.useCase.UpdateReminderRule(&req)
+	if err != nil {
+		r.log.Error("Error updating reminder rule", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteReminderRule godoc
+// @Summary Delete Reminder Rule
+// @Description Delete a reminder rule; rules with delivery history are deactivated instead
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} entity.Message
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /reminders/rules/{id} [delete]
+func (r *remindersRoutes) DeleteReminderRule(c *gin.Context) {
+	var req entity.ReminderRuleID
+	req.ID = c.Param("id")
+
+	res, err := r.useCase.DeleteReminderRule(&req)
+	if err != nil {
+		r.log.Error("Error deleting reminder rule", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetReminderLogs godoc
+// @Summary List Reminder Logs
+// @Description Retrieve every reminder delivery attempt
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Param ReminderLogFilter query entity.ReminderLogFilter false "Log filter parameters"
+// @Success 200 {object} entity.ReminderLogList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /reminders/logs [get]
+func (r *remindersRoutes) GetReminderLogs(c *gin.Context) {
+	var req entity.ReminderLogFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		r.log.Error("Error binding query parameters in GetReminderLogs", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.GetReminderLogs(&req)
+	if err != nil {
+		r.log.Error("Error retrieving reminder logs", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SendReminders godoc
+// @Summary Send Reminders
+// @Description Send all reminders due today without waiting for the daily job
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.ReminderRunResult
+// @Failure 500 {object} entity.Error
+// @Router /reminders/run [post]
+func (r *remindersRoutes) SendReminders(c *gin.Context) {
+	res, err := r.useCase.SendReminders()
+	if err != nil {
+		r.log.Error("Error sending reminders", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
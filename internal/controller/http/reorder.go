@@ -0,0 +1,182 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type reorderRoutes struct {
+	useCase *usecase.ReorderUseCase
+	log     *slog.Logger
+}
+
+func newReorderRoutes(router *gin.RouterGroup, us *usecase.ReorderUseCase, log *slog.Logger) {
+	reorder := &reorderRoutes{useCase: us, log: log}
+
+	// Reorder routes
+	router.GET("/low", reorder.GetLowStock)
+	router.POST("/low/notify", reorder.NotifyLowStock)
+	router.GET("/alerts", reorder.GetStockAlerts)
+	router.GET("/suggestions", reorder.GetReorderSuggestions)
+	router.GET("/reorder-points/:id", reorder.GetReorderPoint)
+	router.PUT("/reorder-points/:id", reorder.SetReorderPoint)
+}
+
+// GetLowStock godoc
+// @Summary Low Stock
+// @Description Retrieve the products at or below their minimum stock, out of stock first
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Param LowStockFilter query entity.LowStockFilter false "Filter"
+// @Success 200 {object} entity.LowStockList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /stock/low [get]
+func (r *reorderRoutes) GetLowStock(c *gin.Context) {
+	var req entity.LowStockFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		r.log.Error("Error binding query parameters in GetLowStock", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.GetLowStock(&req)
+	if err != nil {
+		r.log.Error("Error retrieving low stock products", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// NotifyLowStock godoc
+// @Summary Notify Low Stock
+// @Description Send the low-stock alert to the storekeepers without waiting for the daily job
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.StockAlertResult
+// @Failure 500 {object} entity.Error
+// @Router /stock/low/notify [post]
+func (r *reorderRoutes) NotifyLowStock(c *gin.Context) {
+	res, err := r.useCase.NotifyLowStock()
+	if err != nil {
+		r.log.Error("Error sending low stock alert", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetStockAlerts godoc
+// @Summary Stock Alerts
+// @Description Retrieve the latest low-stock alerts with their delivery status
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.StockAlertList
+// @Failure 500 {object} entity.Error
+// @Router /stock/alerts [get]
+func (r *reorderRoutes) GetStockAlerts(c *gin.Context) {
+	res, err := r.useCase.GetStockAlerts()
+	if err != nil {
+		r.log.Error("Error retrieving stock alerts", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetReorderSuggestions godoc
+// @Summary Suggested Purchase Orders
+// @Description Draft purchase orders grouped by supplier from recent sales velocity and reorder points
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Param ReorderFilter query entity.ReorderFilter false "Filter"
+// @Success 200 {object} entity.ReorderSuggestions
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /stock/suggestions [get]
+func (r *reorderRoutes) GetReorderSuggestions(c *gin.Context) {
+	var req entity.ReorderFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		r.log.Error("Error binding query parameters in GetReorderSuggestions", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.GetReorderSuggestions(&req)
+	if err != nil {
+		r.log.Error("Error drafting purchase orders", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetReorderPoint godoc
+// @Summary Get Reorder Point
+// @Description Retrieve the minimum stock, reorder quantity and preferred supplier of a product
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} entity.ReorderPoint
+// @Failure 500 {object} entity.Error
+// @Router /stock/reorder-points/{id} [get]
+func (r *reorderRoutes) GetReorderPoint(c *gin.Context) {
+	var req entity.ProductID
+	req.ID = c.Param("id")
+
+	res, err := r.useCase.GetReorderPoint(&req)
+	if err != nil {
+		r.log.Error("Error retrieving reorder point", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SetReorderPoint godoc
+// @Summary Set Reorder Point
+// @Description Set the minimum stock, reorder quantity and preferred supplier of a product. Without a minimum stock the product is not watched
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param ReorderPoint body entity.ReorderPoint true "Reorder point"
+// @Success 200 {object} entity.ReorderPoint
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /stock/reorder-points/{id} [put]
+func (r *reorderRoutes) SetReorderPoint(c *gin.Context) {
+	var req entity.ReorderPoint
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		r.log.Error("Error binding JSON in SetReorderPoint", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ProductID = c.Param("id")
+
+	res, err := r.useCase.SetReorderPoint(&req)
+	if err != nil {
+		r.log.Error("Error setting reorder point", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type reportsRoutes struct {
+	useCase *usecase.ReportsUseCase
+	log     *slog.Logger
+}
+
+func newReportsRoutes(router *gin.RouterGroup, us *usecase.ReportsUseCase, log *slog.Logger) {
+	reports := &reportsRoutes{useCase: us, log: log}
+
+	// Reports routes
+	router.GET("/pnl", reports.GetProfitAndLoss)
+}
+
+// GetProfitAndLoss godoc
+// @Summary Profit and Loss
+// @Description Revenue, returns, cost of goods sold, gross margin, operating expenses by cash category and net profit for a period (YYYY-MM-DD, the current month by default) in the base currency, broken down by branch, category or seller and compared with the previous period of the same length. Supplier payments are not expenses, goods are counted at cost when sold
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param PnLFilter query entity.PnLFilter false "Period and breakdown: branch (default), category or seller"
+// @Success 200 {object} entity.PnLReport
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /reports/pnl [get]
+func (r *reportsRoutes) GetProfitAndLoss(c *gin.Context) {
+	var req entity.PnLFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		r.log.Error("Error binding query parameters in GetProfitAndLoss", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.GetProfitAndLoss(&req)
+	if err != nil {
+		r.log.Error("Error building profit and loss report", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
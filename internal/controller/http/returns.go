@@ -0,0 +1,132 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type returnsRoutes struct {
+	useCase *usecase.ReturnsUseCase
+	log     *slog.Logger
+}
+
+func newReturnsRoutes(router *gin.RouterGroup, us *usecase.ReturnsUseCase, log *slog.Logger) {
+	returns := &returnsRoutes{useCase: us, log: log}
+
+	// Returns routes
+	router.POST("", returns.CreateReturn)
+	router.GET("", returns.GetReturnList)
+	router.GET("/sale/:id", returns.GetReturnableItems)
+	router.GET("/:id", returns.GetReturn)
+}
+
+// CreateReturn godoc
+// @Summary Create Sale Return
+// @Description Return some or all lines of a sale, never more than was sold. Goods are restocked or written off; the value first reduces the unpaid sale debt, the rest is refunded by uzs, usd, card or to the client's store credit
+// @Tags Returns
+// @Accept json
+// @Produce json
+// @Param SaleReturnRequest body entity.SaleReturnRequest true "Return data"
+// @Success 201 {object} entity.SaleReturn
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /returns [post]
+func (r *returnsRoutes) CreateReturn(c *gin.Context) {
+	var req entity.SaleReturnRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		r.log.Error("Error binding JSON in CreateReturn", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.CreateReturn(&req)
+	if err != nil {
+		r.log.Error("Error creating sale return", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetReturnList godoc
+// @Summary List Sale Returns
+// @Description Retrieve sale returns filtered by sale, client, user, refund method and dates (YYYY-MM-DD, both included)
+// @Tags Returns
+// @Accept json
+// @Produce json
+// @Param SaleReturnFilter query entity.SaleReturnFilter false "Filter"
+// @Success 200 {object} entity.SaleReturnList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /returns [get]
+func (r *returnsRoutes) GetReturnList(c *gin.Context) {
+	var req entity.SaleReturnFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		r.log.Error("Error binding query parameters in GetReturnList", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := r.useCase.GetReturnList(&req)
+	if err != nil {
+		r.log.Error("Error retrieving sale returns", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetReturnableItems godoc
+// @Summary List Returnable Lines
+// @Description Retrieve the lines of a sale with the quantities sold, already returned and still returnable
+// @Tags Returns
+// @Accept json
+// @Produce json
+// @Param id path string true "Sale ID"
+// @Success 200 {object} entity.ReturnableItemList
+// @Failure 500 {object} entity.Error
+// @Router /returns/sale/{id} [get]
+func (r *returnsRoutes) GetReturnableItems(c *gin.Context) {
+	var req entity.SaleID
+	req.ID = c.Param("id")
+
+	res, err := r.useCase.GetReturnableItems(&req)
+	if err != nil {
+		r.log.Error("Error retrieving returnable lines", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetReturn godoc
+// @Summary Get Sale Return
+// @Description Retrieve a sale return with its lines
+// @Tags Returns
+// @Accept json
+// @Produce json
+// @Param id path string true "Return ID"
+// @Success 200 {object} entity.SaleReturn
+// @Failure 500 {object} entity.Error
+// @Router /returns/{id} [get]
+func (r *returnsRoutes) GetReturn(c *gin.Context) {
+	var req entity.SaleReturnID
+	req.ID = c.Param("id")
+
+	res, err := r.useCase.GetReturn(&req)
+	if err != nil {
+		r.log.Error("Error retrieving sale return", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -27,9 +27,45 @@ func NewRouter(engine *gin.Engine, log *slog.Logger, ctr *controller.Controller)
 	product := engine.Group("/products")
 	purchase := engine.Group("/purchase")
 	sales := engine.Group("/sales")
+	debts := engine.Group("/debts")
+	clients := engine.Group("/clients")
+	reminders := engine.Group("/reminders")
+	payables := engine.Group("/payables")
+	cash := engine.Group("/cash")
+	wallets := engine.Group("/wallets")
+	shifts := engine.Group("/shifts")
+	rates := engine.Group("/rates")
+	recurring := engine.Group("/recurring-expenses")
+	budgets := engine.Group("/budgets")
+	reports := engine.Group("/reports")
+	bank := engine.Group("/bank")
+	returns := engine.Group("/returns")
+	stocktakes := engine.Group("/stocktakes")
+	writeOffs := engine.Group("/write-offs")
+	stock := engine.Group("/stock")
+	purchaseOrders := engine.Group("/purchase-orders")
+	lots := engine.Group("/lots")
 
 	newUserRoutes(user, ctr.Auth, log)
 	newProductRoutes(product, ctr.Product, log)
 	newPurchaseRoutes(purchase, ctr.Purchase, log)
 	newSalesRoutes(sales, ctr.Sales, log)
+	newDebtsRoutes(debts, ctr.Debts, log)
+	newClientsRoutes(clients, ctr.Clients, log)
+	newRemindersRoutes(reminders, ctr.Reminders, log)
+	newPayablesRoutes(payables, ctr.Payables, log)
+	newCashRoutes(cash, ctr.Cash, log)
+	newWalletsRoutes(wallets, ctr.Wallets, log)
+	newShiftsRoutes(shifts, ctr.Shifts, log)
+	newRatesRoutes(rates, ctr.Rates, log)
+	newRecurringRoutes(recurring, ctr.Recurring, log)
+	newBudgetsRoutes(budgets, ctr.Budgets, log)
+	newReportsRoutes(reports, ctr.Reports, log)
+	newBankRoutes(bank, ctr.Bank, log)
+	newReturnsRoutes(returns, ctr.Returns, log)
+	newStocktakesRoutes(stocktakes, ctr.Stocktakes, log)
+	newWriteOffsRoutes(writeOffs, ctr.WriteOffs, log)
+	newReorderRoutes(stock, ctr.Reorder, log)
+	newPurchaseOrdersRoutes(purchaseOrders, ctr.PurchaseOrders, log)
+	newLotsRoutes(lots, ctr.Lots, log)
 }
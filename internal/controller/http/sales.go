@@ -3,6 +3,7 @@ package http
 import (
 	"crm-admin/internal/entity"
 	"crm-admin/internal/usecase"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"log/slog"
 	"net/http"
@@ -18,6 +19,7 @@ func newSalesRoutes(router *gin.RouterGroup, us *usecase.SalesUseCase, log *slog
 
 	// Sales routes
 	router.POST("", sales.CreateSale)
+	router.GET("/daily-report", sales.GetDailyReport)
 	router.GET("/:id", sales.GetSale)
 	router.GET("", sales.GetListSales)
 	router.PUT("/:id", sales.UpdateSale)
@@ -26,13 +28,15 @@ func newSalesRoutes(router *gin.RouterGroup, us *usecase.SalesUseCase, log *slog
 
 // CreateSale godoc
 // @Summary Create Sale
-// @Description Record a new sale transaction
+// @Description Record a new sale transaction, optionally paid with several methods and currencies; cash overpayment is returned as change
 // @Tags Sales
 // @Accept json
 // @Produce json
 // @Param SaleRequest body entity.SaleRequest true "Sale data"
 // @Success 201 {object} entity.SaleResponse
 // @Failure 400 {object} entity.Error
+// @Failure 409 {object} entity.CreditLimitError
+// @Failure 409 {object} entity.StockError
 // @Failure 500 {object} entity.Error
 // @Router /sales [post]
 func (s *salesRoutes) CreateSale(c *gin.Context) {
@@ -47,6 +51,17 @@ func (s *salesRoutes) CreateSale(c *gin.Context) {
 	res, err := s.useCase.CreateSales(&req)
 	if err != nil {
 		s.log.Error("Error creating sale", "error", err.Error())
+
+		var limitErr *entity.CreditLimitError
+		if errors.As(err, &limitErr) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "credit_limit": limitErr})
+			return
+		}
+		var stockErr *entity.StockError
+		if errors.As(err, &stockErr) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "lines": stockErr.Lines})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -54,6 +69,36 @@ func (s *salesRoutes) CreateSale(c *gin.Context) {
 	c.JSON(http.StatusCreated, res)
 }
 
+// GetDailyReport godoc
+// @Summary Daily Sales Report
+// @Description Summarize the sales of a day in the base currency with a breakdown of payments by method and currency
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Param DailySalesFilter query entity.DailySalesFilter false "Date, today by default"
+// @Success 200 {object} entity.DailySalesReport
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /sales/daily-report [get]
+func (s *salesRoutes) GetDailyReport(c *gin.Context) {
+	var req entity.DailySalesFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		s.log.Error("Error binding query parameters in GetDailyReport", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := s.useCase.GetDailyReport(&req)
+	if err != nil {
+		s.log.Error("Error building daily sales report", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
 // GetSale godoc
 // @Summary Get Sale
 // @Description Retrieve a sale by ID
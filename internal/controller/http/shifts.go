@@ -0,0 +1,271 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type shiftsRoutes struct {
+	useCase *usecase.ShiftsUseCase
+	log     *slog.Logger
+}
+
+func newShiftsRoutes(router *gin.RouterGroup, us *usecase.ShiftsUseCase, log *slog.Logger) {
+	shifts := &shiftsRoutes{useCase: us, log: log}
+
+	// Shifts routes
+	router.GET("/branches", shifts.GetBranches)
+	router.POST("/branches", shifts.CreateBranch)
+	router.GET("/registers", shifts.GetRegisters)
+	router.POST("/registers", shifts.CreateRegister)
+	router.POST("/open", shifts.OpenShift)
+	router.GET("", shifts.GetShifts)
+	router.GET("/:id", shifts.GetShift)
+	router.GET("/:id/report", shifts.GetShiftReport)
+	router.PUT("/:id/close", shifts.CloseShift)
+}
+
+// GetBranches godoc
+// @Summary List Branches
+// @Description Retrieve the branches of the organization
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.BranchList
+// @Failure 500 {object} entity.Error
+// @Router /shifts/branches [get]
+func (s *shiftsRoutes) GetBranches(c *gin.Context) {
+	res, err := s.useCase.GetBranches()
+	if err != nil {
+		s.log.Error("Error retrieving branches", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateBranch godoc
+// @Summary Create Branch
+// @Description Add a branch; sales and expenses of shifts at its registers are reported under it
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Param BranchRequest body entity.BranchRequest true "Branch data"
+// @Success 200 {object} entity.Branch
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /shifts/branches [post]
+func (s *shiftsRoutes) CreateBranch(c *gin.Context) {
+	var req entity.BranchRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.log.Error("Error binding JSON in CreateBranch", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := s.useCase.CreateBranch(&req)
+	if err != nil {
+		s.log.Error("Error creating branch", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetRegisters godoc
+// @Summary List Registers
+// @Description Retrieve cash registers with their drawer wallets and branches
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.RegisterList
+// @Failure 500 {object} entity.Error
+// @Router /shifts/registers [get]
+func (s *shiftsRoutes) GetRegisters(c *gin.Context) {
+	res, err := s.useCase.GetRegisters()
+	if err != nil {
+		s.log.Error("Error retrieving registers", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateRegister godoc
+// @Summary Create Register
+// @Description Add a cash register in a branch, the first branch by default; its cash goes to the given wallet
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Param RegisterRequest body entity.RegisterRequest true "Register data"
+// @Success 200 {object} entity.Register
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /shifts/registers [post]
+func (s *shiftsRoutes) CreateRegister(c *gin.Context) {
+	var req entity.RegisterRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.log.Error("Error binding JSON in CreateRegister", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := s.useCase.CreateRegister(&req)
+	if err != nil {
+		s.log.Error("Error creating register", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// OpenShift godoc
+// @Summary Open Shift
+// @Description Open a shift for a user at a register with the counted opening cash
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Param ShiftOpen body entity.ShiftOpen true "Shift data"
+// @Success 200 {object} entity.Shift
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /shifts/open [post]
+func (s *shiftsRoutes) OpenShift(c *gin.Context) {
+	var req entity.ShiftOpen
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.log.Error("Error binding JSON in OpenShift", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := s.useCase.OpenShift(&req)
+	if err != nil {
+		s.log.Error("Error opening shift", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetShifts godoc
+// @Summary List Shifts
+// @Description Retrieve shifts by register, user or status (open, closed)
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Param ShiftFilter query entity.ShiftFilter false "Shift filter parameters"
+// @Success 200 {object} entity.ShiftList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /shifts [get]
+func (s *shiftsRoutes) GetShifts(c *gin.Context) {
+	var req entity.ShiftFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		s.log.Error("Error binding query parameters in GetShifts", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := s.useCase.GetShifts(&req)
+	if err != nil {
+		s.log.Error("Error retrieving shifts", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetShift godoc
+// @Summary Get Shift
+// @Description Retrieve a shift by ID
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Param id path string true "Shift ID"
+// @Success 200 {object} entity.Shift
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /shifts/{id} [get]
+func (s *shiftsRoutes) GetShift(c *gin.Context) {
+	var req entity.ShiftID
+	req.ID = c.Param("id")
+
+	res, err := s.useCase.GetShift(&req)
+	if err != nil {
+		s.log.Error("Error retrieving shift", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetShiftReport godoc
+// @Summary Shift Report
+// @Description X-report with interim totals by payment method for an open shift, Z-report for a closed one
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Param id path string true "Shift ID"
+// @Success 200 {object} entity.ShiftReport
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /shifts/{id}/report [get]
+func (s *shiftsRoutes) GetShiftReport(c *gin.Context) {
+	var req entity.ShiftID
+	req.ID = c.Param("id")
+
+	res, err := s.useCase.GetShiftReport(&req)
+	if err != nil {
+		s.log.Error("Error building shift report", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CloseShift godoc
+// @Summary Close Shift
+// @Description Close a shift with the counted cash and get the Z-report with the discrepancy against expected cash
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Param id path string true "Shift ID"
+// @Param ShiftClose body entity.ShiftClose true "Counted cash"
+// @Success 200 {object} entity.ShiftReport
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /shifts/{id}/close [put]
+func (s *shiftsRoutes) CloseShift(c *gin.Context) {
+	var req entity.ShiftClose
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.log.Error("Error binding JSON in CloseShift", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := s.useCase.CloseShift(&req)
+	if err != nil {
+		s.log.Error("Error closing shift", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
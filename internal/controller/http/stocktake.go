@@ -0,0 +1,231 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type stocktakesRoutes struct {
+	useCase *usecase.StocktakesUseCase
+	log     *slog.Logger
+}
+
+func newStocktakesRoutes(router *gin.RouterGroup, us *usecase.StocktakesUseCase, log *slog.Logger) {
+	stocktakes := &stocktakesRoutes{useCase: us, log: log}
+
+	// Stocktake routes
+	router.POST("", stocktakes.CreateStocktake)
+	router.GET("", stocktakes.GetStocktakes)
+	router.GET("/:id", stocktakes.GetStocktake)
+	router.POST("/:id/counts", stocktakes.AddCounts)
+	router.GET("/:id/variance", stocktakes.GetVariance)
+	router.POST("/:id/post", stocktakes.PostStocktake)
+	router.POST("/:id/cancel", stocktakes.CancelStocktake)
+}
+
+// CreateStocktake godoc
+// @Summary Create Stocktake
+// @Description Start a stocktake by snapshotting the expected stock of all products, or only of a category or storage location
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Param StocktakeRequest body entity.StocktakeRequest true "Scope of the stocktake"
+// @Success 201 {object} entity.Stocktake
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /stocktakes [post]
+func (s *stocktakesRoutes) CreateStocktake(c *gin.Context) {
+	var req entity.StocktakeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.log.Error("Error binding JSON in CreateStocktake", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := s.useCase.CreateStocktake(&req)
+	if err != nil {
+		s.log.Error("Error creating stocktake", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetStocktakes godoc
+// @Summary List Stocktakes
+// @Description Retrieve stocktakes, optionally only those being counted, posted or cancelled
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Param StocktakeFilter query entity.StocktakeFilter false "Filter"
+// @Success 200 {object} entity.StocktakeList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /stocktakes [get]
+func (s *stocktakesRoutes) GetStocktakes(c *gin.Context) {
+	var req entity.StocktakeFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		s.log.Error("Error binding query parameters in GetStocktakes", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := s.useCase.GetStocktakes(&req)
+	if err != nil {
+		s.log.Error("Error retrieving stocktakes", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetStocktake godoc
+// @Summary Get Stocktake
+// @Description Retrieve a stocktake with the number of products expected and counted
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Param id path string true "Stocktake ID"
+// @Success 200 {object} entity.Stocktake
+// @Failure 500 {object} entity.Error
+// @Router /stocktakes/{id} [get]
+func (s *stocktakesRoutes) GetStocktake(c *gin.Context) {
+	var req entity.StocktakeID
+	req.ID = c.Param("id")
+
+	res, err := s.useCase.GetStocktake(&req)
+	if err != nil {
+		s.log.Error("Error retrieving stocktake", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// AddCounts godoc
+// @Summary Add Stocktake Counts
+// @Description Record counted quantities by product ID or barcode. Counts of the same product add up, so it can be counted in parts or by several users; a negative quantity corrects a count
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Param id path string true "Stocktake ID"
+// @Param StocktakeCountRequest body entity.StocktakeCountRequest true "Counted quantities"
+// @Success 200 {object} entity.Stocktake
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /stocktakes/{id}/counts [post]
+func (s *stocktakesRoutes) AddCounts(c *gin.Context) {
+	var req entity.StocktakeCountRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.log.Error("Error binding JSON in AddCounts", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := s.useCase.AddCounts(&req)
+	if err != nil {
+		s.log.Error("Error adding stocktake counts", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetVariance godoc
+// @Summary Get Stocktake Variance
+// @Description Retrieve the expected and counted stock of every product with the variance valued at average cost, and the total shortage and surplus
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Param id path string true "Stocktake ID"
+// @Param StocktakeLineFilter query entity.StocktakeLineFilter false "Filter"
+// @Success 200 {object} entity.StocktakeVariance
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /stocktakes/{id}/variance [get]
+func (s *stocktakesRoutes) GetVariance(c *gin.Context) {
+	var req entity.StocktakeLineFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		s.log.Error("Error binding query parameters in GetVariance", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := s.useCase.GetVariance(&req)
+	if err != nil {
+		s.log.Error("Error retrieving stocktake variance", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// PostStocktake godoc
+// @Summary Post Stocktake
+// @Description Post the variances as inventory adjustments in one transaction. Products never counted are skipped unless zero_uncounted is set
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Param id path string true "Stocktake ID"
+// @Param StocktakePost body entity.StocktakePost true "User posting the stocktake"
+// @Success 200 {object} entity.StocktakeVariance
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /stocktakes/{id}/post [post]
+func (s *stocktakesRoutes) PostStocktake(c *gin.Context) {
+	var req entity.StocktakePost
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.log.Error("Error binding JSON in PostStocktake", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := s.useCase.PostStocktake(&req)
+	if err != nil {
+		s.log.Error("Error posting stocktake", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CancelStocktake godoc
+// @Summary Cancel Stocktake
+// @Description Cancel a stocktake that was not posted, stock is left unchanged
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Param id path string true "Stocktake ID"
+// @Success 200 {object} entity.Stocktake
+// @Failure 500 {object} entity.Error
+// @Router /stocktakes/{id}/cancel [post]
+func (s *stocktakesRoutes) CancelStocktake(c *gin.Context) {
+	var req entity.StocktakeID
+	req.ID = c.Param("id")
+
+	res, err := s.useCase.CancelStocktake(&req)
+	if err != nil {
+		s.log.Error("Error cancelling stocktake", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,250 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type walletsRoutes struct {
+	useCase *usecase.WalletsUseCase
+	log     *slog.Logger
+}
+
+func newWalletsRoutes(router *gin.RouterGroup, us *usecase.WalletsUseCase, log *slog.Logger) {
+	wallets := &walletsRoutes{useCase: us, log: log}
+
+	// Wallets routes
+	router.GET("", wallets.GetWallets)
+	router.POST("", wallets.CreateWallet)
+	router.GET("/balances", wallets.GetBalancesAt)
+	router.POST("/transfers", wallets.Transfer)
+	router.GET("/transfers", wallets.GetTransfers)
+	router.GET("/:id", wallets.GetWallet)
+	router.PUT("/:id", wallets.UpdateWallet)
+	router.DELETE("/:id", wallets.DeleteWallet)
+}
+
+// GetWallets godoc
+// @Summary List Wallets
+// @Description Retrieve all wallets with their current balances
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.WalletList
+// @Failure 500 {object} entity.Error
+// @Router /wallets [get]
+func (w *walletsRoutes) GetWallets(c *gin.Context) {
+	res, err := w.useCase.GetWallets()
+	if err != nil {
+		w.log.Error("Error retrieving wallets", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateWallet godoc
+// @Summary Create Wallet
+// @Description Add a wallet with a currency and payment method (uzs, usd or card)
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param WalletRequest body entity.WalletRequest true "Wallet data"
+// @Success 200 {object} entity.Wallet
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /wallets [post]
+func (w *walletsRoutes) CreateWallet(c *gin.Context) {
+	var req entity.WalletRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Error binding JSON in CreateWallet", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := w.useCase.CreateWallet(&req)
+	if err != nil {
+		w.log.Error("Error creating wallet", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetWallet godoc
+// @Summary Get Wallet
+// @Description Retrieve a wallet by ID
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Success 200 {object} entity.Wallet
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /wallets/{id} [get]
+func (w *walletsRoutes) GetWallet(c *gin.Context) {
+	var req entity.WalletID
+	req.ID = c.Param("id")
+
+	res, err := w.useCase.GetWallet(&req)
+	if err != nil {
+		w.log.Error("Error retrieving wallet", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateWallet godoc
+// @Summary Update Wallet
+// @Description Rename a wallet or make it the default for its payment method
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param WalletRequest body entity.WalletRequest true "Wallet data"
+// @Success 200 {object} entity.Wallet
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /wallets/{id} [put]
+func (w *walletsRoutes) UpdateWallet(c *gin.Context) {
+	var req entity.WalletRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Error binding JSON in UpdateWallet", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := w.useCase.UpdateWallet(&req)
+	if err != nil {
+		w.log.Error("Error updating wallet", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteWallet godoc
+// @Summary Delete Wallet
+// @Description Delete a wallet that has no entries and is not a default wallet
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Success 200 {object} entity.Message
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /wallets/{id} [delete]
+func (w *walletsRoutes) DeleteWallet(c *gin.Context) {
+	var req entity.WalletID
+	req.ID = c.Param("id")
+
+	res, err := w.useCase.DeleteWallet(&req)
+	if err != nil {
+		w.log.Error("Error deleting wallet", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Transfer godoc
+// @Summary Transfer Between Wallets
+// @Description Move money between wallets, e.g. collect the drawer into the safe. to_amount is required across currencies
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param WalletTransferRequest body entity.WalletTransferRequest true "Transfer data"
+// @Success 200 {object} entity.WalletTransfer
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /wallets/transfers [post]
+func (w *walletsRoutes) Transfer(c *gin.Context) {
+	var req entity.WalletTransferRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Error binding JSON in Transfer", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := w.useCase.Transfer(&req)
+	if err != nil {
+		w.log.Error("Error transferring between wallets", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetTransfers godoc
+// @Summary List Transfers
+// @Description Retrieve transfers between wallets
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param WalletTransferFilter query entity.WalletTransferFilter false "Transfer filter parameters"
+// @Success 200 {object} entity.WalletTransferList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /wallets/transfers [get]
+func (w *walletsRoutes) GetTransfers(c *gin.Context) {
+	var req entity.WalletTransferFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		w.log.Error("Error binding query parameters in GetTransfers", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := w.useCase.GetTransfers(&req)
+	if err != nil {
+		w.log.Error("Error retrieving transfers", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetBalancesAt godoc
+// @Summary Wallet Balances At Date
+// @Description Balance of every wallet at the end of the given day (YYYY-MM-DD), today by default
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param WalletBalanceFilter query entity.WalletBalanceFilter false "Balance date"
+// @Success 200 {object} entity.WalletBalanceList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /wallets/balances [get]
+func (w *walletsRoutes) GetBalancesAt(c *gin.Context) {
+	var req entity.WalletBalanceFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		w.log.Error("Error binding query parameters in GetBalancesAt", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := w.useCase.GetBalancesAt(&req)
+	if err != nil {
+		w.log.Error("Error retrieving wallet balances", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
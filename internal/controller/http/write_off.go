@@ -0,0 +1,225 @@
+package http
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type writeOffsRoutes struct {
+	useCase *usecase.WriteOffsUseCase
+	log     *slog.Logger
+}
+
+func newWriteOffsRoutes(router *gin.RouterGroup, us *usecase.WriteOffsUseCase, log *slog.Logger) {
+	writeOffs := &writeOffsRoutes{useCase: us, log: log}
+
+	// Write-off routes
+	router.POST("", writeOffs.CreateWriteOff)
+	router.GET("", writeOffs.GetWriteOffList)
+	router.GET("/threshold", writeOffs.GetThreshold)
+	router.PUT("/threshold", writeOffs.SetThreshold)
+	router.GET("/:id", writeOffs.GetWriteOff)
+	router.POST("/:id/approve", writeOffs.ApproveWriteOff)
+	router.POST("/:id/reject", writeOffs.RejectWriteOff)
+}
+
+// CreateWriteOff godoc
+// @Summary Create Write-off
+// @Description Write off damaged, expired or lost goods at their average cost. Write-offs within the approval threshold leave stock at once, larger ones wait for an owner
+// @Tags Write-off
+// @Accept json
+// @Produce json
+// @Param WriteOffRequest body entity.WriteOffRequest true "Reason and lines"
+// @Success 201 {object} entity.WriteOff
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /write-offs [post]
+func (w *writeOffsRoutes) CreateWriteOff(c *gin.Context) {
+	var req entity.WriteOffRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Error binding JSON in CreateWriteOff", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := w.useCase.CreateWriteOff(&req)
+	if err != nil {
+		w.log.Error("Error creating write-off", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetWriteOffList godoc
+// @Summary List Write-offs
+// @Description Retrieve write-offs by status, reason and dates with the total cost of the approved ones
+// @Tags Write-off
+// @Accept json
+// @Produce json
+// @Param WriteOffFilter query entity.WriteOffFilter false "Filter"
+// @Success 200 {object} entity.WriteOffList
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /write-offs [get]
+func (w *writeOffsRoutes) GetWriteOffList(c *gin.Context) {
+	var req entity.WriteOffFilter
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		w.log.Error("Error binding query parameters in GetWriteOffList", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := w.useCase.GetWriteOffList(&req)
+	if err != nil {
+		w.log.Error("Error retrieving write-offs", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetWriteOff godoc
+// @Summary Get Write-off
+// @Description Retrieve a write-off with its lines
+// @Tags Write-off
+// @Accept json
+// @Produce json
+// @Param id path string true "Write-off ID"
+// @Success 200 {object} entity.WriteOff
+// @Failure 500 {object} entity.Error
+// @Router /write-offs/{id} [get]
+func (w *writeOffsRoutes) GetWriteOff(c *gin.Context) {
+	var req entity.WriteOffID
+	req.ID = c.Param("id")
+
+	res, err := w.useCase.GetWriteOff(&req)
+	if err != nil {
+		w.log.Error("Error retrieving write-off", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ApproveWriteOff godoc
+// @Summary Approve Write-off
+// @Description Approve a pending write-off as an owner, taking its goods out of stock
+// @Tags Write-off
+// @Accept json
+// @Produce json
+// @Param id path string true "Write-off ID"
+// @Param WriteOffDecision body entity.WriteOffDecision true "Approving owner"
+// @Success 200 {object} entity.WriteOff
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /write-offs/{id}/approve [post]
+func (w *writeOffsRoutes) ApproveWriteOff(c *gin.Context) {
+	var req entity.WriteOffDecision
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Error binding JSON in ApproveWriteOff", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := w.useCase.ApproveWriteOff(&req)
+	if err != nil {
+		w.log.Error("Error approving write-off", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RejectWriteOff godoc
+// @Summary Reject Write-off
+// @Description Reject a pending write-off as an owner with a reason, stock is left unchanged
+// @Tags Write-off
+// @Accept json
+// @Produce json
+// @Param id path string true "Write-off ID"
+// @Param WriteOffDecision body entity.WriteOffDecision true "Rejecting owner and reason"
+// @Success 200 {object} entity.WriteOff
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /write-offs/{id}/reject [post]
+func (w *writeOffsRoutes) RejectWriteOff(c *gin.Context) {
+	var req entity.WriteOffDecision
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Error binding JSON in RejectWriteOff", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ID = c.Param("id")
+
+	res, err := w.useCase.RejectWriteOff(&req)
+	if err != nil {
+		w.log.Error("Error rejecting write-off", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetThreshold godoc
+// @Summary Get Write-off Approval Threshold
+// @Description Retrieve the cost in the base currency above which a write-off needs approval
+// @Tags Write-off
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.WriteOffThreshold
+// @Failure 500 {object} entity.Error
+// @Router /write-offs/threshold [get]
+func (w *writeOffsRoutes) GetThreshold(c *gin.Context) {
+	res, err := w.useCase.GetThreshold()
+	if err != nil {
+		w.log.Error("Error retrieving write-off threshold", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SetThreshold godoc
+// @Summary Set Write-off Approval Threshold
+// @Description Change the cost in the base currency above which a write-off needs approval
+// @Tags Write-off
+// @Accept json
+// @Produce json
+// @Param WriteOffThreshold body entity.WriteOffThreshold true "Threshold"
+// @Success 200 {object} entity.WriteOffThreshold
+// @Failure 400 {object} entity.Error
+// @Failure 500 {object} entity.Error
+// @Router /write-offs/threshold [put]
+func (w *writeOffsRoutes) SetThreshold(c *gin.Context) {
+	var req entity.WriteOffThreshold
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Error binding JSON in SetThreshold", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := w.useCase.SetThreshold(&req)
+	if err != nil {
+		w.log.Error("Error setting write-off threshold", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
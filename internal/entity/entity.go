@@ -1,6 +1,11 @@
 package entity
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // ----------------- ProductCategory structs for Repo -----------------------------
 
@@ -30,11 +35,14 @@ type ProductID struct {
 	ID string `json:"id" db:"id"`
 }
 
+// FilterProduct lists the catalog: products with their variants grouped under them. A SKU finds the product
+// or the variant with it.
 type FilterProduct struct {
-	CategoryId string `json:"category_id" db:"category_id"`
-	Name       string `json:"name" db:"name"`
-	TotalCount string `json:"total_count" db:"total_count"`
-	CreatedBy  string `json:"created_by" db:"created_by"`
+	CategoryId string `json:"category_id" db:"category_id" form:"category_id"`
+	Name       string `json:"name" db:"name" form:"name"`
+	SKU        string `json:"sku" db:"sku" form:"sku"`
+	TotalCount string `json:"total_count" db:"total_count" form:"total_count"`
+	CreatedBy  string `json:"created_by" db:"created_by" form:"created_by"`
 }
 
 type ProductRequest struct {
@@ -43,9 +51,14 @@ type ProductRequest struct {
 	BillFormat    string  `json:"bill_format" db:"bill_format"`
 	IncomingPrice float32 `json:"incoming_price" db:"incoming_price"`
 	StandardPrice float32 `json:"standard_price" db:"standard_price"`
+	SKU           string  `json:"sku" db:"sku"`
+	Barcode       string  `json:"barcode" db:"barcode"`
+	Location      string  `json:"location" db:"location"`
 	CreatedBy     string  `json:"created_by" db:"created_by"`
 }
 
+// ProductUpdate changes a product. New prices of a product with variants also apply to the variants
+// without prices of their own.
 type ProductUpdate struct {
 	ID            string  `json:"id" db:"id"`
 	CategoryID    string  `json:"category_id" db:"category_id"`
@@ -53,27 +66,77 @@ type ProductUpdate struct {
 	BillFormat    string  `json:"bill_format" db:"bill_format"`
 	IncomingPrice float32 `json:"incoming_price" db:"incoming_price"`
 	StandardPrice float32 `json:"standard_price" db:"standard_price"`
+	SKU           string  `json:"sku" db:"sku"`
+	Barcode       string  `json:"barcode" db:"barcode"`
+	Location      string  `json:"location" db:"location"`
 }
 
+// Product is a product or one of its variants. A variant has the parent it belongs to and its attribute
+// values; a product with variants has the options they were generated from and is sold and bought only
+// through them. In the catalog the stock of a product with variants is the stock of all its variants.
 type Product struct {
-	ID            string  `json:"id" db:"id"`
-	CategoryID    string  `json:"category_id" db:"category_id"`
-	Name          string  `json:"name" db:"name"`
-	BillFormat    string  `json:"bill_format" db:"bill_format"`
-	IncomingPrice float32 `json:"incoming_price" db:"incoming_price"`
-	StandardPrice float32 `json:"standard_price" db:"standard_price"`
-	TotalCount    int     `json:"total_count" db:"total_count"`
-	CreatedBy     string  `json:"created_by" db:"created_by"`
-	CreatedAt     string  `json:"created_at" db:"created_at"`
+	ID             string          `json:"id" db:"id"`
+	CategoryID     string          `json:"category_id" db:"category_id"`
+	Name           string          `json:"name" db:"name"`
+	BillFormat     string          `json:"bill_format" db:"bill_format"`
+	IncomingPrice  float32         `json:"incoming_price" db:"incoming_price"`
+	StandardPrice  float32         `json:"standard_price" db:"standard_price"`
+	TotalCount     int             `json:"total_count" db:"total_count"`
+	SKU            string          `json:"sku" db:"sku"`
+	Barcode        string          `json:"barcode" db:"barcode"`
+	Location       string          `json:"location" db:"location"`
+	ParentID       string          `json:"parent_id,omitempty" db:"parent_id"`
+	Attributes     json.RawMessage `json:"attributes,omitempty" db:"attributes" swaggertype:"object"`
+	VariantOptions json.RawMessage `json:"variant_options,omitempty" db:"variant_options" swaggertype:"object"`
+	OwnPrice       bool            `json:"own_price" db:"own_price"`
+	CreatedBy      string          `json:"created_by" db:"created_by"`
+	CreatedAt      string          `json:"created_at" db:"created_at"`
+	Variants       []Product       `json:"variants,omitempty" db:"-"`
 }
 
 type ProductList struct {
 	Products []Product `json:"products"`
 }
 
+// VariantGenerateRequest creates a variant for every combination of the attribute values, e.g.
+// {"size": ["S", "M"], "color": ["red", "blue"]}. Combinations that already exist are kept as they are.
+// Generated variants take the prices of the product and a SKU made from its SKU, or name, and the values.
+type VariantGenerateRequest struct {
+	ProductID string              `json:"-" db:"product_id"`
+	Options   map[string][]string `json:"options" db:"-"`
+	CreatedBy string              `json:"created_by" db:"created_by"`
+}
+
+// VariantRequest adds a single variant. Prices left empty are taken from the product, the SKU is generated
+// when left empty.
+type VariantRequest struct {
+	ProductID     string            `json:"-" db:"product_id"`
+	Attributes    map[string]string `json:"attributes" db:"-"`
+	SKU           string            `json:"sku" db:"sku"`
+	Barcode       string            `json:"barcode" db:"barcode"`
+	IncomingPrice *float32          `json:"incoming_price" db:"incoming_price"`
+	StandardPrice *float32          `json:"standard_price" db:"standard_price"`
+	CreatedBy     string            `json:"created_by" db:"created_by"`
+}
+
+// VariantPrice overrides the prices of a variant, or with Inherit makes it follow its product again.
+type VariantPrice struct {
+	ID            string  `json:"-" db:"id"`
+	IncomingPrice float32 `json:"incoming_price" db:"incoming_price"`
+	StandardPrice float32 `json:"standard_price" db:"standard_price"`
+	Inherit       bool    `json:"inherit" db:"-"`
+}
+
+// CountProductReq adds or removes stock. The change is recorded in the inventory ledger as a movement
+// of the given type, an adjustment by default, with its reference document.
 type CountProductReq struct {
-	Id    string `json:"id" db:"id"`
-	Count int    `json:"count" db:"count"`
+	Id            string `json:"id" db:"id"`
+	Count         int    `json:"count" db:"count"`
+	MovementType  string `json:"movement_type" db:"movement_type"`
+	ReferenceType string `json:"reference_type" db:"reference_type"`
+	ReferenceID   string `json:"reference_id" db:"reference_id"`
+	UserID        string `json:"user_id" db:"user_id"`
+	Note          string `json:"note" db:"note"`
 }
 
 type ProductNumber struct {
@@ -81,6 +144,31 @@ type ProductNumber struct {
 	TotalCount int    `json:"total_count" db:"total_count"`
 }
 
+// CostingMethod is how sold goods are costed: fifo or average (moving weighted average).
+type CostingMethod struct {
+	Method string `json:"method" db:"method"`
+}
+
+// CostLayer is a quantity of a product received at one unit cost in the base currency.
+type CostLayer struct {
+	ID             string  `json:"id" db:"id"`
+	ProductID      string  `json:"product_id" db:"product_id"`
+	PurchaseItemID string  `json:"purchase_item_id" db:"purchase_item_id"`
+	Quantity       int     `json:"quantity" db:"quantity"`
+	Remaining      int     `json:"remaining" db:"remaining"`
+	UnitCost       float64 `json:"unit_cost" db:"unit_cost"`
+	CreatedAt      string  `json:"created_at" db:"created_at"`
+}
+
+type CostLayerList struct {
+	ProductID   string      `json:"product_id" db:"product_id"`
+	Method      string      `json:"method" db:"method"`
+	AverageCost float64     `json:"average_cost" db:"average_cost"`
+	OnHand      int         `json:"on_hand" db:"on_hand"`
+	Value       float64     `json:"value" db:"value"`
+	Layers      []CostLayer `json:"layers"`
+}
+
 // ---------------------------------- Message ---------------------------------------------
 
 type Message struct {
@@ -97,14 +185,19 @@ type PurchaseUpdate struct {
 }
 
 type PurchaseResponse struct {
-	ID            string             `json:"id" db:"id"`
-	SupplierID    string             `json:"supplier_id" db:"supplier_id"`
-	PurchasedBy   string             `json:"purchased_by" db:"purchased_by"`
-	TotalCost     float64            `json:"total_cost" db:"total_cost"`
-	Description   string             `json:"description" db:"description"`
-	PaymentMethod string             `json:"payment_method" db:"payment_method"`
-	CreatedAt     string             `json:"created_at" db:"created_at"`
-	PurchaseItem  *[]PurchaseItemReq `json:"purchase_item" db:"purchase_item"`
+	ID             string             `json:"id" db:"id"`
+	SupplierID     string             `json:"supplier_id" db:"supplier_id"`
+	PurchasedBy    string             `json:"purchased_by" db:"purchased_by"`
+	TotalCost      float64            `json:"total_cost" db:"total_cost"`
+	Currency       string             `json:"currency" db:"currency"`
+	ExchangeRate   float64            `json:"exchange_rate" db:"exchange_rate"`
+	AmountPaid     float64            `json:"amount_paid" db:"amount_paid"`
+	ReturnedAmount float64            `json:"returned_amount" db:"returned_amount"`
+	DueDate        string             `json:"due_date" db:"due_date"`
+	Description    string             `json:"description" db:"description"`
+	PaymentMethod  string             `json:"payment_method" db:"payment_method"`
+	CreatedAt      string             `json:"created_at" db:"created_at"`
+	PurchaseItem   *[]PurchaseItemReq `json:"purchase_item" db:"purchase_item"`
 }
 
 type PurchaseItemResponse struct {
@@ -120,6 +213,9 @@ type PurchaseRequest struct {
 	SupplierID    string             `json:"supplier_id" db:"supplier_id"`
 	PurchasedBy   string             `json:"purchased_by" db:"purchased_by"`
 	TotalCost     float64            `json:"total_cost" db:"total_cost"`
+	Currency      string             `json:"currency" db:"currency"`
+	PaidAmount    float64            `json:"paid_amount" db:"paid_amount"`
+	DueDate       string             `json:"due_date" db:"due_date"`
 	Description   string             `json:"description" db:"description"`
 	PaymentMethod string             `json:"payment_method" db:"payment_method"`
 	PurchaseItem  *[]PurchaseItemReq `json:"purchase_item" db:"purchase_item"`
@@ -130,6 +226,8 @@ type PurchaseItemReq struct {
 	Quantity      int     `json:"quantity" db:"quantity"`
 	PurchasePrice float64 `json:"purchase_price" db:"purchase_price"`
 	TotalPrice    float64 `json:"total_price" db:"total_price"`
+	LotNumber     string  `json:"lot_number,omitempty" db:"lot_number"`
+	ExpiryDate    string  `json:"expiry_date,omitempty" db:"expiry_date"`
 }
 
 type Purchase struct {
@@ -137,13 +235,20 @@ type Purchase struct {
 	PurchasedBy   string          `json:"purchased_by" db:"purchased_by"`
 	Description   string          `json:"description" db:"description"`
 	PaymentMethod string          `json:"payment_method" db:"payment_method"`
+	Currency      string          `json:"currency" db:"currency"`
+	PaidAmount    float64         `json:"paid_amount" db:"paid_amount"`
+	OnCredit      bool            `json:"on_credit" db:"on_credit"`
+	DueDate       string          `json:"due_date" db:"due_date"`
 	PurchaseItem  *[]PurchaseItem `json:"purchase_item" db:"purchase_item"`
 }
 
+// PurchaseItem is a purchased line. Goods with a lot number or an expiry date are kept in stock as a lot.
 type PurchaseItem struct {
 	ProductID     string  `json:"product_id" db:"product_id"`
 	Quantity      int     `json:"quantity" db:"quantity"`
 	PurchasePrice float64 `json:"purchase_price" db:"purchase_price"`
+	LotNumber     string  `json:"lot_number" db:"lot_number"`
+	ExpiryDate    string  `json:"expiry_date" db:"expiry_date"`
 }
 
 type PurchaseID struct {
@@ -161,13 +266,1290 @@ type PurchaseList struct {
 	Purchases *[]PurchaseResponse `json:"purchases"`
 }
 
+// --------------- Supplier return structs for repo -----------------------------------------------
+
+type SupplierReturnItemReq struct {
+	PurchaseItemID string `json:"purchase_item_id" db:"purchase_item_id"`
+	Quantity       int    `json:"quantity" db:"quantity"`
+}
+
+// SupplierReturnRequest sends lines of a purchase back to the supplier. What the goods are worth first reduces
+// the unpaid balance of the purchase, the rest is refunded by the supplier with the refund method: uzs, usd or card.
+type SupplierReturnRequest struct {
+	PurchaseID   string                  `json:"purchase_id" db:"purchase_id"`
+	ReturnedBy   string                  `json:"returned_by" db:"returned_by"`
+	Reason       string                  `json:"reason" db:"reason"`
+	RefundMethod string                  `json:"refund_method" db:"refund_method"`
+	Items        []SupplierReturnItemReq `json:"items" db:"-"`
+}
+
+type SupplierReturnItem struct {
+	ID             string  `json:"id" db:"id"`
+	ReturnID       string  `json:"return_id" db:"return_id"`
+	PurchaseItemID string  `json:"purchase_item_id" db:"purchase_item_id"`
+	ProductID      string  `json:"product_id" db:"product_id"`
+	ProductName    string  `json:"product_name" db:"product_name"`
+	Quantity       int     `json:"quantity" db:"quantity"`
+	UnitPrice      float64 `json:"unit_price" db:"unit_price"`
+	TotalPrice     float64 `json:"total_price" db:"total_price"`
+}
+
+type SupplierReturn struct {
+	ID               string               `json:"id" db:"id"`
+	PurchaseID       string               `json:"purchase_id" db:"purchase_id"`
+	SupplierID       string               `json:"supplier_id" db:"supplier_id"`
+	ReturnedBy       string               `json:"returned_by" db:"returned_by"`
+	Reason           string               `json:"reason" db:"reason"`
+	Currency         string               `json:"currency" db:"currency"`
+	TotalAmount      float64              `json:"total_amount" db:"total_amount"`
+	PayableReduction float64              `json:"payable_reduction" db:"payable_reduction"`
+	RefundAmount     float64              `json:"refund_amount" db:"refund_amount"`
+	RefundMethod     string               `json:"refund_method" db:"refund_method"`
+	CreatedAt        string               `json:"created_at" db:"created_at"`
+	Items            []SupplierReturnItem `json:"items,omitempty" db:"-"`
+}
+
+type SupplierReturnID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type SupplierReturnFilter struct {
+	PurchaseID string `json:"purchase_id" form:"purchase_id"`
+	SupplierID string `json:"supplier_id" form:"supplier_id"`
+	From       string `json:"from" form:"from"`
+	To         string `json:"to" form:"to"`
+}
+
+type SupplierReturnList struct {
+	Returns []SupplierReturn `json:"returns"`
+}
+
+// ReturnablePurchaseItem is a line of a purchase with what can still go back to the supplier.
+type ReturnablePurchaseItem struct {
+	PurchaseItemID string  `json:"purchase_item_id" db:"purchase_item_id"`
+	ProductID      string  `json:"product_id" db:"product_id"`
+	ProductName    string  `json:"product_name" db:"product_name"`
+	Purchased      int     `json:"purchased" db:"purchased"`
+	Returned       int     `json:"returned" db:"returned"`
+	Returnable     int     `json:"returnable" db:"returnable"`
+	PurchasePrice  float64 `json:"purchase_price" db:"purchase_price"`
+}
+
+type ReturnablePurchaseItemList struct {
+	PurchaseID string                   `json:"purchase_id"`
+	Items      []ReturnablePurchaseItem `json:"items"`
+}
+
+// --------------- Purchase order structs for repo -----------------------------------------------
+
+type PurchaseOrderItemReq struct {
+	ProductID string  `json:"product_id" db:"product_id"`
+	Quantity  int     `json:"quantity" db:"quantity"`
+	UnitPrice float64 `json:"unit_price" db:"unit_price"`
+}
+
+// PurchaseOrderRequest drafts an order to a supplier. The lines of a suggested order can be sent as they are.
+type PurchaseOrderRequest struct {
+	ID           string                 `json:"-" db:"id"`
+	SupplierID   string                 `json:"supplier_id" db:"supplier_id"`
+	CreatedBy    string                 `json:"created_by" db:"created_by"`
+	Currency     string                 `json:"currency" db:"currency"`
+	ExpectedDate string                 `json:"expected_date" db:"expected_date"`
+	Description  string                 `json:"description" db:"description"`
+	Items        []PurchaseOrderItemReq `json:"items" db:"-"`
+}
+
+type PurchaseOrderItem struct {
+	ID          string  `json:"id" db:"id"`
+	OrderID     string  `json:"order_id" db:"order_id"`
+	ProductID   string  `json:"product_id" db:"product_id"`
+	ProductName string  `json:"product_name" db:"product_name"`
+	Quantity    int     `json:"quantity" db:"quantity"`
+	Received    int     `json:"received" db:"received"`
+	Remaining   int     `json:"remaining" db:"remaining"` // still expected from the supplier
+	UnitPrice   float64 `json:"unit_price" db:"unit_price"`
+	TotalPrice  float64 `json:"total_price" db:"total_price"`
+}
+
+// PurchaseOrder goes draft → sent → partially_received → received, or is closed short or cancelled.
+type PurchaseOrder struct {
+	ID           string                 `json:"id" db:"id"`
+	OrderNumber  int                    `json:"order_number" db:"order_number"`
+	SupplierID   string                 `json:"supplier_id" db:"supplier_id"`
+	SupplierName string                 `json:"supplier_name" db:"supplier_name"`
+	CreatedBy    string                 `json:"created_by" db:"created_by"`
+	Status       string                 `json:"status" db:"status"`
+	Currency     string                 `json:"currency" db:"currency"`
+	ExpectedDate string                 `json:"expected_date" db:"expected_date"`
+	Description  string                 `json:"description" db:"description"`
+	TotalCost    float64                `json:"total_cost" db:"total_cost"`
+	CreatedAt    string                 `json:"created_at" db:"created_at"`
+	SentAt       string                 `json:"sent_at" db:"sent_at"`
+	ClosedAt     string                 `json:"closed_at" db:"closed_at"`
+	Items        []PurchaseOrderItem    `json:"items,omitempty" db:"-"`
+	Receipts     []PurchaseOrderReceipt `json:"receipts,omitempty" db:"-"`
+}
+
+type PurchaseOrderID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type PurchaseOrderFilter struct {
+	SupplierID string `json:"supplier_id" form:"supplier_id"`
+	Status     string `json:"status" form:"status"`
+	From       string `json:"from" form:"from"`
+	To         string `json:"to" form:"to"`
+}
+
+type PurchaseOrderList struct {
+	Orders []PurchaseOrder `json:"orders"`
+}
+
+// PurchaseOrderReceiptItemReq is the quantity actually delivered for a line of the order.
+type PurchaseOrderReceiptItemReq struct {
+	OrderItemID string `json:"order_item_id" db:"order_item_id"`
+	Quantity    int    `json:"quantity" db:"quantity"`
+	LotNumber   string `json:"lot_number" db:"lot_number"`
+	ExpiryDate  string `json:"expiry_date" db:"expiry_date"`
+	Note        string `json:"note" db:"note"`
+}
+
+// PurchaseOrderReceiptRequest receives goods against an order. The receipt is recorded as a purchase, paid in
+// full with the payment method unless it is taken on credit like any purchase.
+type PurchaseOrderReceiptRequest struct {
+	OrderID       string                        `json:"-" db:"order_id"`
+	ReceivedBy    string                        `json:"received_by" db:"received_by"`
+	Note          string                        `json:"note" db:"note"`
+	PaymentMethod string                        `json:"payment_method" db:"payment_method"`
+	OnCredit      bool                          `json:"on_credit" db:"on_credit"`
+	PaidAmount    float64                       `json:"paid_amount" db:"paid_amount"`
+	DueDate       string                        `json:"due_date" db:"due_date"`
+	Items         []PurchaseOrderReceiptItemReq `json:"items" db:"-"`
+}
+
+// PurchaseOrderReceiptItem compares what was still expected on a line with what arrived. A negative difference
+// is a short delivery, a positive one an over-delivery.
+type PurchaseOrderReceiptItem struct {
+	ID          string `json:"id" db:"id"`
+	ReceiptID   string `json:"receipt_id" db:"receipt_id"`
+	OrderItemID string `json:"order_item_id" db:"order_item_id"`
+	ProductID   string `json:"product_id" db:"product_id"`
+	ProductName string `json:"product_name" db:"product_name"`
+	Expected    int    `json:"expected" db:"expected"`
+	Received    int    `json:"received" db:"received"`
+	Difference  int    `json:"difference" db:"difference"`
+	Note        string `json:"note" db:"note"`
+}
+
+type PurchaseOrderReceipt struct {
+	ID             string                     `json:"id" db:"id"`
+	OrderID        string                     `json:"order_id" db:"order_id"`
+	PurchaseID     string                     `json:"purchase_id" db:"purchase_id"`
+	ReceivedBy     string                     `json:"received_by" db:"received_by"`
+	HasDifferences bool                       `json:"has_differences" db:"has_differences"`
+	Note           string                     `json:"note" db:"note"`
+	CreatedAt      string                     `json:"created_at" db:"created_at"`
+	Items          []PurchaseOrderReceiptItem `json:"items,omitempty" db:"-"`
+}
+
+// --------------- Supplier payables structs for repo -----------------------------------------------
+
+type SupplierPaymentRequest struct {
+	PurchaseID    string  `json:"purchase_id" db:"purchase_id"`
+	Amount        float64 `json:"amount" db:"amount"`
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	PaidBy        string  `json:"paid_by" db:"paid_by"`
+	Description   string  `json:"description" db:"description"`
+}
+
+type SupplierPayment struct {
+	ID            string  `json:"id" db:"id"`
+	PurchaseID    string  `json:"purchase_id" db:"purchase_id"`
+	SupplierID    string  `json:"supplier_id" db:"supplier_id"`
+	Amount        float64 `json:"amount" db:"amount"`
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	PaidBy        string  `json:"paid_by" db:"paid_by"`
+	Description   string  `json:"description" db:"description"`
+	PaymentDate   string  `json:"payment_date" db:"payment_date"`
+}
+
+type SupplierPaymentFilter struct {
+	SupplierID string `json:"supplier_id" form:"supplier_id" db:"supplier_id"`
+	PurchaseID string `json:"purchase_id" form:"purchase_id" db:"purchase_id"`
+}
+
+type SupplierPaymentList struct {
+	Payments []SupplierPayment `json:"payments"`
+}
+
+type PayableFilter struct {
+	SupplierID string `json:"supplier_id" form:"supplier_id" db:"supplier_id"`
+}
+
+type Payable struct {
+	PurchaseID   string  `json:"purchase_id" db:"purchase_id"`
+	SupplierID   string  `json:"supplier_id" db:"supplier_id"`
+	SupplierName string  `json:"supplier_name" db:"supplier_name"`
+	TotalCost    float64 `json:"total_cost" db:"total_cost"`
+	Returned     float64 `json:"returned" db:"returned"`
+	AmountPaid   float64 `json:"amount_paid" db:"amount_paid"`
+	Balance      float64 `json:"balance" db:"balance"`
+	Currency     string  `json:"currency" db:"currency"`
+	BaseBalance  float64 `json:"base_balance" db:"base_balance"`
+	DueDate      string  `json:"due_date" db:"due_date"`
+	CreatedAt    string  `json:"created_at" db:"created_at"`
+}
+
+type PayableList struct {
+	Payables []Payable `json:"payables"`
+}
+
+type SupplierBalance struct {
+	SupplierID   string  `json:"supplier_id" db:"supplier_id"`
+	SupplierName string  `json:"supplier_name" db:"supplier_name"`
+	Purchases    int     `json:"purchases" db:"purchases"`
+	Balance      float64 `json:"balance" db:"balance"`
+	Overdue      float64 `json:"overdue" db:"overdue"`
+}
+
+type SupplierBalanceList struct {
+	Currency  string            `json:"currency"`
+	Suppliers []SupplierBalance `json:"suppliers"`
+	Total     float64           `json:"total"`
+}
+
+type PaymentTerms struct {
+	ID               string `json:"id" db:"id"`
+	PaymentTermsDays int    `json:"payment_terms_days" db:"payment_terms_days"`
+}
+
+// --------------- Cash flow structs for repo -----------------------------------------------
+
+type CashCategoryRequest struct {
+	ID              string `json:"id" db:"id"`
+	Name            string `json:"name" db:"name"`
+	TransactionType string `json:"transaction_type" db:"transaction_type"`
+}
+
+type CashCategory struct {
+	ID              string `json:"id" db:"id"`
+	Name            string `json:"name" db:"name"`
+	Code            string `json:"code" db:"code"`
+	TransactionType string `json:"transaction_type" db:"transaction_type"`
+}
+
+type CashCategoryID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type CashCategoryList struct {
+	Categories []CashCategory `json:"categories"`
+}
+
+type CashFlowRequest struct {
+	ID              string  `json:"id" db:"id"`
+	UserID          string  `json:"user_id" db:"user_id"`
+	Amount          float64 `json:"amount" db:"amount"`
+	TransactionType string  `json:"transaction_type" db:"transaction_type"`
+	CategoryID      string  `json:"category_id" db:"category_id"`
+	Description     string  `json:"description" db:"description"`
+	PaymentMethod   string  `json:"payment_method" db:"payment_method"`
+	WalletID        string  `json:"wallet_id" db:"wallet_id"`
+	TransactionDate string  `json:"transaction_date" db:"transaction_date"`
+	Currency        string  `json:"currency" db:"currency"`
+	ReferenceType   string  `json:"-" db:"reference_type"`
+	ReferenceID     string  `json:"-" db:"reference_id"`
+}
+
+type CashFlow struct {
+	ID              string  `json:"id" db:"id"`
+	UserID          string  `json:"user_id" db:"user_id"`
+	TransactionDate string  `json:"transaction_date" db:"transaction_date"`
+	Amount          float64 `json:"amount" db:"amount"`
+	TransactionType string  `json:"transaction_type" db:"transaction_type"`
+	CategoryID      string  `json:"category_id" db:"category_id"`
+	CategoryName    string  `json:"category_name" db:"category_name"`
+	Description     string  `json:"description" db:"description"`
+	PaymentMethod   string  `json:"payment_method" db:"payment_method"`
+	WalletID        string  `json:"wallet_id" db:"wallet_id"`
+	WalletName      string  `json:"wallet_name" db:"wallet_name"`
+	Currency        string  `json:"currency" db:"currency"`
+	ExchangeRate    float64 `json:"exchange_rate" db:"exchange_rate"`
+	ShiftID         string  `json:"shift_id" db:"shift_id"`
+	ReferenceType   string  `json:"reference_type" db:"reference_type"`
+	ReferenceID     string  `json:"reference_id" db:"reference_id"`
+}
+
+type CashFlowID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type CashFlowFilter struct {
+	From            string `json:"from" form:"from" db:"from"`
+	To              string `json:"to" form:"to" db:"to"`
+	TransactionType string `json:"transaction_type" form:"transaction_type" db:"transaction_type"`
+	CategoryID      string `json:"category_id" form:"category_id" db:"category_id"`
+	PaymentMethod   string `json:"payment_method" form:"payment_method" db:"payment_method"`
+	WalletID        string `json:"wallet_id" form:"wallet_id" db:"wallet_id"`
+}
+
+type CashFlowList struct {
+	Entries  []CashFlow `json:"entries"`
+	Currency string     `json:"currency"`
+	Income   float64    `json:"income"`
+	Expense  float64    `json:"expense"`
+}
+
+type CashMethodBalance struct {
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	Currency      string  `json:"currency" db:"currency"`
+	Income        float64 `json:"income" db:"income"`
+	Expense       float64 `json:"expense" db:"expense"`
+	Balance       float64 `json:"balance" db:"balance"`
+	BaseIncome    float64 `json:"base_income" db:"base_income"`
+	BaseExpense   float64 `json:"base_expense" db:"base_expense"`
+	BaseBalance   float64 `json:"base_balance" db:"base_balance"`
+}
+
+type CashBalance struct {
+	Currency string              `json:"currency"`
+	Income   float64             `json:"income"`
+	Expense  float64             `json:"expense"`
+	Balance  float64             `json:"balance"`
+	ByMethod []CashMethodBalance `json:"by_method"`
+}
+
+// --------------- Recurring expense structs for repo -----------------------------------------------
+
+type RecurringExpenseRequest struct {
+	ID          string  `json:"-" db:"id"`
+	Name        string  `json:"name" db:"name"`
+	Amount      float64 `json:"amount" db:"amount"`
+	CategoryID  string  `json:"category_id" db:"category_id"`
+	WalletID    string  `json:"wallet_id" db:"wallet_id"`
+	Schedule    string  `json:"schedule" db:"schedule"`
+	StartDate   string  `json:"start_date" db:"start_date"`
+	EndDate     string  `json:"end_date" db:"end_date"`
+	Description string  `json:"description" db:"description"`
+	IsActive    bool    `json:"is_active" db:"is_active"`
+	CreatedBy   string  `json:"created_by" db:"created_by"`
+}
+
+type RecurringExpense struct {
+	ID           string  `json:"id" db:"id"`
+	Name         string  `json:"name" db:"name"`
+	Amount       float64 `json:"amount" db:"amount"`
+	CategoryID   string  `json:"category_id" db:"category_id"`
+	CategoryName string  `json:"category_name" db:"category_name"`
+	WalletID     string  `json:"wallet_id" db:"wallet_id"`
+	WalletName   string  `json:"wallet_name" db:"wallet_name"`
+	Currency     string  `json:"currency" db:"currency"`
+	Schedule     string  `json:"schedule" db:"schedule"`
+	StartDate    string  `json:"start_date" db:"start_date"`
+	EndDate      string  `json:"end_date" db:"end_date"`
+	Description  string  `json:"description" db:"description"`
+	IsActive     bool    `json:"is_active" db:"is_active"`
+	CreatedBy    string  `json:"created_by" db:"created_by"`
+	LastPostedAt string  `json:"last_posted_at" db:"last_posted_at"`
+	CreatedAt    string  `json:"created_at" db:"created_at"`
+}
+
+type RecurringExpenseID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type RecurringExpenseList struct {
+	Expenses []RecurringExpense `json:"expenses"`
+}
+
+type RecurringPreviewFilter struct {
+	ID    string `json:"-"`
+	Count int    `json:"count" form:"count"`
+}
+
+type RecurringPosting struct {
+	Date   string  `json:"date"`
+	Amount float64 `json:"amount"`
+}
+
+type RecurringPreview struct {
+	ExpenseID string             `json:"expense_id"`
+	Name      string             `json:"name"`
+	Currency  string             `json:"currency"`
+	Postings  []RecurringPosting `json:"postings"`
+}
+
+type RecurringRunResult struct {
+	Posted int `json:"posted"`
+	Failed int `json:"failed"`
+}
+
+// --------------- Budget structs for repo -----------------------------------------------
+
+type BudgetRequest struct {
+	ID         string  `json:"-" db:"id"`
+	CategoryID string  `json:"category_id" db:"category_id"`
+	Period     string  `json:"period" db:"period"`
+	Amount     float64 `json:"amount" db:"amount"`
+}
+
+type Budget struct {
+	ID           string  `json:"id" db:"id"`
+	CategoryID   string  `json:"category_id" db:"category_id"`
+	CategoryName string  `json:"category_name" db:"category_name"`
+	Period       string  `json:"period" db:"period"`
+	Amount       float64 `json:"amount" db:"amount"`
+	CreatedAt    string  `json:"created_at" db:"created_at"`
+}
+
+type BudgetID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type BudgetFilter struct {
+	Period     string `json:"period" form:"period" db:"period"`
+	CategoryID string `json:"category_id" form:"category_id" db:"category_id"`
+}
+
+type BudgetList struct {
+	Budgets []Budget `json:"budgets"`
+}
+
+type BudgetLine struct {
+	BudgetID     string  `json:"budget_id" db:"budget_id"`
+	CategoryID   string  `json:"category_id" db:"category_id"`
+	CategoryName string  `json:"category_name" db:"category_name"`
+	Period       string  `json:"period" db:"period"`
+	Budget       float64 `json:"budget" db:"budget"`
+	Actual       float64 `json:"actual" db:"actual"`
+	Remaining    float64 `json:"remaining" db:"remaining"`
+	Utilization  float64 `json:"utilization" db:"utilization"`
+	Status       string  `json:"status" db:"status"`
+}
+
+type BudgetReport struct {
+	Period      string       `json:"period"`
+	Currency    string       `json:"currency"`
+	Lines       []BudgetLine `json:"lines"`
+	TotalBudget float64      `json:"total_budget"`
+	TotalActual float64      `json:"total_actual"`
+}
+
+type BudgetCrossing struct {
+	BudgetLine
+	Threshold int      `json:"threshold" db:"threshold"`
+	SentTo    []string `json:"-" db:"-"`
+}
+
+type BudgetAlert struct {
+	ID           string  `json:"id" db:"id"`
+	BudgetID     string  `json:"budget_id" db:"budget_id"`
+	CategoryName string  `json:"category_name" db:"category_name"`
+	Period       string  `json:"period" db:"period"`
+	Threshold    int     `json:"threshold" db:"threshold"`
+	Spent        float64 `json:"spent" db:"spent"`
+	Channel      string  `json:"channel" db:"channel"`
+	Recipient    string  `json:"recipient" db:"recipient"`
+	Status       string  `json:"status" db:"status"`
+	Error        string  `json:"error" db:"error"`
+	SentAt       string  `json:"sent_at" db:"sent_at"`
+}
+
+type BudgetAlertList struct {
+	Alerts []BudgetAlert `json:"alerts"`
+}
+
+type BudgetCheckResult struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
+
+// --------------- Bank statement structs for repo -----------------------------------------------
+
+type BankStatementRequest struct {
+	WalletID   string `json:"wallet_id" form:"wallet_id" db:"wallet_id"`
+	Format     string `json:"format" form:"format" db:"format"` // csv or mt940
+	ImportedBy string `json:"imported_by" form:"imported_by" db:"imported_by"`
+	FileName   string `json:"file_name" db:"file_name"`
+}
+
+type BankLineRequest struct {
+	Date        string  `json:"date" db:"line_date"`
+	Amount      float64 `json:"amount" db:"amount"`
+	Reference   string  `json:"reference" db:"reference"`
+	Description string  `json:"description" db:"description"`
+}
+
+type BankStatement struct {
+	ID         string `json:"id" db:"id"`
+	WalletID   string `json:"wallet_id" db:"wallet_id"`
+	WalletName string `json:"wallet_name" db:"wallet_name"`
+	FileName   string `json:"file_name" db:"file_name"`
+	Format     string `json:"format" db:"format"`
+	ImportedBy string `json:"imported_by" db:"imported_by"`
+	Lines      int    `json:"lines" db:"lines"`
+	Matched    int    `json:"matched" db:"matched"`
+	Suggested  int    `json:"suggested" db:"suggested"`
+	Unmatched  int    `json:"unmatched" db:"unmatched"`
+	Duplicates int    `json:"duplicates,omitempty" db:"-"` // lines skipped on import as already imported
+	CreatedAt  string `json:"created_at" db:"created_at"`
+}
+
+type BankStatementID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type BankStatementList struct {
+	Statements []BankStatement `json:"statements"`
+}
+
+// BankStatementLine is a line of a statement with the cash flow entry it was matched to.
+// Status is unmatched, suggested (matched automatically, awaiting confirmation) or matched.
+type BankStatementLine struct {
+	ID            string  `json:"id" db:"id"`
+	StatementID   string  `json:"statement_id" db:"statement_id"`
+	WalletID      string  `json:"wallet_id" db:"wallet_id"`
+	Date          string  `json:"date" db:"line_date"`
+	Amount        float64 `json:"amount" db:"amount"`
+	Reference     string  `json:"reference" db:"reference"`
+	Description   string  `json:"description" db:"description"`
+	Status        string  `json:"status" db:"status"`
+	CashFlowID    string  `json:"cash_flow_id" db:"cash_flow_id"`
+	ReferenceType string  `json:"reference_type" db:"reference_type"` // what the matched entry was posted for
+	MatchedBy     string  `json:"matched_by" db:"matched_by"`
+	MatchedAt     string  `json:"matched_at" db:"matched_at"`
+}
+
+type BankLineFilter struct {
+	ID     string `json:"id" db:"id"`
+	Status string `json:"status" form:"status" db:"status"`
+}
+
+type BankLineList struct {
+	Lines []BankStatementLine `json:"lines"`
+}
+
+type BankLineID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type BankLineMatch struct {
+	ID         string `json:"id" db:"id"`
+	CashFlowID string `json:"cash_flow_id" db:"cash_flow_id"`
+	UserID     string `json:"user_id" db:"user_id"`
+}
+
+// BankLineCashFlow records an unmatched line as a new cash flow entry of the statement wallet.
+type BankLineCashFlow struct {
+	ID          string `json:"id" db:"id"`
+	CategoryID  string `json:"category_id" db:"category_id"`
+	Description string `json:"description" db:"description"`
+	UserID      string `json:"user_id" db:"user_id"`
+}
+
+// --------------- Profit and loss structs for repo -----------------------------------------------
+
+type PnLFilter struct {
+	From    string `json:"from" form:"from"`
+	To      string `json:"to" form:"to"`
+	GroupBy string `json:"group_by" form:"group_by"` // branch, category or seller
+}
+
+// PnLFigures are the amounts of a period in the base currency.
+type PnLFigures struct {
+	Revenue     float64 `json:"revenue" db:"revenue"`
+	Returns     float64 `json:"returns" db:"returns"`
+	NetRevenue  float64 `json:"net_revenue" db:"net_revenue"`
+	COGS        float64 `json:"cogs" db:"cogs"`
+	GrossProfit float64 `json:"gross_profit" db:"gross_profit"`
+	GrossMargin float64 `json:"gross_margin" db:"gross_margin"`
+	Expenses    float64 `json:"expenses" db:"expenses"`
+	NetProfit   float64 `json:"net_profit" db:"net_profit"`
+}
+
+type PnLExpense struct {
+	CategoryID   string  `json:"category_id" db:"category_id"`
+	CategoryName string  `json:"category_name" db:"category_name"`
+	Amount       float64 `json:"amount" db:"amount"`
+	Previous     float64 `json:"previous" db:"previous"`
+}
+
+// PnLSalesRow is the revenue and cost of goods sold of one line of the breakdown.
+type PnLSalesRow struct {
+	Key     string  `json:"key" db:"key"`
+	Name    string  `json:"name" db:"name"`
+	Revenue float64 `json:"revenue" db:"revenue"`
+	COGS    float64 `json:"cogs" db:"cogs"`
+}
+
+// PnLReturnRow is the value of returned goods and the cost of those restocked.
+type PnLReturnRow struct {
+	Key     string  `json:"key" db:"key"`
+	Name    string  `json:"name" db:"name"`
+	Returns float64 `json:"returns" db:"returns"`
+	COGS    float64 `json:"cogs" db:"cogs"`
+}
+
+// PnLExpenseRow is the spending of a cash category attributed to one line of the breakdown.
+type PnLExpenseRow struct {
+	Key          string  `json:"key" db:"key"`
+	Name         string  `json:"name" db:"name"`
+	CategoryID   string  `json:"category_id" db:"category_id"`
+	CategoryName string  `json:"category_name" db:"category_name"`
+	Amount       float64 `json:"amount" db:"amount"`
+}
+
+// PnLLine is one branch, product category or seller. Expenses that cannot be attributed
+// to a line are reported on a line with an empty key.
+type PnLLine struct {
+	Key      string     `json:"key" db:"key"`
+	Name     string     `json:"name" db:"name"`
+	Current  PnLFigures `json:"current"`
+	Previous PnLFigures `json:"previous"`
+}
+
+// PnLChange is the relative change against the previous period, nil when the previous amount is zero.
+type PnLChange struct {
+	Revenue     *float64 `json:"revenue"`
+	GrossProfit *float64 `json:"gross_profit"`
+	Expenses    *float64 `json:"expenses"`
+	NetProfit   *float64 `json:"net_profit"`
+}
+
+type PnLReport struct {
+	Currency     string       `json:"currency"`
+	From         string       `json:"from"`
+	To           string       `json:"to"`
+	PreviousFrom string       `json:"previous_from"`
+	PreviousTo   string       `json:"previous_to"`
+	GroupBy      string       `json:"group_by"`
+	Current      PnLFigures   `json:"current"`
+	Previous     PnLFigures   `json:"previous"`
+	Change       PnLChange    `json:"change"`
+	Expenses     []PnLExpense `json:"expenses"`
+	Lines        []PnLLine    `json:"lines"`
+}
+
+// --------------- Inventory movement structs for repo -----------------------------------------------
+
+// InventoryMovementRequest changes the stock of a product by a signed quantity.
+type InventoryMovementRequest struct {
+	ProductID     string `json:"product_id" db:"product_id"`
+	MovementType  string `json:"movement_type" db:"movement_type"` // purchase, sale, return, adjustment, transfer or write_off
+	Quantity      int    `json:"quantity" db:"quantity"`
+	ReferenceType string `json:"reference_type" db:"reference_type"`
+	ReferenceID   string `json:"reference_id" db:"reference_id"`
+	UserID        string `json:"user_id" db:"user_id"`
+	Note          string `json:"note" db:"note"`
+}
+
+type InventoryMovement struct {
+	ID            string `json:"id" db:"id"`
+	ProductID     string `json:"product_id" db:"product_id"`
+	MovementType  string `json:"movement_type" db:"movement_type"`
+	Quantity      int    `json:"quantity" db:"quantity"`
+	Balance       int    `json:"balance" db:"balance"` // stock after the movement
+	ReferenceType string `json:"reference_type" db:"reference_type"`
+	ReferenceID   string `json:"reference_id" db:"reference_id"`
+	UserID        string `json:"user_id" db:"user_id"`
+	Note          string `json:"note" db:"note"`
+	CreatedAt     string `json:"created_at" db:"created_at"`
+}
+
+type InventoryMovementFilter struct {
+	ProductID    string `json:"product_id" db:"product_id"`
+	From         string `json:"from" form:"from"`
+	To           string `json:"to" form:"to"`
+	MovementType string `json:"movement_type" form:"movement_type"`
+}
+
+// InventoryHistory is the stock card of a product for a period, both dates included.
+type InventoryHistory struct {
+	ProductID   string              `json:"product_id" db:"product_id"`
+	ProductName string              `json:"product_name" db:"product_name"`
+	From        string              `json:"from" db:"-"`
+	To          string              `json:"to" db:"-"`
+	Opening     int                 `json:"opening" db:"opening"`
+	Incoming    int                 `json:"incoming" db:"incoming"`
+	Outgoing    int                 `json:"outgoing" db:"outgoing"`
+	Closing     int                 `json:"closing" db:"closing"`
+	Movements   []InventoryMovement `json:"movements" db:"-"`
+}
+
+// --------------- Stocktake structs for repo -----------------------------------------------
+
+// StocktakeRequest starts a stocktake of all products, or only of one category or storage location.
+type StocktakeRequest struct {
+	CategoryID string `json:"category_id" db:"category_id"`
+	Location   string `json:"location" db:"location"`
+	Note       string `json:"note" db:"note"`
+	CreatedBy  string `json:"created_by" db:"created_by"`
+}
+
+type Stocktake struct {
+	ID         string `json:"id" db:"id"`
+	Status     string `json:"status" db:"status"` // counting, posted or cancelled
+	CategoryID string `json:"category_id" db:"category_id"`
+	Location   string `json:"location" db:"location"`
+	Note       string `json:"note" db:"note"`
+	Products   int    `json:"products" db:"products"` // products expected in the stocktake
+	Counted    int    `json:"counted" db:"counted"`   // products counted at least once
+	CreatedBy  string `json:"created_by" db:"created_by"`
+	CreatedAt  string `json:"created_at" db:"created_at"`
+	PostedBy   string `json:"posted_by" db:"posted_by"`
+	PostedAt   string `json:"posted_at" db:"posted_at"`
+}
+
+type StocktakeID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type StocktakeFilter struct {
+	Status string `json:"status" form:"status"`
+}
+
+type StocktakeList struct {
+	Stocktakes []Stocktake `json:"stocktakes"`
+}
+
+// StocktakeCountItem is a counted quantity of a product found by its ID or barcode. Counts of the same
+// product add up, a negative quantity corrects an earlier count.
+type StocktakeCountItem struct {
+	ProductID string `json:"product_id" db:"product_id"`
+	Barcode   string `json:"barcode" db:"barcode"`
+	Quantity  int    `json:"quantity" db:"quantity"`
+}
+
+type StocktakeCountRequest struct {
+	ID        string               `json:"-" db:"id"`
+	CountedBy string               `json:"counted_by" db:"counted_by"`
+	Items     []StocktakeCountItem `json:"items" db:"-"`
+}
+
+// StocktakeLine compares the expected stock of a product with the counted one, valued at its average cost.
+type StocktakeLine struct {
+	ProductID     string  `json:"product_id" db:"product_id"`
+	ProductName   string  `json:"product_name" db:"product_name"`
+	Barcode       string  `json:"barcode" db:"barcode"`
+	Location      string  `json:"location" db:"location"`
+	Expected      int     `json:"expected" db:"expected"`
+	Counted       int     `json:"counted" db:"counted"`
+	IsCounted     bool    `json:"is_counted" db:"is_counted"`
+	Variance      int     `json:"variance" db:"variance"`
+	UnitCost      float64 `json:"unit_cost" db:"unit_cost"`
+	VarianceValue float64 `json:"variance_value" db:"variance_value"`
+}
+
+type StocktakeLineFilter struct {
+	ID            string `json:"-" db:"id"`
+	OnlyVariances bool   `json:"only_variances" form:"only_variances"`
+	Uncounted     bool   `json:"uncounted" form:"uncounted"`
+}
+
+// StocktakeVariance is the variance list of a stocktake with its cost impact in the base currency.
+type StocktakeVariance struct {
+	Stocktake
+	Currency  string          `json:"currency" db:"-"`
+	Shortage  float64         `json:"shortage" db:"-"` // value of missing goods
+	Surplus   float64         `json:"surplus" db:"-"`  // value of goods found over the expected stock
+	Net       float64         `json:"net" db:"-"`
+	Uncounted int             `json:"uncounted" db:"-"`
+	Lines     []StocktakeLine `json:"lines" db:"-"`
+}
+
+// StocktakePost posts the variances as adjustments. Products never counted are skipped unless ZeroUncounted
+// is set, then they are written down to zero.
+type StocktakePost struct {
+	ID            string `json:"-" db:"id"`
+	PostedBy      string `json:"posted_by" db:"posted_by"`
+	ZeroUncounted bool   `json:"zero_uncounted" db:"zero_uncounted"`
+}
+
+// --------------- Write-off structs for repo -----------------------------------------------
+
+type WriteOffItemReq struct {
+	ProductID string `json:"product_id" db:"product_id"`
+	Quantity  int    `json:"quantity" db:"quantity"`
+}
+
+// WriteOffRequest takes damaged, expired or lost goods out of stock. Write-offs costing more than the
+// approval threshold wait for an owner, the others are posted at once.
+type WriteOffRequest struct {
+	Reason    string            `json:"reason" db:"reason"` // damaged, expired, lost or other
+	Note      string            `json:"note" db:"note"`
+	CreatedBy string            `json:"created_by" db:"created_by"`
+	Items     []WriteOffItemReq `json:"items" db:"-"`
+}
+
+type WriteOffItem struct {
+	ID          string  `json:"id" db:"id"`
+	WriteOffID  string  `json:"write_off_id" db:"write_off_id"`
+	ProductID   string  `json:"product_id" db:"product_id"`
+	ProductName string  `json:"product_name" db:"product_name"`
+	Quantity    int     `json:"quantity" db:"quantity"`
+	UnitCost    float64 `json:"unit_cost" db:"unit_cost"`
+	TotalCost   float64 `json:"total_cost" db:"total_cost"`
+}
+
+// WriteOff is valued at the average cost of its goods in the base currency when it is posted.
+type WriteOff struct {
+	ID           string         `json:"id" db:"id"`
+	Reason       string         `json:"reason" db:"reason"`
+	Note         string         `json:"note" db:"note"`
+	Status       string         `json:"status" db:"status"` // pending, approved or rejected
+	TotalCost    float64        `json:"total_cost" db:"total_cost"`
+	CreatedBy    string         `json:"created_by" db:"created_by"`
+	CreatedAt    string         `json:"created_at" db:"created_at"`
+	ApprovedBy   string         `json:"approved_by" db:"approved_by"`
+	ApprovedAt   string         `json:"approved_at" db:"approved_at"`
+	RejectReason string         `json:"reject_reason" db:"reject_reason"`
+	Items        []WriteOffItem `json:"items,omitempty" db:"-"`
+}
+
+type WriteOffID struct {
+	ID string `json:"id" db:"id"`
+}
+
+// WriteOffDecision approves or rejects a pending write-off, a rejection needs a reason.
+type WriteOffDecision struct {
+	ID     string `json:"-" db:"id"`
+	UserID string `json:"user_id" db:"user_id"`
+	Reason string `json:"reason" db:"reason"`
+}
+
+type WriteOffFilter struct {
+	Status string `json:"status" form:"status"`
+	Reason string `json:"reason" form:"reason"`
+	From   string `json:"from" form:"from"`
+	To     string `json:"to" form:"to"`
+}
+
+type WriteOffList struct {
+	WriteOffs []WriteOff `json:"write_offs"`
+	Total     float64    `json:"total"` // cost of the approved write-offs in the list
+	Currency  string     `json:"currency"`
+}
+
+// WriteOffThreshold is the cost in the base currency above which a write-off needs approval.
+type WriteOffThreshold struct {
+	Amount   float64 `json:"amount" db:"amount"`
+	Currency string  `json:"currency" db:"-"`
+}
+
+// --------------- Reorder structs for repo -----------------------------------------------
+
+// ReorderPoint is the minimum stock of a product, how much to order when it falls to it and from whom.
+// A product without a minimum stock is not watched.
+type ReorderPoint struct {
+	ProductID           string `json:"product_id" db:"product_id"`
+	ProductName         string `json:"product_name" db:"product_name"`
+	Stock               int    `json:"stock" db:"stock"`
+	MinStock            *int   `json:"min_stock" db:"min_stock"`
+	ReorderQty          *int   `json:"reorder_qty" db:"reorder_qty"`
+	PreferredSupplierID string `json:"preferred_supplier_id" db:"preferred_supplier_id"`
+	SupplierName        string `json:"supplier_name" db:"supplier_name"`
+}
+
+type LowStockFilter struct {
+	CategoryID string `json:"category_id" form:"category_id"`
+}
+
+// LowStockItem is a product at or below its minimum stock.
+type LowStockItem struct {
+	ProductID           string `json:"product_id" db:"product_id"`
+	ProductName         string `json:"product_name" db:"product_name"`
+	CategoryID          string `json:"category_id" db:"category_id"`
+	Stock               int    `json:"stock" db:"stock"`
+	MinStock            int    `json:"min_stock" db:"min_stock"`
+	ReorderQty          int    `json:"reorder_qty" db:"reorder_qty"`
+	Shortfall           int    `json:"shortfall" db:"shortfall"` // missing to reach the minimum
+	PreferredSupplierID string `json:"preferred_supplier_id" db:"preferred_supplier_id"`
+	SupplierName        string `json:"supplier_name" db:"supplier_name"`
+}
+
+type LowStockList struct {
+	Products []LowStockItem `json:"products"`
+}
+
+// ReorderFilter drafts orders from the sales of the last Days days, 30 by default, to cover the next
+// CoverDays days, 30 by default.
+type ReorderFilter struct {
+	Days       int    `json:"days" form:"days"`
+	CoverDays  int    `json:"cover_days" form:"cover_days"`
+	SupplierID string `json:"supplier_id" form:"supplier_id"`
+}
+
+// ReorderCandidate is a watched or selling product with its sales and the supplier and price it is
+// ordered at: the preferred supplier, else the last one, at the last purchase price.
+type ReorderCandidate struct {
+	ProductID    string  `json:"product_id" db:"product_id"`
+	ProductName  string  `json:"product_name" db:"product_name"`
+	Stock        int     `json:"stock" db:"stock"`
+	MinStock     int     `json:"min_stock" db:"min_stock"`
+	ReorderQty   int     `json:"reorder_qty" db:"reorder_qty"`
+	IsWatched    bool    `json:"-" db:"is_watched"`
+	Sold         int     `json:"sold" db:"sold"` // net of returns over the sales window
+	SupplierID   string  `json:"-" db:"supplier_id"`
+	SupplierName string  `json:"-" db:"supplier_name"`
+	UnitPrice    float64 `json:"unit_price" db:"unit_price"`
+	Currency     string  `json:"currency" db:"currency"`
+}
+
+type SuggestedOrderLine struct {
+	ReorderCandidate
+	DailySales float64 `json:"daily_sales"`
+	Quantity   int     `json:"quantity"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+// SuggestedOrder is a draft purchase order for one supplier, SupplierID is empty for products that were
+// never bought and have no preferred supplier.
+type SuggestedOrder struct {
+	SupplierID   string               `json:"supplier_id"`
+	SupplierName string               `json:"supplier_name"`
+	Lines        []SuggestedOrderLine `json:"lines"`
+}
+
+type ReorderSuggestions struct {
+	Days      int              `json:"days"`
+	CoverDays int              `json:"cover_days"`
+	Orders    []SuggestedOrder `json:"orders"`
+}
+
+type StockAlert struct {
+	ID        string `json:"id" db:"id"`
+	Products  int    `json:"products" db:"products"`
+	Channel   string `json:"channel" db:"channel"`
+	Recipient string `json:"recipient" db:"recipient"`
+	Status    string `json:"status" db:"status"`
+	Error     string `json:"error" db:"error"`
+	SentAt    string `json:"sent_at" db:"sent_at"`
+}
+
+type StockAlertList struct {
+	Alerts []StockAlert `json:"alerts"`
+}
+
+type StockAlertResult struct {
+	Products int `json:"products"`
+	Sent     int `json:"sent"`
+	Failed   int `json:"failed"`
+}
+
+// --------------- Stock lot structs for repo -----------------------------------------------
+
+// Lot is a batch of a product received with a lot number or an expiry date.
+type Lot struct {
+	ID             string `json:"id" db:"id"`
+	ProductID      string `json:"product_id" db:"product_id"`
+	ProductName    string `json:"product_name" db:"product_name"`
+	PurchaseItemID string `json:"purchase_item_id" db:"purchase_item_id"`
+	LotNumber      string `json:"lot_number" db:"lot_number"`
+	ExpiryDate     string `json:"expiry_date" db:"expiry_date"`
+	DaysLeft       *int   `json:"days_left" db:"days_left"` // negative once expired, empty without an expiry date
+	IsExpired      bool   `json:"is_expired" db:"is_expired"`
+	Quantity       int    `json:"quantity" db:"quantity"`
+	Remaining      int    `json:"remaining" db:"remaining"`
+	CreatedAt      string `json:"created_at" db:"created_at"`
+}
+
+// LotFilter lists the lots still in stock, or all of them with All.
+type LotFilter struct {
+	ProductID string `json:"product_id" form:"product_id"`
+	All       bool   `json:"all" form:"all"`
+}
+
+type LotList struct {
+	Lots []Lot `json:"lots"`
+}
+
+// ExpiringLotsFilter lists the lots in stock that expire within Days days, 30 by default, and those expired.
+type ExpiringLotsFilter struct {
+	Days       int    `json:"days" form:"days"`
+	CategoryID string `json:"category_id" form:"category_id"`
+}
+
+type ExpiringLots struct {
+	Days     int   `json:"days"`
+	Lots     []Lot `json:"lots"`
+	Quantity int   `json:"quantity"`
+}
+
+type LotSettings struct {
+	BlockExpiredSales bool `json:"block_expired_sales"`
+}
+
+// --------------- Wallet structs for repo -----------------------------------------------
+
+type WalletRequest struct {
+	ID            string `json:"id" db:"id"`
+	Name          string `json:"name" db:"name"`
+	Currency      string `json:"currency" db:"currency"`
+	PaymentMethod string `json:"payment_method" db:"payment_method"`
+	IsDefault     bool   `json:"is_default" db:"is_default"`
+}
+
+type Wallet struct {
+	ID            string  `json:"id" db:"id"`
+	Name          string  `json:"name" db:"name"`
+	Currency      string  `json:"currency" db:"currency"`
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	IsDefault     bool    `json:"is_default" db:"is_default"`
+	Balance       float64 `json:"balance" db:"balance"`
+	CreatedAt     string  `json:"created_at" db:"created_at"`
+}
+
+type WalletID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type WalletList struct {
+	Wallets []Wallet `json:"wallets"`
+}
+
+type WalletTransferRequest struct {
+	FromWalletID string  `json:"from_wallet_id" db:"from_wallet_id"`
+	ToWalletID   string  `json:"to_wallet_id" db:"to_wallet_id"`
+	Amount       float64 `json:"amount" db:"amount"`
+	ToAmount     float64 `json:"to_amount" db:"to_amount"`
+	UserID       string  `json:"user_id" db:"user_id"`
+	Description  string  `json:"description" db:"description"`
+}
+
+type WalletTransfer struct {
+	ID           string  `json:"id" db:"id"`
+	FromWalletID string  `json:"from_wallet_id" db:"from_wallet_id"`
+	ToWalletID   string  `json:"to_wallet_id" db:"to_wallet_id"`
+	Amount       float64 `json:"amount" db:"amount"`
+	ToAmount     float64 `json:"to_amount" db:"to_amount"`
+	UserID       string  `json:"user_id" db:"user_id"`
+	Description  string  `json:"description" db:"description"`
+	CreatedAt    string  `json:"created_at" db:"created_at"`
+}
+
+type WalletTransferFilter struct {
+	WalletID string `json:"wallet_id" form:"wallet_id" db:"wallet_id"`
+	From     string `json:"from" form:"from" db:"from"`
+	To       string `json:"to" form:"to" db:"to"`
+}
+
+type WalletTransferList struct {
+	Transfers []WalletTransfer `json:"transfers"`
+}
+
+type WalletBalanceFilter struct {
+	Date string `json:"date" form:"date" db:"date"`
+}
+
+type WalletBalance struct {
+	WalletID      string  `json:"wallet_id" db:"wallet_id"`
+	Name          string  `json:"name" db:"name"`
+	Currency      string  `json:"currency" db:"currency"`
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	Balance       float64 `json:"balance" db:"balance"`
+	ExchangeRate  float64 `json:"exchange_rate" db:"exchange_rate"`
+	BaseBalance   float64 `json:"base_balance" db:"base_balance"`
+}
+
+type WalletBalanceList struct {
+	Date     string          `json:"date"`
+	Currency string          `json:"currency"`
+	Wallets  []WalletBalance `json:"wallets"`
+	Total    float64         `json:"total"`
+}
+
+// --------------- Exchange rate structs for repo -----------------------------------------------
+
+type ExchangeRateRequest struct {
+	Currency string  `json:"currency" db:"currency"`
+	Rate     float64 `json:"rate" db:"rate"`
+	RateDate string  `json:"rate_date" db:"rate_date"`
+}
+
+type ExchangeRate struct {
+	ID        string  `json:"id" db:"id"`
+	Currency  string  `json:"currency" db:"currency"`
+	Rate      float64 `json:"rate" db:"rate"`
+	RateDate  string  `json:"rate_date" db:"rate_date"`
+	Source    string  `json:"source" db:"source"`
+	CreatedAt string  `json:"created_at" db:"created_at"`
+}
+
+type ExchangeRateFilter struct {
+	Currency string `json:"currency" form:"currency" db:"currency"`
+	From     string `json:"from" form:"from" db:"from"`
+	To       string `json:"to" form:"to" db:"to"`
+}
+
+type ExchangeRateList struct {
+	BaseCurrency string         `json:"base_currency"`
+	Rates        []ExchangeRate `json:"rates"`
+}
+
+type BaseCurrency struct {
+	Currency string `json:"currency" db:"currency"`
+}
+
+type RateSyncResult struct {
+	Date    string `json:"date"`
+	Updated int    `json:"updated"`
+}
+
+// --------------- Shift structs for repo -----------------------------------------------
+
+type BranchRequest struct {
+	Name string `json:"name" db:"name"`
+}
+
+type Branch struct {
+	ID        string `json:"id" db:"id"`
+	Name      string `json:"name" db:"name"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+type BranchList struct {
+	Branches []Branch `json:"branches"`
+}
+
+type RegisterRequest struct {
+	Name     string `json:"name" db:"name"`
+	WalletID string `json:"wallet_id" db:"wallet_id"`
+	BranchID string `json:"branch_id" db:"branch_id"`
+}
+
+type Register struct {
+	ID         string `json:"id" db:"id"`
+	Name       string `json:"name" db:"name"`
+	WalletID   string `json:"wallet_id" db:"wallet_id"`
+	WalletName string `json:"wallet_name" db:"wallet_name"`
+	BranchID   string `json:"branch_id" db:"branch_id"`
+	BranchName string `json:"branch_name" db:"branch_name"`
+	CreatedAt  string `json:"created_at" db:"created_at"`
+}
+
+type RegisterList struct {
+	Registers []Register `json:"registers"`
+}
+
+type ShiftOpen struct {
+	RegisterID  string  `json:"register_id" db:"register_id"`
+	UserID      string  `json:"user_id" db:"user_id"`
+	OpeningCash float64 `json:"opening_cash" db:"opening_cash"`
+}
+
+type ShiftClose struct {
+	ID          string  `json:"id" db:"id"`
+	CountedCash float64 `json:"counted_cash" db:"counted_cash"`
+	Note        string  `json:"note" db:"note"`
+}
+
+type Shift struct {
+	ID           string   `json:"id" db:"id"`
+	RegisterID   string   `json:"register_id" db:"register_id"`
+	RegisterName string   `json:"register_name" db:"register_name"`
+	WalletID     string   `json:"wallet_id" db:"wallet_id"`
+	UserID       string   `json:"user_id" db:"user_id"`
+	Status       string   `json:"status" db:"status"`
+	OpeningCash  float64  `json:"opening_cash" db:"opening_cash"`
+	OpenedAt     string   `json:"opened_at" db:"opened_at"`
+	ExpectedCash *float64 `json:"expected_cash" db:"expected_cash"`
+	CountedCash  *float64 `json:"counted_cash" db:"counted_cash"`
+	Discrepancy  *float64 `json:"discrepancy" db:"discrepancy"`
+	Note         string   `json:"note" db:"note"`
+	ClosedAt     string   `json:"closed_at" db:"closed_at"`
+}
+
+type ShiftID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type ShiftFilter struct {
+	RegisterID string `json:"register_id" form:"register_id" db:"register_id"`
+	UserID     string `json:"user_id" form:"user_id" db:"user_id"`
+	Status     string `json:"status" form:"status" db:"status"`
+}
+
+type ShiftList struct {
+	Shifts []Shift `json:"shifts"`
+}
+
+type ShiftMethodTotal struct {
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	Income        float64 `json:"income" db:"income"`
+	Expense       float64 `json:"expense" db:"expense"`
+	Net           float64 `json:"net" db:"net"`
+}
+
+type ShiftReport struct {
+	Type         string             `json:"type"`
+	Shift        Shift              `json:"shift"`
+	Currency     string             `json:"currency"`
+	SalesCount   int                `json:"sales_count"`
+	SalesTotal   float64            `json:"sales_total"`
+	ByMethod     []ShiftMethodTotal `json:"by_method"`
+	Payments     []SalePaymentTotal `json:"payments"`
+	ExpectedCash float64            `json:"expected_cash"`
+	CountedCash  *float64           `json:"counted_cash,omitempty"`
+	Discrepancy  *float64           `json:"discrepancy,omitempty"`
+}
+
 // --------------- Sales structs for repo -----------------------------------------------
 
 type SaleRequest struct {
-	ClientID      string      `json:"client_id" db:"client_id"`
-	SoldBy        string      `json:"sold_by" db:"sold_by"`
-	PaymentMethod string      `json:"payment_method" db:"payment_method"`
-	SoldProducts  []SalesItem `json:"products" db:"products"`
+	ClientID       string               `json:"client_id" db:"client_id"`
+	SoldBy         string               `json:"sold_by" db:"sold_by"`
+	PaymentMethod  string               `json:"payment_method" db:"payment_method"`
+	Currency       string               `json:"currency" db:"currency"`
+	PaidAmount     float64              `json:"paid_amount" db:"paid_amount"`
+	OnCredit       bool                 `json:"on_credit" db:"on_credit"`
+	NextPayment    string               `json:"next_payment" db:"next_payment"`
+	Installments   int                  `json:"installments" db:"installments"`
+	OverrideBy     string               `json:"override_by" db:"override_by"`
+	OverrideReason string               `json:"override_reason" db:"override_reason"`
+	Payments       []SalePaymentRequest `json:"payments" db:"-"`
+	SoldProducts   []SalesItem          `json:"products" db:"products"`
+}
+
+type SalePaymentRequest struct {
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	Currency      string  `json:"currency" db:"currency"`
+	Amount        float64 `json:"amount" db:"amount"`
+}
+
+type SalePayment struct {
+	ID            string  `json:"id" db:"id"`
+	SaleID        string  `json:"sale_id" db:"sale_id"`
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	Currency      string  `json:"currency" db:"currency"`
+	Amount        float64 `json:"amount" db:"amount"`
+	Change        float64 `json:"change" db:"change_amount"`
+	ExchangeRate  float64 `json:"exchange_rate" db:"exchange_rate"`
+	CreatedAt     string  `json:"created_at" db:"created_at"`
+}
+
+type SalePaymentTotal struct {
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	Currency      string  `json:"currency" db:"currency"`
+	Payments      int     `json:"payments" db:"payments"`
+	Amount        float64 `json:"amount" db:"amount"`
+	Change        float64 `json:"change" db:"change_amount"`
+	Net           float64 `json:"net" db:"net"`
+	BaseNet       float64 `json:"base_net" db:"base_net"`
+}
+
+type DailySalesFilter struct {
+	Date string `json:"date" form:"date"`
+}
+
+type DailySalesReport struct {
+	Date        string             `json:"date"`
+	Currency    string             `json:"currency"`
+	SalesCount  int                `json:"sales_count" db:"sales_count"`
+	SalesTotal  float64            `json:"sales_total" db:"sales_total"`
+	PaidTotal   float64            `json:"paid_total"`
+	CreditTotal float64            `json:"credit_total" db:"credit_total"`
+	ChangeTotal float64            `json:"change_total" db:"change_total"`
+	Payments    []SalePaymentTotal `json:"payments"`
 }
 
 type SalesItemRequest struct {
@@ -178,11 +1560,20 @@ type SalesItemRequest struct {
 }
 
 type SalesTotal struct {
-	ClientID       string      `json:"client_id" db:"client_id"`
-	SoldBy         string      `json:"sold_by" db:"sold_by"`
-	TotalSalePrice float64     `json:"total_sale_price" db:"total_sale_price"`
-	PaymentMethod  string      `json:"payment_method" db:"payment_method"`
-	SoldProducts   []SalesItem `json:"products" db:"products"`
+	ClientID       string            `json:"client_id" db:"client_id"`
+	SoldBy         string            `json:"sold_by" db:"sold_by"`
+	TotalSalePrice float64           `json:"total_sale_price" db:"total_sale_price"`
+	PaymentMethod  string            `json:"payment_method" db:"payment_method"`
+	Currency       string            `json:"currency" db:"currency"`
+	ExchangeRate   float64           `json:"exchange_rate" db:"exchange_rate"`
+	PaidAmount     float64           `json:"paid_amount" db:"paid_amount"`
+	OnCredit       bool              `json:"on_credit" db:"on_credit"`
+	NextPayment    string            `json:"next_payment" db:"next_payment"`
+	Schedule       []DebtInstallment `json:"schedule" db:"-"`
+	OverrideBy     string            `json:"override_by" db:"override_by"`
+	OverrideReason string            `json:"override_reason" db:"override_reason"`
+	Payments       []SalePayment     `json:"payments" db:"-"`
+	SoldProducts   []SalesItem       `json:"products" db:"products"`
 }
 
 type SalesItemTotal struct {
@@ -194,13 +1585,19 @@ type SalesItemTotal struct {
 }
 
 type SaleResponse struct {
-	ID             string      `json:"id" db:"id"`
-	ClientID       string      `json:"client_id" db:"client_id"`
-	SoldBy         string      `json:"sold_by" db:"sold_by"`
-	TotalSalePrice float64     `json:"total_sale_price" db:"total_sale_price"`
-	PaymentMethod  string      `json:"payment_method" db:"payment_method"`
-	CreatedAt      string      `json:"created_at" db:"created_at"`
-	SoldProducts   []SalesItem `json:"products" db:"products"`
+	ID             string        `json:"id" db:"id"`
+	ClientID       string        `json:"client_id" db:"client_id"`
+	SoldBy         string        `json:"sold_by" db:"sold_by"`
+	TotalSalePrice float64       `json:"total_sale_price" db:"total_sale_price"`
+	PaymentMethod  string        `json:"payment_method" db:"payment_method"`
+	Currency       string        `json:"currency" db:"currency"`
+	ExchangeRate   float64       `json:"exchange_rate" db:"exchange_rate"`
+	Change         float64       `json:"change" db:"change_amount"`
+	ShiftID        string        `json:"shift_id" db:"shift_id"`
+	CreatedAt      string        `json:"created_at" db:"created_at"`
+	SoldProducts   []SalesItem   `json:"products" db:"products"`
+	Payments       []SalePayment `json:"payments" db:"-"`
+	Debt           *Debt         `json:"debt,omitempty" db:"-"`
 }
 
 type SalesItem struct {
@@ -210,6 +1607,7 @@ type SalesItem struct {
 	Quantity   int     `json:"quantity" db:"quantity"`
 	SalePrice  float64 `json:"sale_price" db:"sale_price"`
 	TotalPrice float64 `json:"total_price" db:"total_price"`
+	COGS       float64 `json:"cogs" db:"cogs"` // cost of the goods sold in the base currency
 }
 
 type SaleUpdate struct {
@@ -233,6 +1631,325 @@ type SaleFilter struct {
 	SoldBy    string `json:"sold_by" db:"sold_by"`
 }
 
+// --------------- Sale return structs for repo -----------------------------------------------
+
+type SaleReturnItemReq struct {
+	SalesItemID string `json:"sales_item_id" db:"sales_item_id"`
+	Quantity    int    `json:"quantity" db:"quantity"`
+	Disposition string `json:"disposition" db:"disposition"` // restock (default) or write_off
+}
+
+// SaleReturnRequest returns lines of a sale. What the return is worth first reduces the unpaid debt of the
+// sale, the rest is refunded with the refund method: uzs, usd, card or store_credit.
+type SaleReturnRequest struct {
+	SaleID       string              `json:"sale_id" db:"sale_id"`
+	ReturnedBy   string              `json:"returned_by" db:"returned_by"`
+	Reason       string              `json:"reason" db:"reason"`
+	RefundMethod string              `json:"refund_method" db:"refund_method"`
+	Items        []SaleReturnItemReq `json:"items" db:"-"`
+}
+
+type SaleReturnItem struct {
+	ID          string  `json:"id" db:"id"`
+	ReturnID    string  `json:"return_id" db:"return_id"`
+	SalesItemID string  `json:"sales_item_id" db:"sales_item_id"`
+	ProductID   string  `json:"product_id" db:"product_id"`
+	ProductName string  `json:"product_name" db:"product_name"`
+	Quantity    int     `json:"quantity" db:"quantity"`
+	UnitPrice   float64 `json:"unit_price" db:"unit_price"`
+	TotalPrice  float64 `json:"total_price" db:"total_price"`
+	COGS        float64 `json:"cogs" db:"cogs"`
+	Disposition string  `json:"disposition" db:"disposition"`
+}
+
+type SaleReturn struct {
+	ID            string           `json:"id" db:"id"`
+	SaleID        string           `json:"sale_id" db:"sale_id"`
+	ClientID      string           `json:"client_id" db:"client_id"`
+	ReturnedBy    string           `json:"returned_by" db:"returned_by"`
+	Reason        string           `json:"reason" db:"reason"`
+	Currency      string           `json:"currency" db:"currency"`
+	TotalAmount   float64          `json:"total_amount" db:"total_amount"`
+	DebtReduction float64          `json:"debt_reduction" db:"debt_reduction"`
+	RefundAmount  float64          `json:"refund_amount" db:"refund_amount"`
+	RefundMethod  string           `json:"refund_method" db:"refund_method"`
+	CreatedAt     string           `json:"created_at" db:"created_at"`
+	Items         []SaleReturnItem `json:"items,omitempty" db:"-"`
+}
+
+type SaleReturnID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type SaleReturnFilter struct {
+	SaleID       string `json:"sale_id" form:"sale_id"`
+	ClientID     string `json:"client_id" form:"client_id"`
+	ReturnedBy   string `json:"returned_by" form:"returned_by"`
+	RefundMethod string `json:"refund_method" form:"refund_method"`
+	From         string `json:"from" form:"from"`
+	To           string `json:"to" form:"to"`
+}
+
+type SaleReturnList struct {
+	Returns []SaleReturn `json:"returns"`
+}
+
+// ReturnableItem is a line of a sale with what is left to return.
+type ReturnableItem struct {
+	SalesItemID string  `json:"sales_item_id" db:"sales_item_id"`
+	ProductID   string  `json:"product_id" db:"product_id"`
+	ProductName string  `json:"product_name" db:"product_name"`
+	Sold        int     `json:"sold" db:"sold"`
+	Returned    int     `json:"returned" db:"returned"`
+	Returnable  int     `json:"returnable" db:"returnable"`
+	SalePrice   float64 `json:"sale_price" db:"sale_price"`
+	COGS        float64 `json:"cogs" db:"cogs"`
+}
+
+type ReturnableItemList struct {
+	SaleID string           `json:"sale_id"`
+	Items  []ReturnableItem `json:"items"`
+}
+
+// --------------- Debt structs for repo -----------------------------------------------
+
+type Debt struct {
+	ID           string            `json:"id" db:"id"`
+	OrderID      string            `json:"order_id" db:"order_id"`
+	ClientID     string            `json:"client_id" db:"client_id"`
+	AmountPaid   float64           `json:"amount_paid" db:"amount_paid"`
+	AmountUnpaid float64           `json:"amount_unpaid" db:"amount_unpaid"`
+	TotalDebt    float64           `json:"total_debt" db:"total_debt"`
+	Currency     string            `json:"currency" db:"currency"`
+	NextPayment  string            `json:"next_payment" db:"next_payment"`
+	LastPaidDay  string            `json:"last_paid_day" db:"last_paid_day"`
+	IsFullyPaid  bool              `json:"is_fully_paid" db:"is_fully_paid"`
+	IsOverdue    bool              `json:"is_overdue" db:"is_overdue"`
+	OverdueSince string            `json:"overdue_since" db:"overdue_since"`
+	RecipientID  string            `json:"recipient_id" db:"recipient_id"`
+	CreatedAt    string            `json:"created_at" db:"created_at"`
+	Schedule     []DebtInstallment `json:"schedule,omitempty" db:"-"`
+}
+
+type DebtID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type DebtFilter struct {
+	ClientID    string `json:"client_id" form:"client_id" db:"client_id"`
+	RecipientID string `json:"recipient_id" form:"recipient_id" db:"recipient_id"`
+	IsFullyPaid string `json:"is_fully_paid" form:"is_fully_paid" db:"is_fully_paid"`
+	IsOverdue   string `json:"is_overdue" form:"is_overdue" db:"is_overdue"`
+}
+
+type DebtList struct {
+	Debts []Debt `json:"debts"`
+}
+
+type DebtPaymentRequest struct {
+	DebtID        string  `json:"debt_id" db:"debt_id"`
+	Amount        float64 `json:"amount" db:"amount"`
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	PaidBy        string  `json:"paid_by" db:"paid_by"`
+	NextPayment   string  `json:"next_payment" db:"next_payment"`
+}
+
+type DebtPayment struct {
+	ID            string  `json:"id" db:"id"`
+	DebtID        string  `json:"debt_id" db:"debt_id"`
+	PaymentDate   string  `json:"payment_date" db:"payment_date"`
+	Amount        float64 `json:"amount" db:"amount"`
+	PaymentMethod string  `json:"payment_method" db:"payment_method"`
+	PaidBy        string  `json:"paid_by" db:"paid_by"`
+}
+
+type DebtPaymentList struct {
+	Payments []DebtPayment `json:"payments"`
+}
+
+type DebtClose struct {
+	ID            string `json:"id" db:"id"`
+	PaymentMethod string `json:"payment_method" db:"payment_method"`
+	ClosedBy      string `json:"closed_by" db:"closed_by"`
+}
+
+type DebtScheduleRequest struct {
+	DebtID       string    `json:"debt_id" db:"debt_id"`
+	Installments int       `json:"installments" db:"installments"`
+	FirstDueDate string    `json:"first_due_date" db:"first_due_date"`
+	Amounts      []float64 `json:"amounts" db:"amounts"`
+}
+
+type DebtInstallment struct {
+	ID            string  `json:"id" db:"id"`
+	DebtID        string  `json:"debt_id" db:"debt_id"`
+	InstallmentNo int     `json:"installment_no" db:"installment_no"`
+	DueDate       string  `json:"due_date" db:"due_date"`
+	Amount        float64 `json:"amount" db:"amount"`
+	AmountPaid    float64 `json:"amount_paid" db:"amount_paid"`
+	IsPaid        bool    `json:"is_paid" db:"is_paid"`
+	PaidAt        string  `json:"paid_at" db:"paid_at"`
+}
+
+type DebtSchedule struct {
+	DebtID       string            `json:"debt_id" db:"debt_id"`
+	Installments []DebtInstallment `json:"installments" db:"installments"`
+}
+
+type AgingFilter struct {
+	GroupBy string `json:"group_by" form:"group_by" db:"group_by"` // client or seller
+}
+
+type AgingRow struct {
+	GroupID    string  `json:"group_id" db:"group_id"`
+	Name       string  `json:"name" db:"name"`
+	Current    float64 `json:"current" db:"current"`
+	Days1To30  float64 `json:"days_1_30" db:"days_1_30"`
+	Days31To60 float64 `json:"days_31_60" db:"days_31_60"`
+	Days61To90 float64 `json:"days_61_90" db:"days_61_90"`
+	Over90     float64 `json:"over_90" db:"over_90"`
+	Total      float64 `json:"total" db:"total"`
+}
+
+type AgingReport struct {
+	GroupBy  string     `json:"group_by"`
+	Currency string     `json:"currency"`
+	Rows     []AgingRow `json:"rows"`
+	Total    AgingRow   `json:"total"`
+}
+
+// --------------- Client structs for repo -----------------------------------------------
+
+type Client struct {
+	ID             string   `json:"id" db:"id"`
+	FullName       string   `json:"full_name" db:"full_name"`
+	Address        string   `json:"address" db:"address"`
+	Phone          string   `json:"phone" db:"phone"`
+	Language       string   `json:"language" db:"language"`
+	TelegramChatID string   `json:"telegram_chat_id" db:"telegram_chat_id"`
+	CreditLimit    *float64 `json:"credit_limit" db:"credit_limit"`
+	PaymentTerms   int      `json:"payment_terms_days" db:"payment_terms_days"`
+	StoreCredit    float64  `json:"store_credit" db:"store_credit"` // in the base currency, from refunds of returns
+	CreatedAt      string   `json:"created_at" db:"created_at"`
+}
+
+type ClientID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type ClientContact struct {
+	ID             string `json:"id" db:"id"`
+	Phone          string `json:"phone" db:"phone"`
+	Language       string `json:"language" db:"language"`
+	TelegramChatID string `json:"telegram_chat_id" db:"telegram_chat_id"`
+}
+
+// CreditLimitUpdate sets the credit limit of a client, a null limit removes it.
+type CreditLimitUpdate struct {
+	ID          string   `json:"id" db:"id"`
+	CreditLimit *float64 `json:"credit_limit" db:"credit_limit"`
+}
+
+type CreditOverride struct {
+	ID          string  `json:"id" db:"id"`
+	ClientID    string  `json:"client_id" db:"client_id"`
+	SaleID      string  `json:"sale_id" db:"sale_id"`
+	ApprovedBy  string  `json:"approved_by" db:"approved_by"`
+	Reason      string  `json:"reason" db:"reason"`
+	CreditLimit float64 `json:"credit_limit" db:"credit_limit"`
+	Outstanding float64 `json:"outstanding" db:"outstanding"`
+	SaleDebt    float64 `json:"sale_debt" db:"sale_debt"`
+	CreatedAt   string  `json:"created_at" db:"created_at"`
+}
+
+type CreditOverrideList struct {
+	Overrides []CreditOverride `json:"overrides"`
+}
+
+type CreditReportFilter struct {
+	Threshold float64 `json:"threshold" form:"threshold" db:"threshold"` // share of the limit, 0.8 by default
+}
+
+type CreditReportRow struct {
+	ClientID    string  `json:"client_id" db:"client_id"`
+	FullName    string  `json:"full_name" db:"full_name"`
+	CreditLimit float64 `json:"credit_limit" db:"credit_limit"`
+	Outstanding float64 `json:"outstanding" db:"outstanding"`
+	Utilization float64 `json:"utilization" db:"utilization"`
+	Status      string  `json:"status" db:"status"` // near or over
+}
+
+type CreditReport struct {
+	Threshold float64           `json:"threshold"`
+	Currency  string            `json:"currency"`
+	Clients   []CreditReportRow `json:"clients"`
+}
+
+// --------------- Reminder structs for repo -----------------------------------------------
+
+type ReminderRule struct {
+	ID         string `json:"id" db:"id"`
+	Name       string `json:"name" db:"name"`
+	Kind       string `json:"kind" db:"kind"` // before_due, on_due or overdue
+	Days       int    `json:"days" db:"days"`
+	Channel    string `json:"channel" db:"channel"` // sms or telegram
+	TemplateUz string `json:"template_uz" db:"template_uz"`
+	TemplateRu string `json:"template_ru" db:"template_ru"`
+	IsActive   bool   `json:"is_active" db:"is_active"`
+	CreatedAt  string `json:"created_at" db:"created_at"`
+}
+
+type ReminderRuleID struct {
+	ID string `json:"id" db:"id"`
+}
+
+type ReminderRuleList struct {
+	Rules []ReminderRule `json:"rules"`
+}
+
+type DueReminder struct {
+	RuleID        string  `json:"rule_id" db:"rule_id"`
+	Channel       string  `json:"channel" db:"channel"`
+	Template      string  `json:"template" db:"template"`
+	DebtID        string  `json:"debt_id" db:"debt_id"`
+	InstallmentID string  `json:"installment_id" db:"installment_id"`
+	DueDate       string  `json:"due_date" db:"due_date"`
+	Amount        float64 `json:"amount" db:"amount"`
+	Currency      string  `json:"currency" db:"currency"`
+	ClientName    string  `json:"client_name" db:"client_name"`
+	Recipient     string  `json:"recipient" db:"recipient"`
+	DaysOverdue   int     `json:"days_overdue" db:"days_overdue"`
+}
+
+type ReminderLog struct {
+	ID            string `json:"id" db:"id"`
+	RuleID        string `json:"rule_id" db:"rule_id"`
+	DebtID        string `json:"debt_id" db:"debt_id"`
+	InstallmentID string `json:"installment_id" db:"installment_id"`
+	DueDate       string `json:"due_date" db:"due_date"`
+	Channel       string `json:"channel" db:"channel"`
+	Recipient     string `json:"recipient" db:"recipient"`
+	Message       string `json:"message" db:"message"`
+	Status        string `json:"status" db:"status"`
+	Error         string `json:"error" db:"error"`
+	CreatedAt     string `json:"created_at" db:"created_at"`
+}
+
+type ReminderLogFilter struct {
+	DebtID  string `json:"debt_id" form:"debt_id" db:"debt_id"`
+	Status  string `json:"status" form:"status" db:"status"`
+	Channel string `json:"channel" form:"channel" db:"channel"`
+}
+
+type ReminderLogList struct {
+	Logs []ReminderLog `json:"logs"`
+}
+
+type ReminderRunResult struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
+
 // -------- User structs for Repo -----------------------------------------
 
 type User struct {
@@ -306,3 +2023,38 @@ type PhoneNumber struct {
 type Error struct {
 	Error error
 }
+
+// CreditLimitError is returned when a credit sale would push a client over the credit limit.
+type CreditLimitError struct {
+	ClientID    string  `json:"client_id"`
+	CreditLimit float64 `json:"credit_limit"`
+	Outstanding float64 `json:"outstanding"`
+	SaleDebt    float64 `json:"sale_debt"`
+}
+
+func (e *CreditLimitError) Error() string {
+	return fmt.Sprintf("credit limit %.2f exceeded: client owes %.2f and the sale adds %.2f",
+		e.CreditLimit, e.Outstanding, e.SaleDebt)
+}
+
+// StockShortage is a sold line asking for more of a product than is in stock. Line is its index in the request.
+type StockShortage struct {
+	Line        int    `json:"line"`
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Requested   int    `json:"requested"`
+	Available   int    `json:"available"`
+}
+
+// StockError is returned when lines of a sale exceed the stock of their products.
+type StockError struct {
+	Lines []StockShortage `json:"lines"`
+}
+
+func (e *StockError) Error() string {
+	parts := make([]string, 0, len(e.Lines))
+	for _, l := range e.Lines {
+		parts = append(parts, fmt.Sprintf("%s: requested %d, available %d", l.ProductName, l.Requested, l.Available))
+	}
+	return "not enough stock: " + strings.Join(parts, "; ")
+}
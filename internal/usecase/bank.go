@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/pkg/statement"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strings"
+)
+
+type BankUseCase struct {
+	repo BankRepo
+	log  *slog.Logger
+}
+
+func NewBankUseCase(repo BankRepo, log *slog.Logger) *BankUseCase {
+	return &BankUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// ImportStatement parses a CSV or MT940 statement of a wallet, saves its new lines and matches them
+// against the cash flow.
+func (b *BankUseCase) ImportStatement(in *entity.BankStatementRequest, file io.Reader) (*entity.BankStatement, error) {
+	in.Format = strings.ToLower(in.Format)
+	if in.Format == "" {
+		in.Format = "csv"
+		if strings.HasSuffix(strings.ToLower(in.FileName), ".sta") || strings.HasSuffix(strings.ToLower(in.FileName), ".940") {
+			in.Format = "mt940"
+		}
+	}
+
+	parsed, err := statement.Parse(in.Format, file)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing statement: %w", err)
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("statement has no transactions")
+	}
+
+	lines := make([]entity.BankLineRequest, 0, len(parsed))
+	for _, l := range parsed {
+		lines = append(lines, entity.BankLineRequest{
+			Date:        l.Date.Format("2006-01-02"),
+			Amount:      math.Round(l.Amount*100) / 100,
+			Reference:   l.Reference,
+			Description: l.Description,
+		})
+	}
+
+	res, err := b.repo.ImportStatement(in, lines)
+	if err != nil {
+		b.log.Error("Error importing bank statement", "error", err.Error())
+		return nil, fmt.Errorf("error importing bank statement: %w", err)
+	}
+
+	return res, nil
+}
+
+// AutoMatch suggests matches again for the lines of a statement that are still unmatched.
+func (b *BankUseCase) AutoMatch(in *entity.BankStatementID) (*entity.BankStatement, error) {
+	res, err := b.repo.AutoMatch(in)
+	if err != nil {
+		b.log.Error("Error matching bank statement", "error", err.Error())
+		return nil, fmt.Errorf("error matching bank statement: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetStatement returns a statement with the number of lines in each status.
+func (b *BankUseCase) GetStatement(in *entity.BankStatementID) (*entity.BankStatement, error) {
+	res, err := b.repo.GetStatement(in)
+	if err != nil {
+		b.log.Error("Error fetching bank statement", "error", err.Error())
+		return nil, fmt.Errorf("error fetching bank statement: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetStatements lists imported statements, newest first.
+func (b *BankUseCase) GetStatements() (*entity.BankStatementList, error) {
+	res, err := b.repo.GetStatements()
+	if err != nil {
+		b.log.Error("Error fetching bank statements", "error", err.Error())
+		return nil, fmt.Errorf("error fetching bank statements: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetStatementLines lists the lines of a statement, optionally only those in one status.
+func (b *BankUseCase) GetStatementLines(in *entity.BankLineFilter) (*entity.BankLineList, error) {
+	if in.Status != "" && in.Status != "unmatched" && in.Status != "suggested" && in.Status != "matched" {
+		return nil, fmt.Errorf("status must be unmatched, suggested or matched")
+	}
+
+	res, err := b.repo.GetStatementLines(in)
+	if err != nil {
+		b.log.Error("Error fetching statement lines", "error", err.Error())
+		return nil, fmt.Errorf("error fetching statement lines: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetLineCandidates lists the cash flow entries a line can be matched to.
+func (b *BankUseCase) GetLineCandidates(in *entity.BankLineID) (*entity.CashFlowList, error) {
+	res, err := b.repo.GetLineCandidates(in)
+	if err != nil {
+		b.log.Error("Error fetching statement line candidates", "error", err.Error())
+		return nil, fmt.Errorf("error fetching statement line candidates: %w", err)
+	}
+
+	return res, nil
+}
+
+// ConfirmLine accepts the suggested match of a line.
+func (b *BankUseCase) ConfirmLine(in *entity.BankLineMatch) (*entity.BankStatementLine, error) {
+	res, err := b.repo.ConfirmLine(in)
+	if err != nil {
+		b.log.Error("Error confirming statement line", "error", err.Error())
+		return nil, fmt.Errorf("error confirming statement line: %w", err)
+	}
+
+	return res, nil
+}
+
+// MatchLine matches a line to a cash flow entry chosen by hand.
+func (b *BankUseCase) MatchLine(in *entity.BankLineMatch) (*entity.BankStatementLine, error) {
+	if in.CashFlowID == "" {
+		return nil, fmt.Errorf("cash_flow_id is required")
+	}
+
+	res, err := b.repo.MatchLine(in)
+	if err != nil {
+		b.log.Error("Error matching statement line", "error", err.Error())
+		return nil, fmt.Errorf("error matching statement line: %w", err)
+	}
+
+	return res, nil
+}
+
+// UnmatchLine rejects the match of a line, suggested or confirmed.
+func (b *BankUseCase) UnmatchLine(in *entity.BankLineID) (*entity.BankStatementLine, error) {
+	res, err := b.repo.UnmatchLine(in)
+	if err != nil {
+		b.log.Error("Error unmatching statement line", "error", err.Error())
+		return nil, fmt.Errorf("error unmatching statement line: %w", err)
+	}
+
+	return res, nil
+}
+
+// CreateLineCashFlow records an unmatched line, such as a bank fee, as a new cash flow entry.
+func (b *BankUseCase) CreateLineCashFlow(in *entity.BankLineCashFlow) (*entity.BankStatementLine, error) {
+	if in.CategoryID == "" {
+		return nil, fmt.Errorf("category_id is required")
+	}
+
+	res, err := b.repo.CreateLineCashFlow(in)
+	if err != nil {
+		b.log.Error("Error creating cash flow from statement line", "error", err.Error())
+		return nil, fmt.Errorf("error creating cash flow from statement line: %w", err)
+	}
+
+	return res, nil
+}
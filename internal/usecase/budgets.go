@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+type BudgetsUseCase struct {
+	repo       BudgetsRepo
+	notifier   Notifier
+	channel    string
+	recipients []string
+	log        *slog.Logger
+}
+
+// NewBudgetsUseCase sends overspend alerts through the notifier of the given channel to a comma-separated
+// list of recipients. Without explicit recipients, SMS alerts go to the phone numbers of the owners.
+func NewBudgetsUseCase(repo BudgetsRepo, notifiers map[string]Notifier, channel, recipients string, log *slog.Logger) *BudgetsUseCase {
+	if channel == "" {
+		channel = "sms"
+	}
+
+	var list []string
+	for _, r := range strings.Split(recipients, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			list = append(list, r)
+		}
+	}
+
+	return &BudgetsUseCase{
+		repo:       repo,
+		notifier:   notifiers[channel],
+		channel:    channel,
+		recipients: list,
+		log:        log,
+	}
+}
+
+func validPeriod(period string) bool {
+	_, err := time.Parse("2006-01", period)
+	return err == nil
+}
+
+// CreateBudget sets the spending plan of a category for a month, replacing an existing one.
+func (b *BudgetsUseCase) CreateBudget(in *entity.BudgetRequest) (*entity.Budget, error) {
+	if !validPeriod(in.Period) {
+		return nil, fmt.Errorf("period must be a month in YYYY-MM format")
+	}
+	if in.Amount <= 0 {
+		return nil, fmt.Errorf("budget amount must be positive")
+	}
+
+	res, err := b.repo.CreateBudget(in)
+	if err != nil {
+		b.log.Error("Error creating budget", "error", err.Error())
+		return nil, fmt.Errorf("error creating budget: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateBudget changes the planned amount of a budget.
+func (b *BudgetsUseCase) UpdateBudget(in *entity.BudgetRequest) (*entity.Budget, error) {
+	if in.Amount <= 0 {
+		return nil, fmt.Errorf("budget amount must be positive")
+	}
+
+	res, err := b.repo.UpdateBudget(in)
+	if err != nil {
+		b.log.Error("Error updating budget", "error", err.Error())
+		return nil, fmt.Errorf("error updating budget: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteBudget removes a budget with its alerts.
+func (b *BudgetsUseCase) DeleteBudget(in *entity.BudgetID) (*entity.Message, error) {
+	res, err := b.repo.DeleteBudget(in)
+	if err != nil {
+		b.log.Error("Error deleting budget", "error", err.Error())
+		return nil, fmt.Errorf("error deleting budget: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetBudgets retrieves budgets filtered by month and category.
+func (b *BudgetsUseCase) GetBudgets(in *entity.BudgetFilter) (*entity.BudgetList, error) {
+	res, err := b.repo.GetBudgets(in)
+	if err != nil {
+		b.log.Error("Error fetching budgets", "error", err.Error())
+		return nil, fmt.Errorf("error fetching budgets: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetBudgetReport compares the budgets of a month, the current one by default, with actual expenses.
+func (b *BudgetsUseCase) GetBudgetReport(in *entity.BudgetFilter) (*entity.BudgetReport, error) {
+	if in.Period == "" {
+		in.Period = time.Now().Format("2006-01")
+	}
+	if !validPeriod(in.Period) {
+		return nil, fmt.Errorf("period must be a month in YYYY-MM format")
+	}
+
+	res, err := b.repo.GetBudgetReport(in)
+	if err != nil {
+		b.log.Error("Error building budget report", "error", err.Error())
+		return nil, fmt.Errorf("error building budget report: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetBudgetAlerts retrieves the log of overspend alerts.
+func (b *BudgetsUseCase) GetBudgetAlerts(in *entity.BudgetFilter) (*entity.BudgetAlertList, error) {
+	res, err := b.repo.GetBudgetAlerts(in)
+	if err != nil {
+		b.log.Error("Error fetching budget alerts", "error", err.Error())
+		return nil, fmt.Errorf("error fetching budget alerts: %w", err)
+	}
+
+	return res, nil
+}
+
+// CheckBudgets alerts about budgets of the current month that crossed 80% or 100%. It is run hourly.
+func (b *BudgetsUseCase) CheckBudgets() (*entity.BudgetCheckResult, error) {
+	crossings, err := b.repo.GetCrossedBudgets()
+	if err != nil {
+		b.log.Error("Error fetching crossed budgets", "error", err.Error())
+		return nil, fmt.Errorf("error fetching crossed budgets: %w", err)
+	}
+
+	res := &entity.BudgetCheckResult{}
+	if len(crossings) == 0 {
+		return res, nil
+	}
+
+	recipients := b.recipients
+	if len(recipients) == 0 && b.channel == "sms" {
+		recipients, err = b.repo.GetOwnerPhones()
+		if err != nil {
+			b.log.Error("Error fetching owner phones", "error", err.Error())
+			return nil, fmt.Errorf("error fetching owner phones: %w", err)
+		}
+	}
+	if len(recipients) == 0 {
+		b.log.Error("No recipients for budget alerts", "channel", b.channel)
+		return nil, fmt.Errorf("no recipients for %s budget alerts", b.channel)
+	}
+
+	// Every recipient is alerted once per threshold, failed sends are retried on the next run
+	for _, crossing := range crossings {
+		message := budgetAlertMessage(crossing)
+		for _, recipient := range recipients {
+			if slices.Contains(crossing.SentTo, recipient) {
+				continue
+			}
+
+			alert := &entity.BudgetAlert{
+				BudgetID:  crossing.BudgetID,
+				Threshold: crossing.Threshold,
+				Spent:     crossing.Actual,
+				Channel:   b.channel,
+				Recipient: recipient,
+				Status:    "sent",
+			}
+
+			if err := b.deliver(recipient, message); err != nil {
+				alert.Status = "failed"
+				alert.Error = err.Error()
+				res.Failed++
+			} else {
+				res.Sent++
+			}
+
+			if err := b.repo.LogBudgetAlert(alert); err != nil {
+				b.log.Error("Error logging budget alert", "error", err.Error(), "budget_id", crossing.BudgetID)
+			}
+		}
+	}
+
+	b.log.Info("Budget alerts processed", "sent", res.Sent, "failed", res.Failed)
+	return res, nil
+}
+
+func (b *BudgetsUseCase) deliver(recipient, message string) error {
+	if b.notifier == nil {
+		return fmt.Errorf("unknown alert channel %q", b.channel)
+	}
+
+	return b.notifier.Send(recipient, message)
+}
+
+func budgetAlertMessage(in entity.BudgetCrossing) string {
+	if in.Threshold >= 100 {
+		return fmt.Sprintf("Budget \"%s\" for %s is used up: spent %.2f of %.2f.",
+			in.CategoryName, in.Period, in.Actual, in.Budget)
+	}
+
+	return fmt.Sprintf("Budget \"%s\" for %s is %d%% used: spent %.2f of %.2f.",
+		in.CategoryName, in.Period, int(in.Utilization*100), in.Actual, in.Budget)
+}
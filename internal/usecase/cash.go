@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+)
+
+type CashUseCase struct {
+	repo CashRepo
+	log  *slog.Logger
+}
+
+func NewCashUseCase(repo CashRepo, log *slog.Logger) *CashUseCase {
+	return &CashUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func validTransactionType(t string) bool {
+	return t == "income" || t == "expense"
+}
+
+// CreateCategory adds a user category such as rent, salaries or utilities.
+func (c *CashUseCase) CreateCategory(in *entity.CashCategoryRequest) (*entity.CashCategory, error) {
+	if in.Name == "" {
+		return nil, fmt.Errorf("category name is required")
+	}
+	if in.TransactionType != "" && !validTransactionType(in.TransactionType) {
+		return nil, fmt.Errorf("unknown transaction type %q", in.TransactionType)
+	}
+
+	res, err := c.repo.CreateCategory(in)
+	if err != nil {
+		c.log.Error("Error creating cash category", "error", err.Error())
+		return nil, fmt.Errorf("error creating cash category: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateCategory renames a user category or changes its transaction type.
+func (c *CashUseCase) UpdateCategory(in *entity.CashCategoryRequest) (*entity.CashCategory, error) {
+	if in.TransactionType != "" && !validTransactionType(in.TransactionType) {
+		return nil, fmt.Errorf("unknown transaction type %q", in.TransactionType)
+	}
+
+	res, err := c.repo.UpdateCategory(in)
+	if err != nil {
+		c.log.Error("Error updating cash category", "error", err.Error())
+		return nil, fmt.Errorf("error updating cash category: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteCategory removes an unused user category.
+func (c *CashUseCase) DeleteCategory(in *entity.CashCategoryID) (*entity.Message, error) {
+	res, err := c.repo.DeleteCategory(in)
+	if err != nil {
+		c.log.Error("Error deleting cash category", "error", err.Error())
+		return nil, fmt.Errorf("error deleting cash category: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetCategories retrieves system and user cash categories.
+func (c *CashUseCase) GetCategories() (*entity.CashCategoryList, error) {
+	res, err := c.repo.GetCategories()
+	if err != nil {
+		c.log.Error("Error fetching cash categories", "error", err.Error())
+		return nil, fmt.Errorf("error fetching cash categories: %w", err)
+	}
+
+	return res, nil
+}
+
+// CreateCashFlow records a manual income or expense entry.
+func (c *CashUseCase) CreateCashFlow(in *entity.CashFlowRequest) (*entity.CashFlow, error) {
+	if in.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if !validTransactionType(in.TransactionType) {
+		return nil, fmt.Errorf("unknown transaction type %q", in.TransactionType)
+	}
+	if in.PaymentMethod == "" {
+		in.PaymentMethod = "uzs"
+	}
+
+	res, err := c.repo.CreateCashFlow(in)
+	if err != nil {
+		c.log.Error("Error creating cash flow entry", "error", err.Error())
+		return nil, fmt.Errorf("error creating cash flow entry: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateCashFlow modifies a manual entry, automatic postings are read-only.
+func (c *CashUseCase) UpdateCashFlow(in *entity.CashFlowRequest) (*entity.CashFlow, error) {
+	if in.Amount < 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if in.TransactionType != "" && !validTransactionType(in.TransactionType) {
+		return nil, fmt.Errorf("unknown transaction type %q", in.TransactionType)
+	}
+
+	res, err := c.repo.UpdateCashFlow(in)
+	if err != nil {
+		c.log.Error("Error updating cash flow entry", "error", err.Error())
+		return nil, fmt.Errorf("error updating cash flow entry: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteCashFlow removes a manual entry.
+func (c *CashUseCase) DeleteCashFlow(in *entity.CashFlowID) (*entity.Message, error) {
+	res, err := c.repo.DeleteCashFlow(in)
+	if err != nil {
+		c.log.Error("Error deleting cash flow entry", "error", err.Error())
+		return nil, fmt.Errorf("error deleting cash flow entry: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetCashFlow retrieves a cash flow entry by ID.
+func (c *CashUseCase) GetCashFlow(in *entity.CashFlowID) (*entity.CashFlow, error) {
+	res, err := c.repo.GetCashFlow(in)
+	if err != nil {
+		c.log.Error("Error fetching cash flow entry", "error", err.Error())
+		return nil, fmt.Errorf("error fetching cash flow entry: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetCashFlowList retrieves cash flow entries for a period with income and expense totals.
+func (c *CashUseCase) GetCashFlowList(in *entity.CashFlowFilter) (*entity.CashFlowList, error) {
+	res, err := c.repo.GetCashFlowList(in)
+	if err != nil {
+		c.log.Error("Error fetching cash flow list", "error", err.Error())
+		return nil, fmt.Errorf("error fetching cash flow list: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetCashBalance calculates the cash balance, per payment method, for the filtered entries.
+func (c *CashUseCase) GetCashBalance(in *entity.CashFlowFilter) (*entity.CashBalance, error) {
+	res, err := c.repo.GetCashBalance(in)
+	if err != nil {
+		c.log.Error("Error calculating cash balance", "error", err.Error())
+		return nil, fmt.Errorf("error calculating cash balance: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+)
+
+type ClientsUseCase struct {
+	repo ClientsRepo
+	log  *slog.Logger
+}
+
+func NewClientsUseCase(repo ClientsRepo, log *slog.Logger) *ClientsUseCase {
+	return &ClientsUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetClient retrieves a client by ID.
+func (c *ClientsUseCase) GetClient(in *entity.ClientID) (*entity.Client, error) {
+	res, err := c.repo.GetClient(in)
+	if err != nil {
+		c.log.Error("Error fetching client", "error", err.Error())
+		return nil, fmt.Errorf("error fetching client: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateClientContact changes the phone, Telegram chat and reminder language of a client.
+func (c *ClientsUseCase) UpdateClientContact(in *entity.ClientContact) (*entity.Client, error) {
+	if in.Language != "" && in.Language != "uz" && in.Language != "ru" {
+		return nil, fmt.Errorf("unsupported language %q, expected uz or ru", in.Language)
+	}
+
+	res, err := c.repo.UpdateClientContact(in)
+	if err != nil {
+		c.log.Error("Error updating client contact", "error", err.Error())
+		return nil, fmt.Errorf("error updating client contact: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateCreditLimit sets or removes the credit limit of a client.
+func (c *ClientsUseCase) UpdateCreditLimit(in *entity.CreditLimitUpdate) (*entity.Client, error) {
+	if in.CreditLimit != nil && *in.CreditLimit < 0 {
+		return nil, fmt.Errorf("credit limit cannot be negative")
+	}
+
+	res, err := c.repo.UpdateCreditLimit(in)
+	if err != nil {
+		c.log.Error("Error updating credit limit", "error", err.Error())
+		return nil, fmt.Errorf("error updating credit limit: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdatePaymentTerms sets the supplier's payment terms used to date new purchases.
+func (c *ClientsUseCase) UpdatePaymentTerms(in *entity.PaymentTerms) (*entity.Client, error) {
+	if in.PaymentTermsDays < 0 {
+		return nil, fmt.Errorf("payment terms cannot be negative")
+	}
+
+	res, err := c.repo.UpdatePaymentTerms(in)
+	if err != nil {
+		c.log.Error("Error updating payment terms", "error", err.Error())
+		return nil, fmt.Errorf("error updating payment terms: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetCreditOverrides retrieves the credit sales an owner allowed above the client's limit.
+func (c *ClientsUseCase) GetCreditOverrides(in *entity.ClientID) (*entity.CreditOverrideList, error) {
+	res, err := c.repo.GetCreditOverrides(in)
+	if err != nil {
+		c.log.Error("Error fetching credit overrides", "error", err.Error())
+		return nil, fmt.Errorf("error fetching credit overrides: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetCreditReport lists clients whose outstanding debt reached the threshold share of their limit.
+func (c *ClientsUseCase) GetCreditReport(in *entity.CreditReportFilter) (*entity.CreditReport, error) {
+	if in.Threshold <= 0 {
+		in.Threshold = 0.8
+	}
+
+	res, err := c.repo.GetCreditReport(in)
+	if err != nil {
+		c.log.Error("Error building credit report", "error", err.Error())
+		return nil, fmt.Errorf("error building credit report: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+)
+
+type DebtsUseCase struct {
+	repo DebtsRepo
+	log  *slog.Logger
+}
+
+func NewDebtsUseCase(repo DebtsRepo, log *slog.Logger) *DebtsUseCase {
+	return &DebtsUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetDebt retrieves a specific debt based on the ID.
+func (d *DebtsUseCase) GetDebt(in *entity.DebtID) (*entity.Debt, error) {
+	res, err := d.repo.GetDebt(in)
+	if err != nil {
+		d.log.Error("Error fetching debt", "error", err.Error())
+		return nil, fmt.Errorf("error fetching debt: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetListDebts retrieves debts filtered by client, recipient or payment state.
+func (d *DebtsUseCase) GetListDebts(in *entity.DebtFilter) (*entity.DebtList, error) {
+	res, err := d.repo.GetDebtList(in)
+	if err != nil {
+		d.log.Error("Error fetching debt list", "error", err.Error())
+		return nil, fmt.Errorf("error fetching debt list: %w", err)
+	}
+
+	return res, nil
+}
+
+// PayDebt records an installment payment against a debt.
+func (d *DebtsUseCase) PayDebt(in *entity.DebtPaymentRequest) (*entity.Debt, error) {
+	if in.Amount <= 0 {
+		return nil, fmt.Errorf("payment amount must be positive")
+	}
+	if in.PaymentMethod == "" {
+		in.PaymentMethod = "uzs"
+	}
+
+	res, err := d.repo.PayDebt(in)
+	if err != nil {
+		d.log.Error("Error paying debt", "error", err.Error())
+		return nil, fmt.Errorf("error paying debt: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetDebtPayments retrieves all payments made against a debt.
+func (d *DebtsUseCase) GetDebtPayments(in *entity.DebtID) (*entity.DebtPaymentList, error) {
+	res, err := d.repo.GetDebtPayments(in)
+	if err != nil {
+		d.log.Error("Error fetching debt payments", "error", err.Error())
+		return nil, fmt.Errorf("error fetching debt payments: %w", err)
+	}
+
+	return res, nil
+}
+
+// CloseDebt settles the remaining balance of a debt and marks it fully paid.
+func (d *DebtsUseCase) CloseDebt(in *entity.DebtClose) (*entity.Debt, error) {
+	if in.PaymentMethod == "" {
+		in.PaymentMethod = "uzs"
+	}
+
+	res, err := d.repo.CloseDebt(in)
+	if err != nil {
+		d.log.Error("Error closing debt", "error", err.Error())
+		return nil, fmt.Errorf("error closing debt: %w", err)
+	}
+
+	return res, nil
+}
+
+// SetDebtSchedule splits the unpaid amount of a debt into monthly installments.
+func (d *DebtsUseCase) SetDebtSchedule(in *entity.DebtScheduleRequest) (*entity.DebtSchedule, error) {
+	debt, err := d.repo.GetDebt(&entity.DebtID{ID: in.DebtID})
+	if err != nil {
+		d.log.Error("Error fetching debt for schedule", "error", err.Error())
+		return nil, fmt.Errorf("error fetching debt for schedule: %w", err)
+	}
+
+	installments, err := buildInstallments(debt.AmountUnpaid, in)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := d.repo.SetDebtSchedule(&entity.DebtSchedule{DebtID: in.DebtID, Installments: installments})
+	if err != nil {
+		d.log.Error("Error saving debt schedule", "error", err.Error())
+		return nil, fmt.Errorf("error saving debt schedule: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetDebtSchedule retrieves the installment plan of a debt.
+func (d *DebtsUseCase) GetDebtSchedule(in *entity.DebtID) (*entity.DebtSchedule, error) {
+	res, err := d.repo.GetDebtSchedule(in)
+	if err != nil {
+		d.log.Error("Error fetching debt schedule", "error", err.Error())
+		return nil, fmt.Errorf("error fetching debt schedule: %w", err)
+	}
+
+	return res, nil
+}
+
+// FlagOverdueDebts marks debts whose next payment date has passed. It is run daily.
+func (d *DebtsUseCase) FlagOverdueDebts() error {
+	rows, err := d.repo.FlagOverdueDebts()
+	if err != nil {
+		d.log.Error("Error flagging overdue debts", "error", err.Error())
+		return fmt.Errorf("error flagging overdue debts: %w", err)
+	}
+
+	d.log.Info("Overdue debts flagged", "updated", rows)
+	return nil
+}
+
+// GetDebtAging groups unpaid debt by days overdue, per client or per seller.
+func (d *DebtsUseCase) GetDebtAging(in *entity.AgingFilter) (*entity.AgingReport, error) {
+	res, err := d.repo.GetDebtAging(in)
+	if err != nil {
+		d.log.Error("Error building debt aging report", "error", err.Error())
+		return nil, fmt.Errorf("error building debt aging report: %w", err)
+	}
+
+	return res, nil
+}
+
+// buildInstallments splits total into monthly installments starting at the first due date.
+// Without explicit amounts the total is split evenly and the last installment takes the rounding.
+func buildInstallments(total float64, in *entity.DebtScheduleRequest) ([]entity.DebtInstallment, error) {
+	count := in.Installments
+	if len(in.Amounts) > 0 {
+		if count == 0 {
+			count = len(in.Amounts)
+		}
+		if count != len(in.Amounts) {
+			return nil, fmt.Errorf("expected %d installment amounts, got %d", count, len(in.Amounts))
+		}
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("number of installments must be positive")
+	}
+
+	firstDue, err := time.Parse("2006-01-02", in.FirstDueDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid first due date %q: %w", in.FirstDueDate, err)
+	}
+
+	totalCents := int64(math.Round(total * 100))
+	cents := make([]int64, count)
+	if len(in.Amounts) > 0 {
+		var sum int64
+		for i, amount := range in.Amounts {
+			if amount <= 0 {
+				return nil, fmt.Errorf("installment amounts must be positive")
+			}
+			cents[i] = int64(math.Round(amount * 100))
+			sum += cents[i]
+		}
+		if sum != totalCents {
+			return nil, fmt.Errorf("installment amounts must add up to %.2f", total)
+		}
+	} else {
+		share := totalCents / int64(count)
+		for i := range cents {
+			cents[i] = share
+		}
+		cents[count-1] = totalCents - share*int64(count-1)
+	}
+
+	installments := make([]entity.DebtInstallment, count)
+	for i := range installments {
+		installments[i] = entity.DebtInstallment{
+			InstallmentNo: i + 1,
+			DueDate:       addMonths(firstDue, i).Format("2006-01-02"),
+			Amount:        float64(cents[i]) / 100,
+		}
+	}
+
+	return installments, nil
+}
+
+// addMonths shifts t by n months, clamping the day to the end of shorter months.
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"testing"
+	"time"
+)
+
+func TestBuildInstallments(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   float64
+		in      entity.DebtScheduleRequest
+		amounts []float64
+		dates   []string
+		wantErr bool
+	}{
+		{
+			name:    "rounding goes to the last installment",
+			total:   100,
+			in:      entity.DebtScheduleRequest{Installments: 3, FirstDueDate: "2024-01-31"},
+			amounts: []float64{33.33, 33.33, 33.34},
+			dates:   []string{"2024-01-31", "2024-02-29", "2024-03-31"},
+		},
+		{
+			name:    "even split",
+			total:   1000,
+			in:      entity.DebtScheduleRequest{Installments: 4, FirstDueDate: "2024-11-15"},
+			amounts: []float64{250, 250, 250, 250},
+			dates:   []string{"2024-11-15", "2024-12-15", "2025-01-15", "2025-02-15"},
+		},
+		{
+			name:    "a few cents over many installments",
+			total:   0.05,
+			in:      entity.DebtScheduleRequest{Installments: 3, FirstDueDate: "2024-03-01"},
+			amounts: []float64{0.01, 0.01, 0.03},
+			dates:   []string{"2024-03-01", "2024-04-01", "2024-05-01"},
+		},
+		{
+			name:    "single installment",
+			total:   99.99,
+			in:      entity.DebtScheduleRequest{Installments: 1, FirstDueDate: "2024-03-01"},
+			amounts: []float64{99.99},
+			dates:   []string{"2024-03-01"},
+		},
+		{
+			name:    "custom amounts set the count",
+			total:   10.01,
+			in:      entity.DebtScheduleRequest{Amounts: []float64{5, 5.01}, FirstDueDate: "2023-01-31"},
+			amounts: []float64{5, 5.01},
+			dates:   []string{"2023-01-31", "2023-02-28"},
+		},
+		{
+			name:    "custom amounts compared in cents",
+			total:   0.3,
+			in:      entity.DebtScheduleRequest{Amounts: []float64{0.1, 0.2}, FirstDueDate: "2024-03-01"},
+			amounts: []float64{0.1, 0.2},
+			dates:   []string{"2024-03-01", "2024-04-01"},
+		},
+		{
+			name:    "custom amounts must add up",
+			total:   100,
+			in:      entity.DebtScheduleRequest{Amounts: []float64{50, 49.99}, FirstDueDate: "2024-03-01"},
+			wantErr: true,
+		},
+		{
+			name:    "custom amounts must match the count",
+			total:   100,
+			in:      entity.DebtScheduleRequest{Installments: 3, Amounts: []float64{50, 50}, FirstDueDate: "2024-03-01"},
+			wantErr: true,
+		},
+		{
+			name:    "custom amounts must be positive",
+			total:   100,
+			in:      entity.DebtScheduleRequest{Amounts: []float64{110, -10}, FirstDueDate: "2024-03-01"},
+			wantErr: true,
+		},
+		{
+			name:    "count is required",
+			total:   100,
+			in:      entity.DebtScheduleRequest{FirstDueDate: "2024-03-01"},
+			wantErr: true,
+		},
+		{
+			name:    "first due date is required",
+			total:   100,
+			in:      entity.DebtScheduleRequest{Installments: 2, FirstDueDate: "01.03.2024"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installments, err := buildInstallments(tt.total, &tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("buildInstallments() = %+v, want error", installments)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildInstallments() error: %v", err)
+			}
+			if len(installments) != len(tt.amounts) {
+				t.Fatalf("buildInstallments() returned %d installments, want %d", len(installments), len(tt.amounts))
+			}
+			for i, installment := range installments {
+				if installment.InstallmentNo != i+1 {
+					t.Errorf("installment %d number = %d", i, installment.InstallmentNo)
+				}
+				if installment.Amount != tt.amounts[i] {
+					t.Errorf("installment %d amount = %v, want %v", i, installment.Amount, tt.amounts[i])
+				}
+				if installment.DueDate != tt.dates[i] {
+					t.Errorf("installment %d due date = %s, want %s", i, installment.DueDate, tt.dates[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		date string
+		n    int
+		want string
+	}{
+		{"2024-01-15", 0, "2024-01-15"},
+		{"2024-01-31", 1, "2024-02-29"},
+		{"2023-01-31", 1, "2023-02-28"},
+		{"2024-01-31", 2, "2024-03-31"},
+		{"2024-03-31", 1, "2024-04-30"},
+		{"2024-02-29", 12, "2025-02-28"},
+		{"2024-12-15", 1, "2025-01-15"},
+		{"2024-08-31", 6, "2025-02-28"},
+		{"2024-01-31", 13, "2025-02-28"},
+	}
+
+	for _, tt := range tests {
+		date, _ := time.Parse("2006-01-02", tt.date)
+		if got := addMonths(date, tt.n).Format("2006-01-02"); got != tt.want {
+			t.Errorf("addMonths(%s, %d) = %s, want %s", tt.date, tt.n, got, tt.want)
+		}
+	}
+}
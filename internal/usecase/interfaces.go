@@ -1,6 +1,9 @@
 package usecase
 
-import "crm-admin/internal/entity"
+import (
+	"crm-admin/internal/entity"
+	"time"
+)
 
 type UsersRepo interface {
 	AddAdmin(in entity.AdminPass) (entity.Message, error)
@@ -23,6 +26,14 @@ type ProductsRepo interface {
 	DeleteProduct(in *entity.ProductID) (*entity.Message, error)
 	GetProduct(in *entity.ProductID) (*entity.Product, error)
 	GetProductList(in *entity.FilterProduct) (*entity.ProductList, error)
+	GenerateVariants(in *entity.VariantGenerateRequest) (*entity.Product, error)
+	CreateVariant(in *entity.VariantRequest) (*entity.Product, error)
+	SetVariantPrice(in *entity.VariantPrice) (*entity.Product, error)
+
+	GetCostingMethod() (*entity.CostingMethod, error)
+	SetCostingMethod(in *entity.CostingMethod) (*entity.CostingMethod, error)
+	GetCostLayers(in *entity.ProductID) (*entity.CostLayerList, error)
+	GetInventoryHistory(in *entity.InventoryMovementFilter) (*entity.InventoryHistory, error)
 }
 
 type ProductQuantity interface {
@@ -38,6 +49,102 @@ type PurchasesRepo interface {
 	GetPurchase(in *entity.PurchaseID) (*entity.PurchaseResponse, error)
 	GetPurchaseList(in *entity.FilterPurchase) (*entity.PurchaseList, error)
 	DeletePurchase(in *entity.PurchaseID) (*entity.Message, error)
+	CreateSupplierReturn(in *entity.SupplierReturnRequest) (*entity.SupplierReturn, error)
+	GetSupplierReturn(in *entity.SupplierReturnID) (*entity.SupplierReturn, error)
+	GetSupplierReturnList(in *entity.SupplierReturnFilter) (*entity.SupplierReturnList, error)
+	GetReturnablePurchaseItems(in *entity.PurchaseID) (*entity.ReturnablePurchaseItemList, error)
+}
+
+type PayablesRepo interface {
+	PaySupplier(in *entity.SupplierPaymentRequest) (*entity.SupplierPayment, error)
+	GetSupplierPayments(in *entity.SupplierPaymentFilter) (*entity.SupplierPaymentList, error)
+	GetPayables(in *entity.PayableFilter) (*entity.PayableList, error)
+	GetSupplierBalances() (*entity.SupplierBalanceList, error)
+	GetPayablesAging() (*entity.AgingReport, error)
+}
+
+type CashRepo interface {
+	CreateCategory(in *entity.CashCategoryRequest) (*entity.CashCategory, error)
+	UpdateCategory(in *entity.CashCategoryRequest) (*entity.CashCategory, error)
+	DeleteCategory(in *entity.CashCategoryID) (*entity.Message, error)
+	GetCategories() (*entity.CashCategoryList, error)
+	CreateCashFlow(in *entity.CashFlowRequest) (*entity.CashFlow, error)
+	UpdateCashFlow(in *entity.CashFlowRequest) (*entity.CashFlow, error)
+	DeleteCashFlow(in *entity.CashFlowID) (*entity.Message, error)
+	GetCashFlow(in *entity.CashFlowID) (*entity.CashFlow, error)
+	GetCashFlowList(in *entity.CashFlowFilter) (*entity.CashFlowList, error)
+	GetCashBalance(in *entity.CashFlowFilter) (*entity.CashBalance, error)
+}
+
+type WalletsRepo interface {
+	CreateWallet(in *entity.WalletRequest) (*entity.Wallet, error)
+	UpdateWallet(in *entity.WalletRequest) (*entity.Wallet, error)
+	DeleteWallet(in *entity.WalletID) (*entity.Message, error)
+	GetWallet(in *entity.WalletID) (*entity.Wallet, error)
+	GetWallets() (*entity.WalletList, error)
+	Transfer(in *entity.WalletTransferRequest) (*entity.WalletTransfer, error)
+	GetTransfers(in *entity.WalletTransferFilter) (*entity.WalletTransferList, error)
+	GetBalancesAt(in *entity.WalletBalanceFilter) (*entity.WalletBalanceList, error)
+}
+
+type ShiftsRepo interface {
+	CreateRegister(in *entity.RegisterRequest) (*entity.Register, error)
+	GetRegisters() (*entity.RegisterList, error)
+	CreateBranch(in *entity.BranchRequest) (*entity.Branch, error)
+	GetBranches() (*entity.BranchList, error)
+	OpenShift(in *entity.ShiftOpen) (*entity.Shift, error)
+	CloseShift(in *entity.ShiftClose) (*entity.ShiftReport, error)
+	GetShift(in *entity.ShiftID) (*entity.Shift, error)
+	GetShifts(in *entity.ShiftFilter) (*entity.ShiftList, error)
+	GetShiftReport(in *entity.ShiftID) (*entity.ShiftReport, error)
+}
+
+type RecurringRepo interface {
+	CreateRecurringExpense(in *entity.RecurringExpenseRequest) (*entity.RecurringExpense, error)
+	UpdateRecurringExpense(in *entity.RecurringExpenseRequest) (*entity.RecurringExpense, error)
+	DeleteRecurringExpense(in *entity.RecurringExpenseID) (*entity.Message, error)
+	GetRecurringExpense(in *entity.RecurringExpenseID) (*entity.RecurringExpense, error)
+	GetRecurringExpenses() (*entity.RecurringExpenseList, error)
+	PostRecurringExpense(id string, scheduledAt time.Time) (bool, error)
+}
+
+type BudgetsRepo interface {
+	CreateBudget(in *entity.BudgetRequest) (*entity.Budget, error)
+	UpdateBudget(in *entity.BudgetRequest) (*entity.Budget, error)
+	DeleteBudget(in *entity.BudgetID) (*entity.Message, error)
+	GetBudgets(in *entity.BudgetFilter) (*entity.BudgetList, error)
+	GetBudgetReport(in *entity.BudgetFilter) (*entity.BudgetReport, error)
+	GetCrossedBudgets() ([]entity.BudgetCrossing, error)
+	GetOwnerPhones() ([]string, error)
+	LogBudgetAlert(in *entity.BudgetAlert) error
+	GetBudgetAlerts(in *entity.BudgetFilter) (*entity.BudgetAlertList, error)
+}
+
+type BankRepo interface {
+	ImportStatement(in *entity.BankStatementRequest, lines []entity.BankLineRequest) (*entity.BankStatement, error)
+	AutoMatch(in *entity.BankStatementID) (*entity.BankStatement, error)
+	GetStatement(in *entity.BankStatementID) (*entity.BankStatement, error)
+	GetStatements() (*entity.BankStatementList, error)
+	GetStatementLines(in *entity.BankLineFilter) (*entity.BankLineList, error)
+	GetLineCandidates(in *entity.BankLineID) (*entity.CashFlowList, error)
+	ConfirmLine(in *entity.BankLineMatch) (*entity.BankStatementLine, error)
+	MatchLine(in *entity.BankLineMatch) (*entity.BankStatementLine, error)
+	UnmatchLine(in *entity.BankLineID) (*entity.BankStatementLine, error)
+	CreateLineCashFlow(in *entity.BankLineCashFlow) (*entity.BankStatementLine, error)
+}
+
+type ReportsRepo interface {
+	GetPnLSales(from, to time.Time, groupBy string) ([]entity.PnLSalesRow, error)
+	GetPnLReturns(from, to time.Time, groupBy string) ([]entity.PnLReturnRow, error)
+	GetPnLExpenses(from, to time.Time, groupBy string) ([]entity.PnLExpenseRow, error)
+	GetBaseCurrency() (string, error)
+}
+
+type RatesRepo interface {
+	SaveRates(in []entity.ExchangeRateRequest, source string) ([]entity.ExchangeRate, error)
+	GetRates(in *entity.ExchangeRateFilter) (*entity.ExchangeRateList, error)
+	GetBaseCurrency() (*entity.BaseCurrency, error)
+	SetBaseCurrency(in *entity.BaseCurrency) (*entity.BaseCurrency, error)
 }
 
 type SalesRepo interface {
@@ -46,12 +153,101 @@ type SalesRepo interface {
 	GetSale(in *entity.SaleID) (*entity.SaleResponse, error)
 	GetSaleList(filter *entity.SaleFilter) (*entity.SaleList, error)
 	DeleteSale(in *entity.SaleID) (*entity.Message, error)
+	GetDailyReport(in *entity.DailySalesFilter) (*entity.DailySalesReport, error)
+}
+
+type DebtsRepo interface {
+	GetDebt(in *entity.DebtID) (*entity.Debt, error)
+	GetDebtList(in *entity.DebtFilter) (*entity.DebtList, error)
+	PayDebt(in *entity.DebtPaymentRequest) (*entity.Debt, error)
+	GetDebtPayments(in *entity.DebtID) (*entity.DebtPaymentList, error)
+	CloseDebt(in *entity.DebtClose) (*entity.Debt, error)
+	SetDebtSchedule(in *entity.DebtSchedule) (*entity.DebtSchedule, error)
+	GetDebtSchedule(in *entity.DebtID) (*entity.DebtSchedule, error)
+	FlagOverdueDebts() (int64, error)
+	GetDebtAging(in *entity.AgingFilter) (*entity.AgingReport, error)
+}
+
+type ClientsRepo interface {
+	GetClient(in *entity.ClientID) (*entity.Client, error)
+	UpdateClientContact(in *entity.ClientContact) (*entity.Client, error)
+	UpdateCreditLimit(in *entity.CreditLimitUpdate) (*entity.Client, error)
+	GetCreditOverrides(in *entity.ClientID) (*entity.CreditOverrideList, error)
+	GetCreditReport(in *entity.CreditReportFilter) (*entity.CreditReport, error)
+	UpdatePaymentTerms(in *entity.PaymentTerms) (*entity.Client, error)
+}
+
+type RemindersRepo interface {
+	CreateReminderRule(in *entity.ReminderRule) (*entity.ReminderRule, error)
+	UpdateReminderRule(in *entity.ReminderRule) (*entity.ReminderRule, error)
+	GetReminderRuleList() (*entity.ReminderRuleList, error)
+	DeleteReminderRule(in *entity.ReminderRuleID) (*entity.Message, error)
+	GetDueReminders() ([]entity.DueReminder, error)
+	LogReminder(in *entity.ReminderLog) error
+	GetReminderLogs(in *entity.ReminderLogFilter) (*entity.ReminderLogList, error)
+}
+
+// Notifier delivers a text message to a recipient: a phone number for SMS or a chat ID for Telegram.
+type Notifier interface {
+	Send(recipient, message string) error
+}
+
+// RateProvider fetches the exchange rates of a day as units of the base currency per unit of each currency.
+type RateProvider interface {
+	Rates(base string, date time.Time) (map[string]float64, error)
 }
 
 type ReturnedProductsRepo interface {
-	CreateReturnedProducts() error
-	UpdateReturnedProducts() error
-	GetReturnedProducts() error
-	GetReturnedProductsList() error
-	DeleteReturnedProducts() error
+	CreateReturn(in *entity.SaleReturnRequest) (*entity.SaleReturn, error)
+	GetReturn(in *entity.SaleReturnID) (*entity.SaleReturn, error)
+	GetReturnList(in *entity.SaleReturnFilter) (*entity.SaleReturnList, error)
+	GetReturnableItems(in *entity.SaleID) (*entity.ReturnableItemList, error)
+}
+
+type StocktakesRepo interface {
+	CreateStocktake(in *entity.StocktakeRequest) (*entity.Stocktake, error)
+	GetStocktake(in *entity.StocktakeID) (*entity.Stocktake, error)
+	GetStocktakes(in *entity.StocktakeFilter) (*entity.StocktakeList, error)
+	AddCounts(in *entity.StocktakeCountRequest) (*entity.Stocktake, error)
+	GetVariance(in *entity.StocktakeLineFilter) (*entity.StocktakeVariance, error)
+	PostStocktake(in *entity.StocktakePost) (*entity.StocktakeVariance, error)
+	CancelStocktake(in *entity.StocktakeID) (*entity.Stocktake, error)
+}
+
+type WriteOffsRepo interface {
+	CreateWriteOff(in *entity.WriteOffRequest) (*entity.WriteOff, error)
+	ApproveWriteOff(in *entity.WriteOffDecision) (*entity.WriteOff, error)
+	RejectWriteOff(in *entity.WriteOffDecision) (*entity.WriteOff, error)
+	GetWriteOff(in *entity.WriteOffID) (*entity.WriteOff, error)
+	GetWriteOffList(in *entity.WriteOffFilter) (*entity.WriteOffList, error)
+	GetThreshold() (*entity.WriteOffThreshold, error)
+	SetThreshold(in *entity.WriteOffThreshold) (*entity.WriteOffThreshold, error)
+}
+
+type PurchaseOrdersRepo interface {
+	CreatePurchaseOrder(in *entity.PurchaseOrderRequest) (*entity.PurchaseOrder, error)
+	UpdatePurchaseOrder(in *entity.PurchaseOrderRequest) (*entity.PurchaseOrder, error)
+	GetPurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error)
+	GetPurchaseOrders(in *entity.PurchaseOrderFilter) (*entity.PurchaseOrderList, error)
+	SendPurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error)
+	ReceivePurchaseOrder(in *entity.PurchaseOrderReceiptRequest) (*entity.PurchaseOrderReceipt, error)
+	ClosePurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error)
+	CancelPurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error)
+}
+
+type LotsRepo interface {
+	GetLots(in *entity.LotFilter) (*entity.LotList, error)
+	GetExpiringLots(in *entity.ExpiringLotsFilter) (*entity.ExpiringLots, error)
+	GetLotSettings() (*entity.LotSettings, error)
+	SetLotSettings(in *entity.LotSettings) (*entity.LotSettings, error)
+}
+
+type ReorderRepo interface {
+	SetReorderPoint(in *entity.ReorderPoint) (*entity.ReorderPoint, error)
+	GetReorderPoint(in *entity.ProductID) (*entity.ReorderPoint, error)
+	GetLowStock(in *entity.LowStockFilter) (*entity.LowStockList, error)
+	GetReorderCandidates(days int, supplierID string) ([]entity.ReorderCandidate, error)
+	GetStorekeeperPhones() ([]string, error)
+	LogStockAlert(in *entity.StockAlert) error
+	GetStockAlerts() (*entity.StockAlertList, error)
 }
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type LotsUseCase struct {
+	repo LotsRepo
+	log  *slog.Logger
+}
+
+func NewLotsUseCase(repo LotsRepo, log *slog.Logger) *LotsUseCase {
+	return &LotsUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// checkExpiryDate checks that an expiry date, when given, is a YYYY-MM-DD date.
+func checkExpiryDate(date string) error {
+	if date == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return fmt.Errorf("expiry date %q must be in YYYY-MM-DD format", date)
+	}
+
+	return nil
+}
+
+// GetLots lists the lots still in stock, or every lot when asked to.
+func (l *LotsUseCase) GetLots(in *entity.LotFilter) (*entity.LotList, error) {
+	res, err := l.repo.GetLots(in)
+	if err != nil {
+		l.log.Error("Error fetching lots", "error", err.Error())
+		return nil, fmt.Errorf("error fetching lots: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetExpiringLots reports the lots in stock expiring within the given days, the expired ones included.
+func (l *LotsUseCase) GetExpiringLots(in *entity.ExpiringLotsFilter) (*entity.ExpiringLots, error) {
+	if in.Days == 0 {
+		in.Days = 30
+	}
+	if in.Days < 0 {
+		return nil, fmt.Errorf("days must be positive")
+	}
+
+	res, err := l.repo.GetExpiringLots(in)
+	if err != nil {
+		l.log.Error("Error fetching expiring lots", "error", err.Error())
+		return nil, fmt.Errorf("error fetching expiring lots: %w", err)
+	}
+
+	return res, nil
+}
+
+func (l *LotsUseCase) GetLotSettings() (*entity.LotSettings, error) {
+	res, err := l.repo.GetLotSettings()
+	if err != nil {
+		l.log.Error("Error fetching lot settings", "error", err.Error())
+		return nil, fmt.Errorf("error fetching lot settings: %w", err)
+	}
+
+	return res, nil
+}
+
+// SetLotSettings turns the blocking of sales from expired lots on or off.
+func (l *LotsUseCase) SetLotSettings(in *entity.LotSettings) (*entity.LotSettings, error) {
+	res, err := l.repo.SetLotSettings(in)
+	if err != nil {
+		l.log.Error("Error saving lot settings", "error", err.Error())
+		return nil, fmt.Errorf("error saving lot settings: %w", err)
+	}
+
+	return res, nil
+}
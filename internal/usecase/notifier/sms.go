@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"bytes"
+	"crm-admin/config"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SMS sends messages through an HTTP SMS gateway.
+type SMS struct {
+	url    string
+	token  string
+	sender string
+	client *http.Client
+}
+
+func NewSMS(cfg config.Config) *SMS {
+	return &SMS{
+		url:    strings.TrimRight(cfg.SMS_GATEWAY_URL, "/"),
+		token:  cfg.SMS_GATEWAY_TOKEN,
+		sender: cfg.SMS_SENDER,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type smsRequest struct {
+	To   string `json:"to"`
+	From string `json:"from"`
+	Text string `json:"text"`
+}
+
+func (s *SMS) Send(recipient, message string) error {
+	body, err := json.Marshal(smsRequest{To: recipient, From: s.sender, Text: message})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url+"/send", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms gateway request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sms gateway returned %d: %s", resp.StatusCode, msg)
+	}
+
+	return nil
+}
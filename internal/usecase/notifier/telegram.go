@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"bytes"
+	"crm-admin/config"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Telegram sends messages through the Telegram Bot API.
+type Telegram struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewTelegram(cfg config.Config) *Telegram {
+	return &Telegram{
+		url:    strings.TrimRight(cfg.TELEGRAM_API_URL, "/"),
+		token:  cfg.TELEGRAM_BOT_TOKEN,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type telegramRequest struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+type telegramResponse struct {
+	Ok          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+func (t *Telegram) Send(recipient, message string) error {
+	body, err := json.Marshal(telegramRequest{ChatID: recipient, Text: message})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", t.url, t.token)
+	resp, err := t.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("telegram request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var res telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("telegram returned %d: %w", resp.StatusCode, err)
+	}
+	if !res.Ok {
+		return fmt.Errorf("telegram returned %d: %s", resp.StatusCode, res.Description)
+	}
+
+	return nil
+}
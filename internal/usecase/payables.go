@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+)
+
+type PayablesUseCase struct {
+	repo PayablesRepo
+	log  *slog.Logger
+}
+
+func NewPayablesUseCase(repo PayablesRepo, log *slog.Logger) *PayablesUseCase {
+	return &PayablesUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// PaySupplier records a payment against the outstanding balance of a purchase.
+func (p *PayablesUseCase) PaySupplier(in *entity.SupplierPaymentRequest) (*entity.SupplierPayment, error) {
+	if in.Amount <= 0 {
+		return nil, fmt.Errorf("payment amount must be positive")
+	}
+	if in.PaymentMethod == "" {
+		in.PaymentMethod = "uzs"
+	}
+
+	res, err := p.repo.PaySupplier(in)
+	if err != nil {
+		p.log.Error("Error paying supplier", "error", err.Error())
+		return nil, fmt.Errorf("error paying supplier: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetSupplierPayments retrieves payments made to suppliers.
+func (p *PayablesUseCase) GetSupplierPayments(in *entity.SupplierPaymentFilter) (*entity.SupplierPaymentList, error) {
+	res, err := p.repo.GetSupplierPayments(in)
+	if err != nil {
+		p.log.Error("Error fetching supplier payments", "error", err.Error())
+		return nil, fmt.Errorf("error fetching supplier payments: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetPayables retrieves purchases that are not paid in full.
+func (p *PayablesUseCase) GetPayables(in *entity.PayableFilter) (*entity.PayableList, error) {
+	res, err := p.repo.GetPayables(in)
+	if err != nil {
+		p.log.Error("Error fetching payables", "error", err.Error())
+		return nil, fmt.Errorf("error fetching payables: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetSupplierBalances retrieves what is owed to each supplier.
+func (p *PayablesUseCase) GetSupplierBalances() (*entity.SupplierBalanceList, error) {
+	res, err := p.repo.GetSupplierBalances()
+	if err != nil {
+		p.log.Error("Error fetching supplier balances", "error", err.Error())
+		return nil, fmt.Errorf("error fetching supplier balances: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetPayablesAging buckets unpaid purchase balances by days past due per supplier.
+func (p *PayablesUseCase) GetPayablesAging() (*entity.AgingReport, error) {
+	res, err := p.repo.GetPayablesAging()
+	if err != nil {
+		p.log.Error("Error building payables aging report", "error", err.Error())
+		return nil, fmt.Errorf("error building payables aging report: %w", err)
+	}
+
+	return res, nil
+}
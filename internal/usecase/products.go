@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"crm-admin/internal/entity"
+	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 )
 
 type ProductsUseCase struct {
@@ -116,3 +119,143 @@ func (p *ProductsUseCase) GetProductList(in *entity.FilterProduct) (*entity.Prod
 
 	return res, nil
 }
+
+// GenerateVariants adds a variant of a product for every combination of the given attribute values,
+// e.g. each size in each color. Combinations that already exist are kept.
+func (p *ProductsUseCase) GenerateVariants(in *entity.VariantGenerateRequest) (*entity.Product, error) {
+	if len(in.Options) == 0 {
+		return nil, fmt.Errorf("at least one attribute with values is required")
+	}
+	for name, values := range in.Options {
+		if strings.TrimSpace(name) == "" || len(values) == 0 {
+			return nil, fmt.Errorf("every attribute needs a name and at least one value")
+		}
+		seen := map[string]bool{}
+		for _, value := range values {
+			if strings.TrimSpace(value) == "" || seen[value] {
+				return nil, fmt.Errorf("values of %s must be unique and not empty", name)
+			}
+			seen[value] = true
+		}
+	}
+
+	res, err := p.repo.GenerateVariants(in)
+
+	if err != nil {
+		p.log.Error("GenerateVariants", "error", err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CreateVariant adds a single variant of a product. Prices left out follow the product.
+func (p *ProductsUseCase) CreateVariant(in *entity.VariantRequest) (*entity.Product, error) {
+	if len(in.Attributes) == 0 {
+		return nil, fmt.Errorf("variant attributes are required")
+	}
+	for name, value := range in.Attributes {
+		if strings.TrimSpace(name) == "" || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("variant attributes must have a name and a value")
+		}
+	}
+	if (in.IncomingPrice != nil && *in.IncomingPrice < 0) || (in.StandardPrice != nil && *in.StandardPrice <= 0) {
+		return nil, fmt.Errorf("variant prices must be positive")
+	}
+
+	res, err := p.repo.CreateVariant(in)
+
+	if err != nil {
+		p.log.Error("CreateVariant", "error", err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetVariantPrice overrides the prices of a variant, or makes it follow the prices of its product again.
+func (p *ProductsUseCase) SetVariantPrice(in *entity.VariantPrice) (*entity.Product, error) {
+	if !in.Inherit && (in.IncomingPrice < 0 || in.StandardPrice <= 0) {
+		return nil, fmt.Errorf("variant prices must be positive")
+	}
+
+	res, err := p.repo.SetVariantPrice(in)
+
+	if err != nil {
+		p.log.Error("SetVariantPrice", "error", err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetCostingMethod returns how sold goods are costed.
+func (p *ProductsUseCase) GetCostingMethod() (*entity.CostingMethod, error) {
+	res, err := p.repo.GetCostingMethod()
+
+	if err != nil {
+		p.log.Error("GetCostingMethod", "error", err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetCostingMethod switches costing between FIFO and moving weighted average for future sales.
+func (p *ProductsUseCase) SetCostingMethod(in *entity.CostingMethod) (*entity.CostingMethod, error) {
+	if in.Method != "fifo" && in.Method != "average" {
+		return nil, fmt.Errorf("costing method must be fifo or average")
+	}
+
+	res, err := p.repo.SetCostingMethod(in)
+
+	if err != nil {
+		p.log.Error("SetCostingMethod", "error", err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetCostLayers returns the stock of a product by cost layer with its value.
+func (p *ProductsUseCase) GetCostLayers(in *entity.ProductID) (*entity.CostLayerList, error) {
+	res, err := p.repo.GetCostLayers(in)
+
+	if err != nil {
+		p.log.Error("GetCostLayers", "error", err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetInventoryHistory returns the stock card of a product for a period, the current month by default.
+func (p *ProductsUseCase) GetInventoryHistory(in *entity.InventoryMovementFilter) (*entity.InventoryHistory, error) {
+	now := time.Now()
+	if in.From == "" {
+		in.From = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).Format("2006-01-02")
+	}
+	if in.To == "" {
+		in.To = now.Format("2006-01-02")
+	}
+	from, err := time.Parse("2006-01-02", in.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date %q", in.From)
+	}
+	to, err := time.Parse("2006-01-02", in.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date %q", in.To)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("to date is before the from date")
+	}
+
+	res, err := p.repo.GetInventoryHistory(in)
+
+	if err != nil {
+		p.log.Error("GetInventoryHistory", "error", err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -4,7 +4,7 @@ import (
 	"crm-admin/internal/entity"
 	"fmt"
 	"log/slog"
-	"sync"
+	"strings"
 )
 
 type PurchaseUseCase struct {
@@ -30,12 +30,17 @@ func (p *PurchaseUseCase) CalculateTotalPurchases(in *entity.Purchase) (*entity.
 		if pr.Quantity == 0 {
 			continue
 		}
+		if err := checkExpiryDate(pr.ExpiryDate); err != nil {
+			return nil, err
+		}
 
 		purchase := entity.PurchaseItemReq{
 			PurchasePrice: pr.PurchasePrice,
 			ProductID:     pr.ProductID,
 			Quantity:      pr.Quantity,
 			TotalPrice:    float64(pr.Quantity) * pr.PurchasePrice,
+			LotNumber:     pr.LotNumber,
+			ExpiryDate:    pr.ExpiryDate,
 		}
 
 		purchaseList = append(purchaseList, purchase)
@@ -47,45 +52,41 @@ func (p *PurchaseUseCase) CalculateTotalPurchases(in *entity.Purchase) (*entity.
 	result.PurchaseItem = &purchaseList
 	result.TotalCost = totalSum
 	result.PaymentMethod = in.PaymentMethod
+	result.Currency = in.Currency
 	result.Description = in.Description
+	result.DueDate = in.DueDate
+
+	// Without credit the purchase is paid in full upfront
+	result.PaidAmount = totalSum
+	if in.OnCredit {
+		if in.PaidAmount < 0 || in.PaidAmount > totalSum {
+			return nil, fmt.Errorf("paid amount must be between 0 and %.2f", totalSum)
+		}
+		result.PaidAmount = in.PaidAmount
+	}
 
 	return &result, nil
 }
 
 func (p *PurchaseUseCase) CreatePurchase(in *entity.Purchase) (*entity.PurchaseResponse, error) {
+	if in.PaymentMethod == "" {
+		in.PaymentMethod = "uzs"
+	}
+	in.Currency = strings.ToUpper(in.Currency)
+
 	req, err := p.CalculateTotalPurchases(in)
 	if err != nil {
 		p.log.Error("Error calculating total purchase cost", "error", err.Error())
 		return nil, fmt.Errorf("error calculating total purchase cost: %w", err)
 	}
 
+	// Stock is received in the same transaction as the purchase
 	res, err := p.repo.CreatePurchase(req)
 	if err != nil {
 		p.log.Error("Error creating purchase", "error", err.Error())
 		return nil, fmt.Errorf("error creating purchase: %w", err)
 	}
 
-	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, 10) // limit to 10 goroutines
-
-	for _, item := range *res.PurchaseItem {
-		wg.Add(1)
-		go func(item entity.PurchaseItemReq) {
-			defer wg.Done()
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			productQuantityReq := &entity.CountProductReq{
-				Id:    item.ProductID,
-				Count: item.Quantity,
-			}
-			if _, err := p.product.AddProduct(productQuantityReq); err != nil {
-				p.log.Error("Error adding product quantity", "error", err.Error())
-			}
-		}(item)
-	}
-
-	wg.Wait()
 	return res, nil
 }
 
@@ -155,28 +156,67 @@ func (p *PurchaseUseCase) DeletePurchase(req *entity.PurchaseID) (*entity.Messag
 		return nil, err
 	}
 
+	// Stock is taken back out in the same transaction
 	res, err := p.repo.DeletePurchase(req)
 	if err != nil {
 		p.log.Error("Error deleting purchase", "error", err.Error())
 		return nil, fmt.Errorf("error deleting purchase: %w", err)
 	}
 
-	go func() {
-		for _, item := range *purchase.PurchaseItem {
-			if item.Quantity == 0 {
-				item.Quantity = 1
-			}
-
-			productQuantityReq := &entity.CountProductReq{
-				Id:    item.ProductID,
-				Count: item.Quantity,
-			}
+	return res, nil
+}
 
-			if _, err := p.product.RemoveProduct(productQuantityReq); err != nil {
-				p.log.Error("Error removing product in DeletePurchase", "error", err.Error())
-			}
+// CreateSupplierReturn sends purchased goods back to the supplier without touching the purchase itself.
+func (p *PurchaseUseCase) CreateSupplierReturn(in *entity.SupplierReturnRequest) (*entity.SupplierReturn, error) {
+	if len(in.Items) == 0 {
+		return nil, fmt.Errorf("return must have at least one line")
+	}
+	for _, item := range in.Items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("returned quantities must be positive")
 		}
-	}()
+	}
+	switch in.RefundMethod {
+	case "", "none", "uzs", "usd", "card":
+	default:
+		return nil, fmt.Errorf("refund method must be uzs, usd or card")
+	}
+
+	res, err := p.repo.CreateSupplierReturn(in)
+	if err != nil {
+		p.log.Error("Error creating supplier return", "error", err.Error())
+		return nil, fmt.Errorf("error creating supplier return: %w", err)
+	}
+
+	return res, nil
+}
+
+func (p *PurchaseUseCase) GetSupplierReturn(in *entity.SupplierReturnID) (*entity.SupplierReturn, error) {
+	res, err := p.repo.GetSupplierReturn(in)
+	if err != nil {
+		p.log.Error("Error fetching supplier return", "error", err.Error())
+		return nil, fmt.Errorf("error fetching supplier return: %w", err)
+	}
+
+	return res, nil
+}
+
+func (p *PurchaseUseCase) GetSupplierReturnList(in *entity.SupplierReturnFilter) (*entity.SupplierReturnList, error) {
+	res, err := p.repo.GetSupplierReturnList(in)
+	if err != nil {
+		p.log.Error("Error fetching supplier returns", "error", err.Error())
+		return nil, fmt.Errorf("error fetching supplier returns: %w", err)
+	}
+
+	return res, nil
+}
+
+func (p *PurchaseUseCase) GetReturnablePurchaseItems(in *entity.PurchaseID) (*entity.ReturnablePurchaseItemList, error) {
+	res, err := p.repo.GetReturnablePurchaseItems(in)
+	if err != nil {
+		p.log.Error("Error fetching returnable purchase lines", "error", err.Error())
+		return nil, fmt.Errorf("error fetching returnable purchase lines: %w", err)
+	}
 
 	return res, nil
 }
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"bytes"
+	"crm-admin/internal/entity"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"strings"
+)
+
+type PurchaseOrdersUseCase struct {
+	repo PurchaseOrdersRepo
+	log  *slog.Logger
+}
+
+func NewPurchaseOrdersUseCase(repo PurchaseOrdersRepo, log *slog.Logger) *PurchaseOrdersUseCase {
+	return &PurchaseOrdersUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func validatePurchaseOrder(in *entity.PurchaseOrderRequest) error {
+	if in.SupplierID == "" {
+		return fmt.Errorf("supplier is required")
+	}
+	if len(in.Items) == 0 {
+		return fmt.Errorf("purchase order must have at least one line")
+	}
+
+	seen := map[string]bool{}
+	for _, item := range in.Items {
+		if item.Quantity <= 0 {
+			return fmt.Errorf("ordered quantities must be positive")
+		}
+		if item.UnitPrice < 0 {
+			return fmt.Errorf("unit prices cannot be negative")
+		}
+		if seen[item.ProductID] {
+			return fmt.Errorf("product %s is ordered twice", item.ProductID)
+		}
+		seen[item.ProductID] = true
+	}
+	in.Currency = strings.ToUpper(in.Currency)
+
+	return nil
+}
+
+// CreatePurchaseOrder drafts an order to a supplier. Nothing reaches stock until the order is received.
+func (p *PurchaseOrdersUseCase) CreatePurchaseOrder(in *entity.PurchaseOrderRequest) (*entity.PurchaseOrder, error) {
+	if err := validatePurchaseOrder(in); err != nil {
+		return nil, err
+	}
+
+	res, err := p.repo.CreatePurchaseOrder(in)
+	if err != nil {
+		p.log.Error("Error creating purchase order", "error", err.Error())
+		return nil, fmt.Errorf("error creating purchase order: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdatePurchaseOrder replaces a draft order.
+func (p *PurchaseOrdersUseCase) UpdatePurchaseOrder(in *entity.PurchaseOrderRequest) (*entity.PurchaseOrder, error) {
+	if err := validatePurchaseOrder(in); err != nil {
+		return nil, err
+	}
+
+	res, err := p.repo.UpdatePurchaseOrder(in)
+	if err != nil {
+		p.log.Error("Error updating purchase order", "error", err.Error())
+		return nil, fmt.Errorf("error updating purchase order: %w", err)
+	}
+
+	return res, nil
+}
+
+func (p *PurchaseOrdersUseCase) GetPurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error) {
+	res, err := p.repo.GetPurchaseOrder(in)
+	if err != nil {
+		p.log.Error("Error fetching purchase order", "error", err.Error())
+		return nil, fmt.Errorf("error fetching purchase order: %w", err)
+	}
+
+	return res, nil
+}
+
+func (p *PurchaseOrdersUseCase) GetPurchaseOrders(in *entity.PurchaseOrderFilter) (*entity.PurchaseOrderList, error) {
+	res, err := p.repo.GetPurchaseOrders(in)
+	if err != nil {
+		p.log.Error("Error fetching purchase orders", "error", err.Error())
+		return nil, fmt.Errorf("error fetching purchase orders: %w", err)
+	}
+
+	return res, nil
+}
+
+// SendPurchaseOrder marks a draft order as sent to the supplier, after which it can no longer be changed.
+func (p *PurchaseOrdersUseCase) SendPurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error) {
+	res, err := p.repo.SendPurchaseOrder(in)
+	if err != nil {
+		p.log.Error("Error sending purchase order", "error", err.Error())
+		return nil, fmt.Errorf("error sending purchase order: %w", err)
+	}
+
+	return res, nil
+}
+
+// ReceivePurchaseOrder brings the delivered quantities into stock as a purchase and flags the differences
+// with what was ordered.
+func (p *PurchaseOrdersUseCase) ReceivePurchaseOrder(in *entity.PurchaseOrderReceiptRequest) (*entity.PurchaseOrderReceipt, error) {
+	for _, item := range in.Items {
+		if item.Quantity < 0 {
+			return nil, fmt.Errorf("received quantities cannot be negative")
+		}
+		if err := checkExpiryDate(item.ExpiryDate); err != nil {
+			return nil, err
+		}
+	}
+	if in.PaymentMethod == "" {
+		in.PaymentMethod = "uzs"
+	}
+
+	res, err := p.repo.ReceivePurchaseOrder(in)
+	if err != nil {
+		p.log.Error("Error receiving purchase order", "error", err.Error())
+		return nil, fmt.Errorf("error receiving purchase order: %w", err)
+	}
+
+	return res, nil
+}
+
+// ClosePurchaseOrder closes a partially received order without waiting for the rest.
+func (p *PurchaseOrdersUseCase) ClosePurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error) {
+	res, err := p.repo.ClosePurchaseOrder(in)
+	if err != nil {
+		p.log.Error("Error closing purchase order", "error", err.Error())
+		return nil, fmt.Errorf("error closing purchase order: %w", err)
+	}
+
+	return res, nil
+}
+
+func (p *PurchaseOrdersUseCase) CancelPurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error) {
+	res, err := p.repo.CancelPurchaseOrder(in)
+	if err != nil {
+		p.log.Error("Error cancelling purchase order", "error", err.Error())
+		return nil, fmt.Errorf("error cancelling purchase order: %w", err)
+	}
+
+	return res, nil
+}
+
+var purchaseOrderPrint = template.Must(template.New("purchase-order").Funcs(template.FuncMap{
+	"inc":   func(i int) int { return i + 1 },
+	"money": func(v float64) string { return fmt.Sprintf("%.2f", v) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Purchase order #{{.OrderNumber}}</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 32px; }
+table { width: 100%; border-collapse: collapse; margin-top: 16px; }
+th, td { border: 1px solid #999; padding: 4px 8px; text-align: left; }
+td.num, th.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Purchase order #{{.OrderNumber}}</h1>
+<p>Supplier: {{.SupplierName}}<br>
+Date: {{.CreatedAt}}<br>
+{{if .ExpectedDate}}Expected delivery: {{.ExpectedDate}}<br>{{end}}
+{{if .Description}}Note: {{.Description}}{{end}}</p>
+<table>
+<tr><th>#</th><th>Product</th><th class="num">Quantity</th><th class="num">Unit price</th><th class="num">Total</th></tr>
+{{range $i, $item := .Items}}<tr><td>{{inc $i}}</td><td>{{$item.ProductName}}</td><td class="num">{{$item.Quantity}}</td>
+<td class="num">{{money $item.UnitPrice}}</td><td class="num">{{money $item.TotalPrice}}</td></tr>
+{{end}}<tr><th colspan="4" class="num">Total, {{.Currency}}</th><th class="num">{{money .TotalCost}}</th></tr>
+</table>
+</body>
+</html>
+`))
+
+// PrintPurchaseOrder renders an order as an HTML page to print or send to the supplier.
+func (p *PurchaseOrdersUseCase) PrintPurchaseOrder(in *entity.PurchaseOrderID) ([]byte, error) {
+	order, err := p.GetPurchaseOrder(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := purchaseOrderPrint.Execute(&buf, order); err != nil {
+		p.log.Error("Error rendering purchase order", "error", err.Error())
+		return nil, fmt.Errorf("error rendering purchase order: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type RatesUseCase struct {
+	repo     RatesRepo
+	provider RateProvider
+	log      *slog.Logger
+}
+
+func NewRatesUseCase(repo RatesRepo, provider RateProvider, log *slog.Logger) *RatesUseCase {
+	return &RatesUseCase{
+		repo:     repo,
+		provider: provider,
+		log:      log,
+	}
+}
+
+func validCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// AddRate records a manual exchange rate of a currency to the base currency for a day.
+func (r *RatesUseCase) AddRate(in *entity.ExchangeRateRequest) (*entity.ExchangeRate, error) {
+	in.Currency = strings.ToUpper(in.Currency)
+	if !validCurrency(in.Currency) {
+		return nil, fmt.Errorf("invalid currency code %q", in.Currency)
+	}
+	if in.Rate <= 0 {
+		return nil, fmt.Errorf("exchange rate must be positive")
+	}
+
+	base, err := r.repo.GetBaseCurrency()
+	if err != nil {
+		r.log.Error("Error fetching base currency", "error", err.Error())
+		return nil, fmt.Errorf("error fetching base currency: %w", err)
+	}
+	if in.Currency == base.Currency {
+		return nil, fmt.Errorf("%s is the base currency", in.Currency)
+	}
+
+	res, err := r.repo.SaveRates([]entity.ExchangeRateRequest{*in}, "manual")
+	if err != nil {
+		r.log.Error("Error saving exchange rate", "error", err.Error())
+		return nil, fmt.Errorf("error saving exchange rate: %w", err)
+	}
+
+	return &res[0], nil
+}
+
+// GetRates retrieves exchange rates with optional currency and date filters.
+func (r *RatesUseCase) GetRates(in *entity.ExchangeRateFilter) (*entity.ExchangeRateList, error) {
+	in.Currency = strings.ToUpper(in.Currency)
+
+	res, err := r.repo.GetRates(in)
+	if err != nil {
+		r.log.Error("Error fetching exchange rates", "error", err.Error())
+		return nil, fmt.Errorf("error fetching exchange rates: %w", err)
+	}
+
+	return res, nil
+}
+
+// SyncRates loads today's rates from the configured provider.
+func (r *RatesUseCase) SyncRates() (*entity.RateSyncResult, error) {
+	base, err := r.repo.GetBaseCurrency()
+	if err != nil {
+		r.log.Error("Error fetching base currency", "error", err.Error())
+		return nil, fmt.Errorf("error fetching base currency: %w", err)
+	}
+
+	date := time.Now()
+	rates, err := r.provider.Rates(base.Currency, date)
+	if err != nil {
+		r.log.Error("Error fetching rates from provider", "error", err.Error())
+		return nil, fmt.Errorf("error fetching rates from provider: %w", err)
+	}
+
+	result := &entity.RateSyncResult{Date: date.Format("2006-01-02")}
+	var req []entity.ExchangeRateRequest
+	for currency, rate := range rates {
+		if currency == base.Currency || rate <= 0 {
+			continue
+		}
+		req = append(req, entity.ExchangeRateRequest{Currency: currency, Rate: rate, RateDate: result.Date})
+	}
+
+	saved, err := r.repo.SaveRates(req, "provider")
+	if err != nil {
+		r.log.Error("Error saving provider rates", "error", err.Error())
+		return nil, fmt.Errorf("error saving provider rates: %w", err)
+	}
+	result.Updated = len(saved)
+
+	return result, nil
+}
+
+// GetBaseCurrency returns the currency reports are converted to.
+func (r *RatesUseCase) GetBaseCurrency() (*entity.BaseCurrency, error) {
+	res, err := r.repo.GetBaseCurrency()
+	if err != nil {
+		r.log.Error("Error fetching base currency", "error", err.Error())
+		return nil, fmt.Errorf("error fetching base currency: %w", err)
+	}
+
+	return res, nil
+}
+
+// SetBaseCurrency changes the base currency while no rates depend on it yet.
+func (r *RatesUseCase) SetBaseCurrency(in *entity.BaseCurrency) (*entity.BaseCurrency, error) {
+	in.Currency = strings.ToUpper(in.Currency)
+	if !validCurrency(in.Currency) {
+		return nil, fmt.Errorf("invalid currency code %q", in.Currency)
+	}
+
+	res, err := r.repo.SetBaseCurrency(in)
+	if err != nil {
+		r.log.Error("Error setting base currency", "error", err.Error())
+		return nil, fmt.Errorf("error setting base currency: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,84 @@
+package rates
+
+import (
+	"crm-admin/config"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CBU loads the official rates of the Central Bank of Uzbekistan.
+type CBU struct {
+	url    string
+	client *http.Client
+}
+
+func NewCBU(cfg config.Config) *CBU {
+	return &CBU{
+		url:    strings.TrimRight(cfg.RATE_PROVIDER_URL, "/"),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type cbuRate struct {
+	Ccy     string `json:"Ccy"`
+	Rate    string `json:"Rate"`
+	Nominal string `json:"Nominal"`
+}
+
+func (c *CBU) Rates(base string, date time.Time) (map[string]float64, error) {
+	resp, err := c.client.Get(c.url + "/all/" + date.Format("2006-01-02") + "/")
+	if err != nil {
+		return nil, fmt.Errorf("rate provider request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("rate provider returned %d: %s", resp.StatusCode, msg)
+	}
+
+	var list []cbuRate
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode rates: %w", err)
+	}
+
+	// The bank quotes sums per nominal units of each currency
+	uzs := map[string]float64{}
+	for _, r := range list {
+		rate, err := strconv.ParseFloat(r.Rate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate %q for %s", r.Rate, r.Ccy)
+		}
+		nominal, err := strconv.ParseFloat(r.Nominal, 64)
+		if err != nil || nominal <= 0 {
+			nominal = 1
+		}
+		uzs[strings.ToUpper(r.Ccy)] = rate / nominal
+	}
+
+	return crossRates(uzs, base)
+}
+
+// crossRates turns rates in sums into rates in the base currency.
+func crossRates(uzs map[string]float64, base string) (map[string]float64, error) {
+	uzs["UZS"] = 1
+
+	baseRate, ok := uzs[base]
+	if !ok {
+		return nil, fmt.Errorf("no rate for base currency %s", base)
+	}
+
+	rates := make(map[string]float64, len(uzs))
+	for currency, rate := range uzs {
+		if currency != base {
+			rates[currency] = rate / baseRate
+		}
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,18 @@
+package rates
+
+import "time"
+
+// Stub returns fixed rates, for development without access to the bank.
+type Stub struct{}
+
+func NewStub() *Stub {
+	return &Stub{}
+}
+
+func (s *Stub) Rates(base string, date time.Time) (map[string]float64, error) {
+	return crossRates(map[string]float64{
+		"USD": 12800,
+		"EUR": 13900,
+		"RUB": 140,
+	}, base)
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/pkg/schedule"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// maxCatchUp limits how many missed runs of one template are posted at once,
+// the rest follow on the next run of the scheduler.
+const maxCatchUp = 100
+
+type RecurringUseCase struct {
+	repo RecurringRepo
+	log  *slog.Logger
+}
+
+func NewRecurringUseCase(repo RecurringRepo, log *slog.Logger) *RecurringUseCase {
+	return &RecurringUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func validateRecurringExpense(in *entity.RecurringExpenseRequest) error {
+	if in.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if in.Amount <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	if in.CategoryID == "" || in.WalletID == "" {
+		return fmt.Errorf("category and wallet are required")
+	}
+	if _, err := schedule.Parse(in.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	var start time.Time
+	if in.StartDate != "" {
+		t, err := time.Parse("2006-01-02", in.StartDate)
+		if err != nil {
+			return fmt.Errorf("invalid start date %q", in.StartDate)
+		}
+		start = t
+	}
+	if in.EndDate != "" {
+		end, err := time.Parse("2006-01-02", in.EndDate)
+		if err != nil {
+			return fmt.Errorf("invalid end date %q", in.EndDate)
+		}
+		if end.Before(start) {
+			return fmt.Errorf("end date is before the start date")
+		}
+	}
+
+	return nil
+}
+
+// dueRuns returns the runs of a template after the given time up to the limit, within its start and end dates.
+func dueRuns(expense *entity.RecurringExpense, after, until time.Time, limit int) ([]time.Time, error) {
+	sched, err := schedule.Parse(expense.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule of %s: %w", expense.Name, err)
+	}
+
+	start, err := time.ParseInLocation("2006-01-02", expense.StartDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date of %s: %w", expense.Name, err)
+	}
+	if after.Before(start) {
+		after = start.Add(-time.Second)
+	}
+
+	// Runs on the end date are still posted
+	if expense.EndDate != "" {
+		end, err := time.ParseInLocation("2006-01-02", expense.EndDate, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end date of %s: %w", expense.Name, err)
+		}
+		if end = end.AddDate(0, 0, 1); end.Before(until) {
+			until = end.Add(-time.Second)
+		}
+	}
+
+	var runs []time.Time
+	for t := sched.Next(after); !t.IsZero() && !t.After(until) && len(runs) < limit; t = sched.Next(t) {
+		runs = append(runs, t)
+	}
+
+	return runs, nil
+}
+
+// lastPosted returns the time of the last posted run, or the zero time.
+func lastPosted(expense *entity.RecurringExpense) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", expense.LastPostedAt, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// CreateRecurringExpense adds a template for an expense that repeats on a schedule.
+func (r *RecurringUseCase) CreateRecurringExpense(in *entity.RecurringExpenseRequest) (*entity.RecurringExpense, error) {
+	if err := validateRecurringExpense(in); err != nil {
+		return nil, err
+	}
+	in.IsActive = true
+
+	res, err := r.repo.CreateRecurringExpense(in)
+	if err != nil {
+		r.log.Error("Error creating recurring expense", "error", err.Error())
+		return nil, fmt.Errorf("error creating recurring expense: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateRecurringExpense changes a template, including pausing it with is_active.
+func (r *RecurringUseCase) UpdateRecurringExpense(in *entity.RecurringExpenseRequest) (*entity.RecurringExpense, error) {
+	if err := validateRecurringExpense(in); err != nil {
+		return nil, err
+	}
+
+	res, err := r.repo.UpdateRecurringExpense(in)
+	if err != nil {
+		r.log.Error("Error updating recurring expense", "error", err.Error())
+		return nil, fmt.Errorf("error updating recurring expense: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteRecurringExpense removes a template that has never been posted.
+func (r *RecurringUseCase) DeleteRecurringExpense(in *entity.RecurringExpenseID) (*entity.Message, error) {
+	res, err := r.repo.DeleteRecurringExpense(in)
+	if err != nil {
+		r.log.Error("Error deleting recurring expense", "error", err.Error())
+		return nil, fmt.Errorf("error deleting recurring expense: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetRecurringExpense retrieves a template by ID.
+func (r *RecurringUseCase) GetRecurringExpense(in *entity.RecurringExpenseID) (*entity.RecurringExpense, error) {
+	res, err := r.repo.GetRecurringExpense(in)
+	if err != nil {
+		r.log.Error("Error fetching recurring expense", "error", err.Error())
+		return nil, fmt.Errorf("error fetching recurring expense: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetRecurringExpenses retrieves all templates.
+func (r *RecurringUseCase) GetRecurringExpenses() (*entity.RecurringExpenseList, error) {
+	res, err := r.repo.GetRecurringExpenses()
+	if err != nil {
+		r.log.Error("Error fetching recurring expenses", "error", err.Error())
+		return nil, fmt.Errorf("error fetching recurring expenses: %w", err)
+	}
+
+	return res, nil
+}
+
+// PreviewRecurringExpense lists the upcoming postings of a template, 12 by default.
+func (r *RecurringUseCase) PreviewRecurringExpense(in *entity.RecurringPreviewFilter) (*entity.RecurringPreview, error) {
+	if in.Count <= 0 {
+		in.Count = 12
+	}
+	if in.Count > maxCatchUp {
+		in.Count = maxCatchUp
+	}
+
+	expense, err := r.repo.GetRecurringExpense(&entity.RecurringExpenseID{ID: in.ID})
+	if err != nil {
+		r.log.Error("Error fetching recurring expense", "error", err.Error())
+		return nil, fmt.Errorf("error fetching recurring expense: %w", err)
+	}
+
+	// Runs missed while the service was down come first, they are posted on the next run
+	after := lastPosted(expense)
+	runs, err := dueRuns(expense, after, time.Now().AddDate(100, 0, 0), in.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &entity.RecurringPreview{
+		ExpenseID: expense.ID,
+		Name:      expense.Name,
+		Currency:  expense.Currency,
+		Postings:  []entity.RecurringPosting{},
+	}
+	if !expense.IsActive {
+		return preview, nil
+	}
+	for _, t := range runs {
+		preview.Postings = append(preview.Postings, entity.RecurringPosting{
+			Date:   t.Format("2006-01-02 15:04"),
+			Amount: expense.Amount,
+		})
+	}
+
+	return preview, nil
+}
+
+// PostDueExpenses posts every run that is due, including runs missed while the service was down. It is run hourly.
+func (r *RecurringUseCase) PostDueExpenses() (*entity.RecurringRunResult, error) {
+	expenses, err := r.repo.GetRecurringExpenses()
+	if err != nil {
+		r.log.Error("Error fetching recurring expenses", "error", err.Error())
+		return nil, fmt.Errorf("error fetching recurring expenses: %w", err)
+	}
+
+	res := &entity.RecurringRunResult{}
+	now := time.Now()
+	for _, expense := range expenses.Expenses {
+		if !expense.IsActive {
+			continue
+		}
+
+		runs, err := dueRuns(&expense, lastPosted(&expense), now, maxCatchUp)
+		if err != nil {
+			r.log.Error("Error scheduling recurring expense", "error", err.Error(), "expense_id", expense.ID)
+			res.Failed++
+			continue
+		}
+
+		for _, t := range runs {
+			posted, err := r.repo.PostRecurringExpense(expense.ID, t)
+			if err != nil {
+				r.log.Error("Error posting recurring expense", "error", err.Error(), "expense_id", expense.ID)
+				res.Failed++
+				break
+			}
+			if posted {
+				res.Posted++
+			}
+		}
+	}
+
+	r.log.Info("Recurring expenses processed", "posted", res.Posted, "failed", res.Failed)
+	return res, nil
+}
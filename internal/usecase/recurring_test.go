@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"testing"
+	"time"
+)
+
+func localTime(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestDueRuns(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedule  string
+		startDate string
+		endDate   string
+		after     string
+		until     string
+		limit     int
+		want      []string
+	}{
+		{
+			name:     "runs up to now",
+			schedule: "0 9 * * *", startDate: "2024-03-01",
+			after: "2024-03-01 09:00", until: "2024-03-03 12:00", limit: 100,
+			want: []string{"2024-03-02 09:00", "2024-03-03 09:00"},
+		},
+		{
+			name:     "run at the until time is due",
+			schedule: "0 9 * * *", startDate: "2024-03-01",
+			after: "2024-03-01 09:00", until: "2024-03-02 09:00", limit: 100,
+			want: []string{"2024-03-02 09:00"},
+		},
+		{
+			name:     "never posted starts at the start date",
+			schedule: "0 9 * * *", startDate: "2024-03-01",
+			until: "2024-03-02 08:00", limit: 100,
+			want: []string{"2024-03-01 09:00"},
+		},
+		{
+			name:     "run at midnight of the start date",
+			schedule: "0 0 * * *", startDate: "2024-03-01",
+			after: "2024-02-01 00:00", until: "2024-03-01 12:00", limit: 100,
+			want: []string{"2024-03-01 00:00"},
+		},
+		{
+			name:     "start date in the future",
+			schedule: "0 9 * * *", startDate: "2024-04-01",
+			until: "2024-03-10 12:00", limit: 100,
+			want: nil,
+		},
+		{
+			name:     "runs on the end date are posted",
+			schedule: "0 9 * * *", startDate: "2024-03-01", endDate: "2024-03-02",
+			until: "2024-03-10 12:00", limit: 100,
+			want: []string{"2024-03-01 09:00", "2024-03-02 09:00"},
+		},
+		{
+			name:     "midnight after the end date is not",
+			schedule: "0 0 * * *", startDate: "2024-03-01", endDate: "2024-03-02",
+			until: "2024-03-10 12:00", limit: 100,
+			want: []string{"2024-03-01 00:00", "2024-03-02 00:00"},
+		},
+		{
+			name:     "catch-up stops at the limit",
+			schedule: "0 9 * * *", startDate: "2024-03-01",
+			until: "2024-12-31 12:00", limit: 3,
+			want: []string{"2024-03-01 09:00", "2024-03-02 09:00", "2024-03-03 09:00"},
+		},
+		{
+			name:     "next catch-up continues after the last posted run",
+			schedule: "0 9 * * *", startDate: "2024-03-01",
+			after: "2024-03-03 09:00", until: "2024-03-05 12:00", limit: 3,
+			want: []string{"2024-03-04 09:00", "2024-03-05 09:00"},
+		},
+		{
+			name:     "monthly at the end of each month",
+			schedule: "monthly:31", startDate: "2024-01-01",
+			until: "2024-04-30 12:00", limit: 100,
+			want: []string{"2024-01-31 00:00", "2024-02-29 00:00", "2024-03-31 00:00", "2024-04-30 00:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expense := &entity.RecurringExpense{
+				Name:      "Rent",
+				Schedule:  tt.schedule,
+				StartDate: tt.startDate,
+				EndDate:   tt.endDate,
+			}
+			var after time.Time
+			if tt.after != "" {
+				after = localTime(tt.after)
+			}
+
+			runs, err := dueRuns(expense, after, localTime(tt.until), tt.limit)
+			if err != nil {
+				t.Fatalf("dueRuns error: %v", err)
+			}
+			if len(runs) != len(tt.want) {
+				t.Fatalf("dueRuns returned %d runs %v, want %v", len(runs), runs, tt.want)
+			}
+			for i, run := range runs {
+				if !run.Equal(localTime(tt.want[i])) {
+					t.Errorf("run %d = %s, want %s", i, run.Format("2006-01-02 15:04"), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDueRunsErrors(t *testing.T) {
+	tests := []entity.RecurringExpense{
+		{Name: "Bad schedule", Schedule: "0 0 31 2 *", StartDate: "2024-03-01"},
+		{Name: "Bad start", Schedule: "0 9 * * *", StartDate: "01.03.2024"},
+		{Name: "Bad end", Schedule: "0 9 * * *", StartDate: "2024-03-01", EndDate: "soon"},
+	}
+
+	for _, expense := range tests {
+		if _, err := dueRuns(&expense, time.Time{}, localTime("2024-03-10 12:00"), 100); err == nil {
+			t.Errorf("dueRuns(%s) should fail", expense.Name)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type RemindersUseCase struct {
+	repo      RemindersRepo
+	notifiers map[string]Notifier
+	log       *slog.Logger
+}
+
+// NewRemindersUseCase takes the notifiers keyed by channel name, e.g. "sms" and "telegram".
+func NewRemindersUseCase(repo RemindersRepo, notifiers map[string]Notifier, log *slog.Logger) *RemindersUseCase {
+	return &RemindersUseCase{
+		repo:      repo,
+		notifiers: notifiers,
+		log:       log,
+	}
+}
+
+func (r *RemindersUseCase) validateRule(in *entity.ReminderRule) error {
+	switch in.Kind {
+	case "before_due", "overdue":
+		if in.Days <= 0 {
+			return fmt.Errorf("days must be positive for %s reminders", in.Kind)
+		}
+	case "on_due":
+		in.Days = 0
+	default:
+		return fmt.Errorf("unknown reminder kind %q", in.Kind)
+	}
+
+	if _, ok := r.notifiers[in.Channel]; !ok {
+		return fmt.Errorf("unknown reminder channel %q", in.Channel)
+	}
+
+	if in.TemplateUz == "" || in.TemplateRu == "" {
+		return fmt.Errorf("templates in both uz and ru are required")
+	}
+
+	return nil
+}
+
+// CreateReminderRule adds an active reminder rule.
+func (r *RemindersUseCase) CreateReminderRule(in *entity.ReminderRule) (*entity.ReminderRule, error) {
+	if err := r.validateRule(in); err != nil {
+		return nil, err
+	}
+
+	res, err := r.repo.CreateReminderRule(in)
+	if err != nil {
+		r.log.Error("Error creating reminder rule", "error", err.Error())
+		return nil, fmt.Errorf("error creating reminder rule: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateReminderRule replaces all fields of a reminder rule.
+func (r *RemindersUseCase) UpdateReminderRule(in *entity.ReminderRule) (*entity.ReminderRule, error) {
+	if err := r.validateRule(in); err != nil {
+		return nil, err
+	}
+
+	res, err := r.repo.UpdateReminderRule(in)
+	if err != nil {
+		r.log.Error("Error updating reminder rule", "error", err.Error())
+		return nil, fmt.Errorf("error updating reminder rule: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetReminderRuleList retrieves all reminder rules.
+func (r *RemindersUseCase) GetReminderRuleList() (*entity.ReminderRuleList, error) {
+	res, err := r.repo.GetReminderRuleList()
+	if err != nil {
+		r.log.Error("Error fetching reminder rules", "error", err.Error())
+		return nil, fmt.Errorf("error fetching reminder rules: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteReminderRule removes a reminder rule, or deactivates it if reminders were already sent.
+func (r *RemindersUseCase) DeleteReminderRule(in *entity.ReminderRuleID) (*entity.Message, error) {
+	res, err := r.repo.DeleteReminderRule(in)
+	if err != nil {
+		r.log.Error("Error deleting reminder rule", "error", err.Error())
+		return nil, fmt.Errorf("error deleting reminder rule: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetReminderLogs retrieves the delivery log of reminders.
+func (r *RemindersUseCase) GetReminderLogs(in *entity.ReminderLogFilter) (*entity.ReminderLogList, error) {
+	res, err := r.repo.GetReminderLogs(in)
+	if err != nil {
+		r.log.Error("Error fetching reminder logs", "error", err.Error())
+		return nil, fmt.Errorf("error fetching reminder logs: %w", err)
+	}
+
+	return res, nil
+}
+
+// SendReminders delivers every reminder due today and logs each attempt. It is run daily.
+func (r *RemindersUseCase) SendReminders() (*entity.ReminderRunResult, error) {
+	due, err := r.repo.GetDueReminders()
+	if err != nil {
+		r.log.Error("Error fetching due reminders", "error", err.Error())
+		return nil, fmt.Errorf("error fetching due reminders: %w", err)
+	}
+
+	res := &entity.ReminderRunResult{}
+	for _, item := range due {
+		entry := &entity.ReminderLog{
+			RuleID:        item.RuleID,
+			DebtID:        item.DebtID,
+			InstallmentID: item.InstallmentID,
+			DueDate:       item.DueDate,
+			Channel:       item.Channel,
+			Recipient:     item.Recipient,
+			Message:       renderReminder(item),
+			Status:        "sent",
+		}
+
+		if err := r.deliver(item.Channel, item.Recipient, entry.Message); err != nil {
+			entry.Status = "failed"
+			entry.Error = err.Error()
+			res.Failed++
+		} else {
+			res.Sent++
+		}
+
+		if err := r.repo.LogReminder(entry); err != nil {
+			r.log.Error("Error logging reminder", "error", err.Error(), "debt_id", item.DebtID)
+		}
+	}
+
+	r.log.Info("Debt reminders processed", "sent", res.Sent, "failed", res.Failed)
+	return res, nil
+}
+
+func (r *RemindersUseCase) deliver(channel, recipient, message string) error {
+	notifier, ok := r.notifiers[channel]
+	if !ok {
+		return fmt.Errorf("unknown reminder channel %q", channel)
+	}
+	if recipient == "" {
+		return fmt.Errorf("client has no %s contact", channel)
+	}
+
+	return notifier.Send(recipient, message)
+}
+
+// renderReminder fills the {client}, {amount}, {due_date} and {days_overdue} placeholders.
+// The amount is given in the currency of the debt, e.g. "150.00 USD".
+func renderReminder(in entity.DueReminder) string {
+	return strings.NewReplacer(
+		"{client}", in.ClientName,
+		"{amount}", strings.TrimSpace(fmt.Sprintf("%.2f %s", in.Amount, in.Currency)),
+		"{due_date}", in.DueDate,
+		"{days_overdue}", fmt.Sprint(in.DaysOverdue),
+	).Replace(in.Template)
+}
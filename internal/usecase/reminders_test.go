@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"testing"
+)
+
+func TestRenderReminder(t *testing.T) {
+	tests := []struct {
+		name string
+		in   entity.DueReminder
+		want string
+	}{
+		{
+			name: "base currency",
+			in: entity.DueReminder{
+				Template: "{client}, pay {amount} by {due_date}", ClientName: "Ali", Amount: 150000, Currency: "UZS",
+				DueDate: "2024-03-01",
+			},
+			want: "Ali, pay 150000.00 UZS by 2024-03-01",
+		},
+		{
+			name: "foreign currency",
+			in: entity.DueReminder{
+				Template: "{amount} is {days_overdue} days late", Amount: 12.5, Currency: "USD", DaysOverdue: 3,
+			},
+			want: "12.50 USD is 3 days late",
+		},
+		{
+			name: "no currency",
+			in:   entity.DueReminder{Template: "Pay {amount}", Amount: 10},
+			want: "Pay 10.00",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := renderReminder(tt.in); got != tt.want {
+			t.Errorf("%s: renderReminder() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+)
+
+type ReorderUseCase struct {
+	repo       ReorderRepo
+	notifier   Notifier
+	channel    string
+	recipients []string
+	log        *slog.Logger
+}
+
+// NewReorderUseCase sends low-stock alerts through the notifier of the given channel to a comma-separated
+// list of recipients. Without explicit recipients, SMS alerts go to the phone numbers of the storekeepers.
+func NewReorderUseCase(repo ReorderRepo, notifiers map[string]Notifier, channel, recipients string, log *slog.Logger) *ReorderUseCase {
+	if channel == "" {
+		channel = "sms"
+	}
+
+	var list []string
+	for _, r := range strings.Split(recipients, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			list = append(list, r)
+		}
+	}
+
+	return &ReorderUseCase{
+		repo:       repo,
+		notifier:   notifiers[channel],
+		channel:    channel,
+		recipients: list,
+		log:        log,
+	}
+}
+
+// SetReorderPoint sets the minimum stock, reorder quantity and preferred supplier of a product.
+// Without a minimum stock the product is no longer watched.
+func (r *ReorderUseCase) SetReorderPoint(in *entity.ReorderPoint) (*entity.ReorderPoint, error) {
+	if in.MinStock != nil && *in.MinStock < 0 {
+		return nil, fmt.Errorf("minimum stock cannot be negative")
+	}
+	if in.ReorderQty != nil && *in.ReorderQty <= 0 {
+		return nil, fmt.Errorf("reorder quantity must be positive")
+	}
+
+	res, err := r.repo.SetReorderPoint(in)
+	if err != nil {
+		r.log.Error("Error setting reorder point", "error", err.Error())
+		return nil, fmt.Errorf("error setting reorder point: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetReorderPoint returns the reorder settings of a product with its stock.
+func (r *ReorderUseCase) GetReorderPoint(in *entity.ProductID) (*entity.ReorderPoint, error) {
+	res, err := r.repo.GetReorderPoint(in)
+	if err != nil {
+		r.log.Error("Error fetching reorder point", "error", err.Error())
+		return nil, fmt.Errorf("error fetching reorder point: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetLowStock lists the products at or below their minimum stock, out of stock first.
+func (r *ReorderUseCase) GetLowStock(in *entity.LowStockFilter) (*entity.LowStockList, error) {
+	res, err := r.repo.GetLowStock(in)
+	if err != nil {
+		r.log.Error("Error fetching low stock products", "error", err.Error())
+		return nil, fmt.Errorf("error fetching low stock products: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetReorderSuggestions drafts purchase orders per supplier. A product is ordered up to what it sells over
+// the cover period on top of its minimum stock; a product at or below its minimum is ordered at least by its
+// reorder quantity.
+func (r *ReorderUseCase) GetReorderSuggestions(in *entity.ReorderFilter) (*entity.ReorderSuggestions, error) {
+	if in.Days == 0 {
+		in.Days = 30
+	}
+	if in.CoverDays == 0 {
+		in.CoverDays = 30
+	}
+	if in.Days < 0 || in.CoverDays < 0 {
+		return nil, fmt.Errorf("days must be positive")
+	}
+
+	candidates, err := r.repo.GetReorderCandidates(in.Days, in.SupplierID)
+	if err != nil {
+		r.log.Error("Error fetching reorder candidates", "error", err.Error())
+		return nil, fmt.Errorf("error fetching reorder candidates: %w", err)
+	}
+
+	res := &entity.ReorderSuggestions{Days: in.Days, CoverDays: in.CoverDays, Orders: []entity.SuggestedOrder{}}
+	orders := map[string]int{}
+	for _, c := range candidates {
+		daily := float64(c.Sold) / float64(in.Days)
+		quantity := int(math.Ceil(daily*float64(in.CoverDays))) + c.MinStock - c.Stock
+		if c.IsWatched && c.Stock <= c.MinStock && quantity < c.ReorderQty {
+			quantity = c.ReorderQty
+		}
+		if quantity <= 0 {
+			continue
+		}
+
+		i, ok := orders[c.SupplierID]
+		if !ok {
+			i = len(res.Orders)
+			orders[c.SupplierID] = i
+			res.Orders = append(res.Orders, entity.SuggestedOrder{SupplierID: c.SupplierID, SupplierName: c.SupplierName})
+		}
+		res.Orders[i].Lines = append(res.Orders[i].Lines, entity.SuggestedOrderLine{
+			ReorderCandidate: c,
+			DailySales:       math.Round(daily*100) / 100,
+			Quantity:         quantity,
+			TotalPrice:       math.Round(float64(quantity)*c.UnitPrice*100) / 100,
+		})
+	}
+
+	return res, nil
+}
+
+// GetStockAlerts retrieves the log of low-stock alerts.
+func (r *ReorderUseCase) GetStockAlerts() (*entity.StockAlertList, error) {
+	res, err := r.repo.GetStockAlerts()
+	if err != nil {
+		r.log.Error("Error fetching stock alerts", "error", err.Error())
+		return nil, fmt.Errorf("error fetching stock alerts: %w", err)
+	}
+
+	return res, nil
+}
+
+// NotifyLowStock sends the list of products at or below their minimum stock. It is run daily.
+func (r *ReorderUseCase) NotifyLowStock() (*entity.StockAlertResult, error) {
+	low, err := r.repo.GetLowStock(&entity.LowStockFilter{})
+	if err != nil {
+		r.log.Error("Error fetching low stock products", "error", err.Error())
+		return nil, fmt.Errorf("error fetching low stock products: %w", err)
+	}
+
+	res := &entity.StockAlertResult{Products: len(low.Products)}
+	if len(low.Products) == 0 {
+		return res, nil
+	}
+
+	recipients := r.recipients
+	if len(recipients) == 0 && r.channel == "sms" {
+		recipients, err = r.repo.GetStorekeeperPhones()
+		if err != nil {
+			r.log.Error("Error fetching storekeeper phones", "error", err.Error())
+			return nil, fmt.Errorf("error fetching storekeeper phones: %w", err)
+		}
+	}
+	if len(recipients) == 0 {
+		r.log.Error("No recipients for stock alerts", "channel", r.channel)
+		return nil, fmt.Errorf("no recipients for %s stock alerts", r.channel)
+	}
+
+	message := lowStockMessage(low.Products)
+	for _, recipient := range recipients {
+		alert := &entity.StockAlert{
+			Products:  len(low.Products),
+			Channel:   r.channel,
+			Recipient: recipient,
+			Status:    "sent",
+		}
+
+		if err := r.deliver(recipient, message); err != nil {
+			alert.Status = "failed"
+			alert.Error = err.Error()
+			res.Failed++
+		} else {
+			res.Sent++
+		}
+
+		if err := r.repo.LogStockAlert(alert); err != nil {
+			r.log.Error("Error logging stock alert", "error", err.Error(), "recipient", recipient)
+		}
+	}
+
+	r.log.Info("Stock alerts processed", "products", res.Products, "sent", res.Sent, "failed", res.Failed)
+	return res, nil
+}
+
+func (r *ReorderUseCase) deliver(recipient, message string) error {
+	if r.notifier == nil {
+		return fmt.Errorf("unknown alert channel %q", r.channel)
+	}
+
+	return r.notifier.Send(recipient, message)
+}
+
+// lowStockMessage lists the products most in need first, keeping the message short enough for an SMS.
+func lowStockMessage(products []entity.LowStockItem) string {
+	const shown = 10
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Low stock: %d product(s).", len(products))
+	for i, p := range products {
+		if i == shown {
+			fmt.Fprintf(&b, " And %d more.", len(products)-shown)
+			break
+		}
+		fmt.Fprintf(&b, " %s: %d of %d;", p.ProductName, p.Stock, p.MinStock)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,438 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"strings"
+)
+
+type bankRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewBankRepo(db *sqlx.DB) usecase.BankRepo {
+	return &bankRepoImpl{db: db}
+}
+
+const bankStatementColumns = `s.id, s.wallet_id, w.name AS wallet_name, s.file_name, s.format, s.imported_by, s.created_at,
+	COUNT(l.id) AS lines,
+	COUNT(l.id) FILTER (WHERE l.cash_flow_id IS NOT NULL AND l.confirmed) AS matched,
+	COUNT(l.id) FILTER (WHERE l.cash_flow_id IS NOT NULL AND NOT l.confirmed) AS suggested,
+	COUNT(l.id) FILTER (WHERE l.cash_flow_id IS NULL) AS unmatched`
+
+const bankStatementFrom = ` FROM bank_statements s
+	JOIN wallets w ON w.id = s.wallet_id
+	LEFT JOIN bank_statement_lines l ON l.statement_id = s.id`
+
+// A line whose entry was deleted is unmatched again
+const bankLineColumns = `l.id, l.statement_id, s.wallet_id, TO_CHAR(l.line_date, 'YYYY-MM-DD') AS line_date, l.amount,
+	COALESCE(l.reference, '') AS reference, COALESCE(l.description, '') AS description,
+	CASE WHEN l.cash_flow_id IS NULL THEN 'unmatched' WHEN l.confirmed THEN 'matched' ELSE 'suggested' END AS status,
+	COALESCE(l.cash_flow_id::text, '') AS cash_flow_id, COALESCE(f.reference_type, '') AS reference_type,
+	COALESCE(l.matched_by::text, '') AS matched_by,
+	COALESCE(TO_CHAR(l.matched_at, 'YYYY-MM-DD"T"HH24:MI:SS'), '') AS matched_at`
+
+const bankLineFrom = ` FROM bank_statement_lines l
+	JOIN bank_statements s ON s.id = l.statement_id
+	LEFT JOIN cash_flow f ON f.id = l.cash_flow_id`
+
+func getBankLine(q sqlx.Queryer, id string, forUpdate bool) (*entity.BankStatementLine, error) {
+	query := `SELECT ` + bankLineColumns + bankLineFrom + ` WHERE l.id = $1`
+	if forUpdate {
+		query += ` FOR UPDATE OF l`
+	}
+
+	line := &entity.BankStatementLine{}
+	err := sqlx.Get(q, line, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("statement line not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement line: %w", err)
+	}
+
+	return line, nil
+}
+
+// bankCandidates returns the unmatched entries of the wallet with the amount and direction of a line,
+// within the given number of days of its date. Entries whose description or document mention the line
+// reference come first, then the closest in time.
+func bankCandidates(q sqlx.Queryer, line *entity.BankStatementLine, days, limit int) ([]entity.CashFlow, error) {
+	transactionType := "income"
+	if line.Amount < 0 {
+		transactionType = "expense"
+	}
+
+	query := `SELECT ` + cashFlowColumns + cashFlowFrom + `
+	          WHERE f.wallet_id = $1 AND f.transaction_type = $2 AND f.amount = $3
+	            AND f.transaction_date >= $4::date - make_interval(days => $5::int)
+	            AND f.transaction_date < $4::date + make_interval(days => $5::int + 1)
+	            AND NOT EXISTS (SELECT 1 FROM bank_statement_lines m WHERE m.cash_flow_id = f.id)
+	          ORDER BY ($6 <> '' AND (f.description ILIKE '%' || $6 || '%' OR f.reference_id::text = $6)) DESC,
+	                   ABS(EXTRACT(EPOCH FROM f.transaction_date - $4::date)), f.transaction_date
+	          LIMIT $7`
+
+	var entries []entity.CashFlow
+	err := sqlx.Select(q, &entries, query, line.WalletID, transactionType, math.Abs(line.Amount), line.Date, days,
+		line.Reference, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find matching cash flow entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// autoMatch suggests for every unmatched line of a statement the best entry of the same amount and
+// direction within three days. Suggestions wait for the owner to confirm them.
+func autoMatch(tx *sqlx.Tx, statementID string) error {
+	var ids []string
+	err := tx.Select(&ids, `SELECT id FROM bank_statement_lines
+	                        WHERE statement_id = $1 AND cash_flow_id IS NULL
+	                        ORDER BY line_date, created_at FOR UPDATE`, statementID)
+	if err != nil {
+		return fmt.Errorf("failed to get unmatched lines: %w", err)
+	}
+
+	for _, id := range ids {
+		line, err := getBankLine(tx, id, false)
+		if err != nil {
+			return err
+		}
+
+		entries, err := bankCandidates(tx, line, 3, 1)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		_, err = tx.Exec(`UPDATE bank_statement_lines
+		                  SET cash_flow_id = $1, confirmed = FALSE, matched_by = NULL, matched_at = NOW()
+		                  WHERE id = $2`, entries[0].ID, id)
+		if err != nil {
+			return fmt.Errorf("failed to match statement line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ImportStatement saves the lines of a statement and matches them. Lines already imported
+// with another statement of the wallet are skipped.
+func (r *bankRepoImpl) ImportStatement(in *entity.BankStatementRequest, lines []entity.BankLineRequest) (*entity.BankStatement, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.Get(&id, `INSERT INTO bank_statements (wallet_id, file_name, format, imported_by)
+	                   VALUES ($1, $2, $3, $4) RETURNING id`, in.WalletID, in.FileName, in.Format, in.ImportedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bank statement: %w", err)
+	}
+
+	query := `INSERT INTO bank_statement_lines (statement_id, line_date, amount, reference, description)
+	          SELECT $1, $2::date, $3, NULLIF($4, ''), NULLIF($5, '')
+	          WHERE NOT EXISTS (
+	              SELECT 1 FROM bank_statement_lines l JOIN bank_statements s ON s.id = l.statement_id
+	              WHERE s.wallet_id = $6 AND l.statement_id <> $1 AND l.line_date = $2::date AND l.amount = $3
+	                AND COALESCE(l.reference, '') = $4 AND COALESCE(l.description, '') = $5)`
+	var duplicates int
+	for _, line := range lines {
+		result, err := tx.Exec(query, id, line.Date, line.Amount, line.Reference, line.Description, in.WalletID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save statement line: %w", err)
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			duplicates++
+		}
+	}
+	if duplicates == len(lines) {
+		return nil, errors.New("all lines of the statement were already imported")
+	}
+
+	if err := autoMatch(tx, id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit bank statement: %w", err)
+	}
+
+	statement, err := r.GetStatement(&entity.BankStatementID{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	statement.Duplicates = duplicates
+
+	return statement, nil
+}
+
+func (r *bankRepoImpl) AutoMatch(in *entity.BankStatementID) (*entity.BankStatement, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := autoMatch(tx, in.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit statement matching: %w", err)
+	}
+
+	return r.GetStatement(in)
+}
+
+func (r *bankRepoImpl) GetStatement(in *entity.BankStatementID) (*entity.BankStatement, error) {
+	statement := &entity.BankStatement{}
+	err := r.db.Get(statement, `SELECT `+bankStatementColumns+bankStatementFrom+`
+	                            WHERE s.id = $1 GROUP BY s.id, w.name`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("bank statement not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank statement: %w", err)
+	}
+
+	return statement, nil
+}
+
+func (r *bankRepoImpl) GetStatements() (*entity.BankStatementList, error) {
+	statements := &entity.BankStatementList{}
+	err := r.db.Select(&statements.Statements, `SELECT `+bankStatementColumns+bankStatementFrom+`
+	                                           GROUP BY s.id, w.name ORDER BY s.created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bank statements: %w", err)
+	}
+
+	return statements, nil
+}
+
+func (r *bankRepoImpl) GetStatementLines(in *entity.BankLineFilter) (*entity.BankLineList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + bankLineColumns + bankLineFrom + ` WHERE 1=1`)
+
+	if in.ID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND l.statement_id = $%d", argIndex))
+		args = append(args, in.ID)
+		argIndex++
+	}
+	switch in.Status {
+	case "unmatched":
+		queryBuilder.WriteString(" AND l.cash_flow_id IS NULL")
+	case "suggested":
+		queryBuilder.WriteString(" AND l.cash_flow_id IS NOT NULL AND NOT l.confirmed")
+	case "matched":
+		queryBuilder.WriteString(" AND l.cash_flow_id IS NOT NULL AND l.confirmed")
+	}
+
+	queryBuilder.WriteString(" ORDER BY l.line_date, l.created_at")
+
+	lines := &entity.BankLineList{}
+	err := r.db.Select(&lines.Lines, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statement lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// GetLineCandidates lists the unmatched entries a line can be matched to by hand, within 30 days of it.
+func (r *bankRepoImpl) GetLineCandidates(in *entity.BankLineID) (*entity.CashFlowList, error) {
+	line, err := getBankLine(r.db, in.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &entity.CashFlowList{}
+	err = r.db.Get(&list.Currency, `SELECT currency FROM wallets WHERE id = $1`, line.WalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet currency: %w", err)
+	}
+
+	list.Entries, err = bankCandidates(r.db, line, 30, 50)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range list.Entries {
+		if entry.TransactionType == "income" {
+			list.Income += entry.Amount
+		} else {
+			list.Expense += entry.Amount
+		}
+	}
+
+	return list, nil
+}
+
+func (r *bankRepoImpl) ConfirmLine(in *entity.BankLineMatch) (*entity.BankStatementLine, error) {
+	result, err := r.db.Exec(`UPDATE bank_statement_lines SET confirmed = TRUE, matched_by = $1, matched_at = NOW()
+	                          WHERE id = $2 AND cash_flow_id IS NOT NULL`, in.UserID, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm statement line: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, errors.New("statement line not found or has no match to confirm")
+	}
+
+	line, err := getBankLine(r.db, in.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// MatchLine matches a line by hand to an entry of the same wallet, direction and amount.
+func (r *bankRepoImpl) MatchLine(in *entity.BankLineMatch) (*entity.BankStatementLine, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	line, err := getBankLine(tx, in.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	if line.Status == "matched" {
+		return nil, errors.New("statement line is already matched, unmatch it first")
+	}
+
+	var walletID, transactionType string
+	var amount float64
+	var taken bool
+	err = tx.QueryRowx(`SELECT wallet_id, transaction_type, amount,
+	                           EXISTS (SELECT 1 FROM bank_statement_lines WHERE cash_flow_id = f.id AND id <> $2)
+	                    FROM cash_flow f WHERE id = $1`, in.CashFlowID, in.ID).
+		Scan(&walletID, &transactionType, &amount, &taken)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("cash flow entry not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cash flow entry: %w", err)
+	}
+
+	switch {
+	case walletID != line.WalletID:
+		return nil, errors.New("cash flow entry belongs to another wallet")
+	case (transactionType == "income") != (line.Amount > 0):
+		return nil, errors.New("cash flow entry goes in the other direction")
+	case math.Round(amount*100) != math.Round(math.Abs(line.Amount)*100):
+		return nil, fmt.Errorf("cash flow entry amount %.2f differs from the line amount %.2f", amount, math.Abs(line.Amount))
+	case taken:
+		return nil, errors.New("cash flow entry is already matched to another line")
+	}
+
+	_, err = tx.Exec(`UPDATE bank_statement_lines
+	                  SET cash_flow_id = $1, confirmed = TRUE, matched_by = $2, matched_at = NOW()
+	                  WHERE id = $3`, in.CashFlowID, in.UserID, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match statement line: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit statement line match: %w", err)
+	}
+
+	line, err = getBankLine(r.db, in.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+func (r *bankRepoImpl) UnmatchLine(in *entity.BankLineID) (*entity.BankStatementLine, error) {
+	result, err := r.db.Exec(`UPDATE bank_statement_lines
+	                          SET cash_flow_id = NULL, confirmed = FALSE, matched_by = NULL, matched_at = NULL
+	                          WHERE id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmatch statement line: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, errors.New("statement line not found")
+	}
+
+	line, err := getBankLine(r.db, in.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// CreateLineCashFlow records an unmatched line as a manual entry of the statement wallet on the line date.
+func (r *bankRepoImpl) CreateLineCashFlow(in *entity.BankLineCashFlow) (*entity.BankStatementLine, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	line, err := getBankLine(tx, in.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	if line.CashFlowID != "" {
+		return nil, errors.New("statement line is already matched")
+	}
+
+	entry := &entity.CashFlowRequest{
+		UserID:          in.UserID,
+		Amount:          math.Abs(line.Amount),
+		TransactionType: "income",
+		CategoryID:      in.CategoryID,
+		Description:     in.Description,
+		WalletID:        line.WalletID,
+		TransactionDate: line.Date,
+	}
+	if line.Amount < 0 {
+		entry.TransactionType = "expense"
+	}
+	if entry.Description == "" {
+		entry.Description = strings.TrimSpace(line.Reference + " " + line.Description)
+	}
+
+	id, err := insertCashFlow(tx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCashEntry(tx, id); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`UPDATE bank_statement_lines
+	                  SET cash_flow_id = $1, confirmed = TRUE, matched_by = $2, matched_at = NOW()
+	                  WHERE id = $3`, id, in.UserID, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match statement line: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit statement line entry: %w", err)
+	}
+
+	line, err = getBankLine(r.db, in.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
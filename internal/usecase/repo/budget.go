@@ -0,0 +1,254 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strings"
+)
+
+type budgetsRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewBudgetsRepo(db *sqlx.DB) usecase.BudgetsRepo {
+	return &budgetsRepoImpl{db: db}
+}
+
+const budgetColumns = `b.id, b.category_id, c.name AS category_name, TO_CHAR(b.period, 'YYYY-MM') AS period,
+	b.amount, b.created_at`
+
+// budgetLines compares budgets with the expenses of their category and month, in the base currency.
+const budgetLines = `SELECT b.id AS budget_id, b.category_id, c.name AS category_name,
+	       TO_CHAR(b.period, 'YYYY-MM') AS period, b.amount AS budget, a.actual,
+	       b.amount - a.actual AS remaining,
+	       CASE WHEN b.amount > 0 THEN ROUND(a.actual / b.amount, 4) ELSE 0 END AS utilization,
+	       CASE WHEN a.actual >= b.amount THEN 'over'
+	            WHEN a.actual >= b.amount * 0.8 THEN 'near'
+	            ELSE 'ok' END AS status
+	FROM budgets b
+	JOIN cash_category c ON c.id = b.category_id
+	JOIN LATERAL (
+	    SELECT COALESCE(ROUND(SUM(f.amount * f.exchange_rate), 2), 0) AS actual
+	    FROM cash_flow f
+	    WHERE f.category_id = b.category_id AND f.transaction_type = 'expense'
+	      AND f.transaction_date >= b.period AND f.transaction_date < b.period + INTERVAL '1 month'
+	) a ON TRUE`
+
+// checkBudgetCategory allows categories that can hold expenses, except transfers between wallets.
+func checkBudgetCategory(q sqlx.Queryer, categoryID string) error {
+	var categoryType, code string
+	err := q.QueryRowx(`SELECT COALESCE(transaction_type::text, ''), COALESCE(code, '') FROM cash_category WHERE id = $1`,
+		categoryID).Scan(&categoryType, &code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("cash category not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check cash category: %w", err)
+	}
+
+	if categoryType == "income" || code == "transfer" {
+		return errors.New("budgets can only be set for expense categories")
+	}
+
+	return nil
+}
+
+func (r *budgetsRepoImpl) getBudget(id string) (*entity.Budget, error) {
+	budget := &entity.Budget{}
+	err := r.db.Get(budget, `SELECT `+budgetColumns+` FROM budgets b JOIN cash_category c ON c.id = b.category_id
+	                         WHERE b.id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("budget not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+func (r *budgetsRepoImpl) CreateBudget(in *entity.BudgetRequest) (*entity.Budget, error) {
+	if err := checkBudgetCategory(r.db, in.CategoryID); err != nil {
+		return nil, err
+	}
+
+	var id string
+	query := `INSERT INTO budgets (category_id, period, amount) VALUES ($1, ($2 || '-01')::date, $3)
+	          ON CONFLICT (category_id, period) DO UPDATE SET amount = EXCLUDED.amount
+	          RETURNING id`
+	err := r.db.Get(&id, query, in.CategoryID, in.Period, in.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	return r.getBudget(id)
+}
+
+func (r *budgetsRepoImpl) UpdateBudget(in *entity.BudgetRequest) (*entity.Budget, error) {
+	result, err := r.db.Exec(`UPDATE budgets SET amount = $1 WHERE id = $2`, in.Amount, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update budget: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, errors.New("budget not found")
+	}
+
+	return r.getBudget(in.ID)
+}
+
+func (r *budgetsRepoImpl) DeleteBudget(in *entity.BudgetID) (*entity.Message, error) {
+	result, err := r.db.Exec(`DELETE FROM budgets WHERE id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete budget: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, errors.New("budget not found")
+	}
+
+	return &entity.Message{Message: "Budget deleted successfully"}, nil
+}
+
+func (r *budgetsRepoImpl) GetBudgets(in *entity.BudgetFilter) (*entity.BudgetList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + budgetColumns + ` FROM budgets b JOIN cash_category c ON c.id = b.category_id
+		WHERE 1=1`)
+
+	if in.Period != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND b.period = ($%d || '-01')::date", argIndex))
+		args = append(args, in.Period)
+		argIndex++
+	}
+	if in.CategoryID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND b.category_id = $%d", argIndex))
+		args = append(args, in.CategoryID)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY b.period DESC, c.name")
+
+	budgets := &entity.BudgetList{}
+	err := r.db.Select(&budgets.Budgets, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	return budgets, nil
+}
+
+func (r *budgetsRepoImpl) GetBudgetReport(in *entity.BudgetFilter) (*entity.BudgetReport, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.BudgetReport{Period: in.Period, Currency: base}
+	query := budgetLines + ` WHERE b.period = ($1 || '-01')::date ORDER BY utilization DESC, c.name`
+	err = r.db.Select(&report.Lines, query, in.Period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build budget report: %w", err)
+	}
+
+	for _, line := range report.Lines {
+		report.TotalBudget += line.Budget
+		report.TotalActual += line.Actual
+	}
+
+	return report, nil
+}
+
+// GetCrossedBudgets returns this month's budgets whose spending passed 80% or 100% with the recipients
+// already alerted at that threshold or above. Only the highest crossed threshold is returned.
+func (r *budgetsRepoImpl) GetCrossedBudgets() ([]entity.BudgetCrossing, error) {
+	var rows []struct {
+		entity.BudgetCrossing
+		SentTo pq.StringArray `db:"sent_to"`
+	}
+	query := `WITH lines AS (` + budgetLines + ` WHERE b.period = DATE_TRUNC('month', CURRENT_DATE)::date),
+	          crossed AS (
+	              SELECT l.*, CASE WHEN l.actual >= l.budget THEN 100 ELSE 80 END AS threshold
+	              FROM lines l
+	              WHERE l.budget > 0 AND l.actual >= l.budget * 0.8
+	          )
+	          SELECT c.*, ARRAY(SELECT DISTINCT a.recipient FROM budget_alerts a
+	                            WHERE a.budget_id = c.budget_id AND a.status = 'sent'
+	                              AND a.threshold >= c.threshold) AS sent_to
+	          FROM crossed c`
+	err := r.db.Select(&rows, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crossed budgets: %w", err)
+	}
+
+	crossings := make([]entity.BudgetCrossing, 0, len(rows))
+	for _, row := range rows {
+		row.BudgetCrossing.SentTo = row.SentTo
+		crossings = append(crossings, row.BudgetCrossing)
+	}
+
+	return crossings, nil
+}
+
+func (r *budgetsRepoImpl) GetOwnerPhones() ([]string, error) {
+	var phones []string
+	err := r.db.Select(&phones, `SELECT phone_number FROM users
+	                             WHERE role = 'admin' AND COALESCE(phone_number, '') <> ''`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner phones: %w", err)
+	}
+
+	return phones, nil
+}
+
+func (r *budgetsRepoImpl) LogBudgetAlert(in *entity.BudgetAlert) error {
+	query := `INSERT INTO budget_alerts (budget_id, threshold, spent, channel, recipient, status, error)
+	          VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))`
+	_, err := r.db.Exec(query, in.BudgetID, in.Threshold, in.Spent, in.Channel, in.Recipient, in.Status, in.Error)
+	if err != nil {
+		return fmt.Errorf("failed to log budget alert: %w", err)
+	}
+
+	return nil
+}
+
+func (r *budgetsRepoImpl) GetBudgetAlerts(in *entity.BudgetFilter) (*entity.BudgetAlertList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT a.id, a.budget_id, c.name AS category_name, TO_CHAR(b.period, 'YYYY-MM') AS period,
+		       a.threshold, a.spent, a.channel, a.recipient, a.status, COALESCE(a.error, '') AS error, a.sent_at
+		FROM budget_alerts a
+		JOIN budgets b ON b.id = a.budget_id
+		JOIN cash_category c ON c.id = b.category_id
+		WHERE 1=1`)
+
+	if in.Period != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND b.period = ($%d || '-01')::date", argIndex))
+		args = append(args, in.Period)
+		argIndex++
+	}
+	if in.CategoryID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND b.category_id = $%d", argIndex))
+		args = append(args, in.CategoryID)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY a.sent_at DESC")
+
+	alerts := &entity.BudgetAlertList{}
+	err := r.db.Select(&alerts.Alerts, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budget alerts: %w", err)
+	}
+
+	return alerts, nil
+}
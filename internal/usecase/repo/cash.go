@@ -0,0 +1,513 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"strings"
+)
+
+type cashRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewCashRepo(db *sqlx.DB) usecase.CashRepo {
+	return &cashRepoImpl{db: db}
+}
+
+const cashCategoryColumns = `id, name, COALESCE(code, '') AS code, COALESCE(transaction_type::text, '') AS transaction_type`
+
+const cashFlowColumns = `f.id, f.user_id, f.transaction_date, f.amount, f.transaction_type, f.category_id,
+	c.name AS category_name, COALESCE(f.description, '') AS description,
+	COALESCE(f.payment_method, 'uzs') AS payment_method, f.wallet_id, w.name AS wallet_name,
+	w.currency, f.exchange_rate,
+	COALESCE(f.shift_id::text, '') AS shift_id,
+	COALESCE(f.reference_type, '') AS reference_type, COALESCE(f.reference_id::text, '') AS reference_id`
+
+const cashFlowFrom = ` FROM cash_flow f
+	JOIN cash_category c ON c.id = f.category_id
+	JOIN wallets w ON w.id = f.wallet_id`
+
+// moveWalletBalance applies an entry to the running balance of its wallet.
+func moveWalletBalance(tx *sqlx.Tx, walletID, transactionType string, amount float64) error {
+	if transactionType != "income" {
+		amount = -amount
+	}
+
+	_, err := tx.Exec(`UPDATE wallets SET balance = balance + $1 WHERE id = $2`, amount, walletID)
+	if err != nil {
+		return fmt.Errorf("failed to update wallet balance: %w", err)
+	}
+
+	return nil
+}
+
+// insertCashFlow records an entry in the given wallet. Without one, money goes to the drawer of the
+// user's open shift when the payment method matches, otherwise to the default wallet of the method.
+// Amounts in another currency than the wallet's are converted at today's rates.
+func insertCashFlow(tx *sqlx.Tx, in *entity.CashFlowRequest) (string, error) {
+	var walletID, walletCurrency string
+	var shiftID sql.NullString
+	query := `WITH shift AS (
+	              SELECT s.id, r.wallet_id
+	              FROM shifts s JOIN registers r ON r.id = s.register_id
+	              WHERE s.user_id = $1 AND s.status = 'open'
+	          )
+	          SELECT w.id, w.currency, (SELECT id FROM shift)
+	          FROM wallets w
+	          WHERE w.id = COALESCE(NULLIF($2, '')::uuid,
+	                                (SELECT sw.id FROM shift JOIN wallets sw ON sw.id = shift.wallet_id
+	                                 WHERE sw.payment_method::text = $3),
+	                                (SELECT d.id FROM wallets d WHERE d.is_default AND d.payment_method::text = $3))`
+	err := tx.QueryRowx(query, in.UserID, in.WalletID, in.PaymentMethod).Scan(&walletID, &walletCurrency, &shiftID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("no wallet for payment method %q", in.PaymentMethod)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	_, walletRate, err := resolveCurrency(tx, walletCurrency)
+	if err != nil {
+		return "", err
+	}
+
+	amount := in.Amount
+	if in.Currency != "" && in.Currency != walletCurrency {
+		_, rate, err := resolveCurrency(tx, in.Currency)
+		if err != nil {
+			return "", err
+		}
+		amount = convertAmount(amount, rate, walletRate)
+	}
+
+	var id string
+	query = `INSERT INTO cash_flow (user_id, transaction_date, amount, transaction_type, category_id, description,
+	                                payment_method, wallet_id, exchange_rate, reference_type, reference_id, shift_id)
+	         SELECT $1, COALESCE(NULLIF($2, '')::timestamp, NOW()), $3, $4, $5, $6,
+	                w.payment_method, w.id, $7, NULLIF($8, ''), NULLIF($9, '')::uuid, $10
+	         FROM wallets w WHERE w.id = $11
+	         RETURNING id`
+	err = tx.Get(&id, query, in.UserID, in.TransactionDate, amount, in.TransactionType, in.CategoryID,
+		in.Description, walletRate, in.ReferenceType, in.ReferenceID, shiftID, walletID)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cash flow entry: %w", err)
+	}
+
+	if err := moveWalletBalance(tx, walletID, in.TransactionType, amount); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// postCashFlow records an automatic cash flow entry in the system category with the given code.
+func postCashFlow(tx *sqlx.Tx, code string, in *entity.CashFlowRequest) error {
+	var categoryType string
+	err := tx.QueryRowx(`SELECT id, COALESCE(transaction_type::text, '') FROM cash_category WHERE code = $1`, code).
+		Scan(&in.CategoryID, &categoryType)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("cash category %q not found", code)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get cash category: %w", err)
+	}
+
+	// Transfers have no fixed type, the caller decides the direction
+	if categoryType != "" {
+		in.TransactionType = categoryType
+	}
+
+	_, err = insertCashFlow(tx, in)
+	return err
+}
+
+// removeCashFlows deletes the entries matching the condition and takes them back out of wallet balances.
+func removeCashFlows(tx *sqlx.Tx, condition string, args ...interface{}) error {
+	query := `WITH removed AS (
+	              DELETE FROM cash_flow WHERE ` + condition + `
+	              RETURNING wallet_id, CASE WHEN transaction_type = 'income' THEN amount ELSE -amount END AS delta
+	          )
+	          UPDATE wallets w
+	          SET balance = w.balance - r.delta
+	          FROM (SELECT wallet_id, SUM(delta) AS delta FROM removed GROUP BY wallet_id) r
+	          WHERE w.id = r.wallet_id`
+	_, err := tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to remove cash flow entries: %w", err)
+	}
+
+	return nil
+}
+
+// checkCashEntry makes sure a manual entry uses a user category that matches its transaction type.
+func checkCashEntry(tx *sqlx.Tx, id string) error {
+	var entryType, categoryType, code string
+	query := `SELECT f.transaction_type, COALESCE(c.transaction_type::text, ''), COALESCE(c.code, '')
+	          FROM cash_flow f JOIN cash_category c ON c.id = f.category_id
+	          WHERE f.id = $1`
+	err := tx.QueryRowx(query, id).Scan(&entryType, &categoryType, &code)
+	if err != nil {
+		return fmt.Errorf("failed to check cash flow entry: %w", err)
+	}
+
+	if code != "" {
+		return fmt.Errorf("category %q is reserved for automatic postings", code)
+	}
+	if categoryType != "" && categoryType != entryType {
+		return fmt.Errorf("category is for %s entries, got %s", categoryType, entryType)
+	}
+
+	return nil
+}
+
+func (r *cashRepoImpl) CreateCategory(in *entity.CashCategoryRequest) (*entity.CashCategory, error) {
+	category := &entity.CashCategory{}
+	query := `INSERT INTO cash_category (name, transaction_type)
+	          VALUES ($1, NULLIF($2, '')::transaction_type) RETURNING ` + cashCategoryColumns
+	err := r.db.QueryRowx(query, in.Name, in.TransactionType).StructScan(category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cash category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (r *cashRepoImpl) UpdateCategory(in *entity.CashCategoryRequest) (*entity.CashCategory, error) {
+	category := &entity.CashCategory{}
+	query := `UPDATE cash_category
+	          SET name = COALESCE(NULLIF($1, ''), name),
+	              transaction_type = COALESCE(NULLIF($2, '')::transaction_type, transaction_type)
+	          WHERE id = $3 AND code IS NULL
+	          RETURNING ` + cashCategoryColumns
+	err := r.db.QueryRowx(query, in.Name, in.TransactionType, in.ID).StructScan(category)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("cash category not found or is a system category")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cash category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (r *cashRepoImpl) DeleteCategory(in *entity.CashCategoryID) (*entity.Message, error) {
+	var used bool
+	err := r.db.Get(&used, `SELECT EXISTS (SELECT 1 FROM cash_flow WHERE category_id = $1)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check cash category usage: %w", err)
+	}
+	if used {
+		return nil, errors.New("cash category has entries and cannot be deleted")
+	}
+
+	result, err := r.db.Exec(`DELETE FROM cash_category WHERE id = $1 AND code IS NULL`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete cash category: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, errors.New("cash category not found or is a system category")
+	}
+
+	return &entity.Message{Message: "Cash category deleted successfully"}, nil
+}
+
+func (r *cashRepoImpl) GetCategories() (*entity.CashCategoryList, error) {
+	categories := &entity.CashCategoryList{}
+	query := `SELECT ` + cashCategoryColumns + ` FROM cash_category ORDER BY code NULLS LAST, name`
+	err := r.db.Select(&categories.Categories, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cash categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *cashRepoImpl) CreateCashFlow(in *entity.CashFlowRequest) (*entity.CashFlow, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	id, err := insertCashFlow(tx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCashEntry(tx, id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cash flow entry: %w", err)
+	}
+
+	return r.GetCashFlow(&entity.CashFlowID{ID: id})
+}
+
+// UpdateCashFlow changes a manual entry. An entry moved to a wallet in another currency keeps its value:
+// its amount is converted from the rate it was posted at to today's rate of the new wallet's currency.
+// A new amount is taken in the given currency, the wallet's by default, as when the entry is created.
+func (r *cashRepoImpl) UpdateCashFlow(in *entity.CashFlowRequest) (*entity.CashFlow, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Automatic postings follow their documents and cannot be edited by hand
+	var old entity.CashFlow
+	err = tx.Get(&old, `SELECT f.wallet_id, f.transaction_type, f.amount, f.exchange_rate, w.currency
+	                    FROM cash_flow f JOIN wallets w ON w.id = f.wallet_id
+	                    WHERE f.id = $1 AND f.reference_type IS NULL FOR UPDATE OF f`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("cash flow entry not found or was posted automatically")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cash flow entry: %w", err)
+	}
+
+	walletID, walletCurrency, walletRate := old.WalletID, old.Currency, old.ExchangeRate
+	if in.WalletID != "" && in.WalletID != old.WalletID {
+		walletID = in.WalletID
+		err = tx.Get(&walletCurrency, `SELECT currency FROM wallets WHERE id = $1`, walletID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("wallet not found")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wallet: %w", err)
+		}
+		if walletCurrency != old.Currency {
+			if _, walletRate, err = resolveCurrency(tx, walletCurrency); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	amount := old.Amount
+	switch {
+	case in.Amount != 0 && in.Currency != "" && in.Currency != walletCurrency:
+		_, rate, err := resolveCurrency(tx, in.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if _, walletRate, err = resolveCurrency(tx, walletCurrency); err != nil {
+			return nil, err
+		}
+		amount = convertAmount(in.Amount, rate, walletRate)
+	case in.Amount != 0:
+		amount = in.Amount
+	case walletCurrency != old.Currency:
+		amount = convertAmount(old.Amount, old.ExchangeRate, walletRate)
+	}
+
+	updates := []string{"amount = $1", "exchange_rate = $2"}
+	args := []interface{}{amount, walletRate}
+	argIndex := 3
+
+	if in.TransactionType != "" {
+		updates = append(updates, fmt.Sprintf("transaction_type = $%d", argIndex))
+		args = append(args, in.TransactionType)
+		argIndex++
+	}
+	if in.CategoryID != "" {
+		updates = append(updates, fmt.Sprintf("category_id = $%d", argIndex))
+		args = append(args, in.CategoryID)
+		argIndex++
+	}
+	if in.Description != "" {
+		updates = append(updates, fmt.Sprintf("description = $%d", argIndex))
+		args = append(args, in.Description)
+		argIndex++
+	}
+	if walletID != old.WalletID {
+		updates = append(updates, fmt.Sprintf("wallet_id = $%d", argIndex),
+			fmt.Sprintf("payment_method = (SELECT payment_method FROM wallets WHERE id = $%d)", argIndex))
+		args = append(args, walletID)
+		argIndex++
+	}
+	if in.TransactionDate != "" {
+		updates = append(updates, fmt.Sprintf("transaction_date = $%d", argIndex))
+		args = append(args, in.TransactionDate)
+		argIndex++
+	}
+
+	if len(updates) == 2 && in.Amount == 0 && in.WalletID == "" {
+		return nil, errors.New("no fields to update")
+	}
+
+	query := `UPDATE cash_flow SET ` + strings.Join(updates, ", ") +
+		fmt.Sprintf(" WHERE id = $%d RETURNING wallet_id, transaction_type, amount", argIndex)
+	args = append(args, in.ID)
+
+	var updated entity.CashFlow
+	err = tx.Get(&updated, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cash flow entry: %w", err)
+	}
+
+	if err := moveWalletBalance(tx, old.WalletID, old.TransactionType, -old.Amount); err != nil {
+		return nil, err
+	}
+	if err := moveWalletBalance(tx, updated.WalletID, updated.TransactionType, updated.Amount); err != nil {
+		return nil, err
+	}
+
+	if err := checkCashEntry(tx, in.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cash flow entry: %w", err)
+	}
+
+	return r.GetCashFlow(&entity.CashFlowID{ID: in.ID})
+}
+
+func (r *cashRepoImpl) DeleteCashFlow(in *entity.CashFlowID) (*entity.Message, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var manual bool
+	err = tx.Get(&manual, `SELECT EXISTS (SELECT 1 FROM cash_flow WHERE id = $1 AND reference_type IS NULL)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cash flow entry: %w", err)
+	}
+	if !manual {
+		return nil, errors.New("cash flow entry not found or was posted automatically")
+	}
+
+	if err := removeCashFlows(tx, "id = $1", in.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cash flow deletion: %w", err)
+	}
+
+	return &entity.Message{Message: "Cash flow entry deleted successfully"}, nil
+}
+
+func (r *cashRepoImpl) GetCashFlow(in *entity.CashFlowID) (*entity.CashFlow, error) {
+	entry := &entity.CashFlow{}
+	query := `SELECT ` + cashFlowColumns + cashFlowFrom + ` WHERE f.id = $1`
+	err := r.db.Get(entry, query, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cash flow entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// cashFlowWhere builds the filter shared by the cash flow list and balance.
+func cashFlowWhere(in *entity.CashFlowFilter) (string, []interface{}) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(" WHERE 1=1")
+
+	if in.From != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND f.transaction_date >= $%d::date", argIndex))
+		args = append(args, in.From)
+		argIndex++
+	}
+	if in.To != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND f.transaction_date < $%d::date + 1", argIndex))
+		args = append(args, in.To)
+		argIndex++
+	}
+	if in.TransactionType != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND f.transaction_type = $%d", argIndex))
+		args = append(args, in.TransactionType)
+		argIndex++
+	}
+	if in.CategoryID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND f.category_id = $%d", argIndex))
+		args = append(args, in.CategoryID)
+		argIndex++
+	}
+	if in.PaymentMethod != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND f.payment_method = $%d", argIndex))
+		args = append(args, in.PaymentMethod)
+		argIndex++
+	}
+	if in.WalletID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND f.wallet_id = $%d", argIndex))
+		args = append(args, in.WalletID)
+		argIndex++
+	}
+
+	return queryBuilder.String(), args
+}
+
+func (r *cashRepoImpl) GetCashFlowList(in *entity.CashFlowFilter) (*entity.CashFlowList, error) {
+	where, args := cashFlowWhere(in)
+	query := `SELECT ` + cashFlowColumns + cashFlowFrom + where + ` ORDER BY f.transaction_date DESC`
+
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &entity.CashFlowList{Currency: base}
+	err = r.db.Select(&list.Entries, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cash flow: %w", err)
+	}
+
+	// Totals are in the base currency at the rates fixed on each entry
+	for _, e := range list.Entries {
+		if e.TransactionType == "income" {
+			list.Income += e.Amount * e.ExchangeRate
+		} else {
+			list.Expense += e.Amount * e.ExchangeRate
+		}
+	}
+	list.Income = math.Round(list.Income*100) / 100
+	list.Expense = math.Round(list.Expense*100) / 100
+
+	return list, nil
+}
+
+func (r *cashRepoImpl) GetCashBalance(in *entity.CashFlowFilter) (*entity.CashBalance, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := cashFlowWhere(in)
+	query := `SELECT COALESCE(f.payment_method, 'uzs') AS payment_method, w.currency,
+	                 COALESCE(SUM(f.amount) FILTER (WHERE f.transaction_type = 'income'), 0) AS income,
+	                 COALESCE(SUM(f.amount) FILTER (WHERE f.transaction_type = 'expense'), 0) AS expense,
+	                 COALESCE(SUM(CASE WHEN f.transaction_type = 'income' THEN f.amount ELSE -f.amount END), 0) AS balance,
+	                 ROUND(COALESCE(SUM(f.amount * f.exchange_rate) FILTER (WHERE f.transaction_type = 'income'), 0), 2) AS base_income,
+	                 ROUND(COALESCE(SUM(f.amount * f.exchange_rate) FILTER (WHERE f.transaction_type = 'expense'), 0), 2) AS base_expense,
+	                 ROUND(COALESCE(SUM(CASE WHEN f.transaction_type = 'income' THEN f.amount ELSE -f.amount END
+	                                    * f.exchange_rate), 0), 2) AS base_balance
+	          FROM cash_flow f JOIN wallets w ON w.id = f.wallet_id` + where + `
+	          GROUP BY 1, 2 ORDER BY 1, 2`
+
+	balance := &entity.CashBalance{Currency: base}
+	err = r.db.Select(&balance.ByMethod, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cash balance: %w", err)
+	}
+
+	for _, m := range balance.ByMethod {
+		balance.Income += m.BaseIncome
+		balance.Expense += m.BaseExpense
+		balance.Balance += m.BaseBalance
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,176 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+const clientColumns = `id, full_name, COALESCE(address, '') AS address, COALESCE(phone, '') AS phone,
+	COALESCE(language, 'uz') AS language, COALESCE(telegram_chat_id, '') AS telegram_chat_id, credit_limit,
+	COALESCE(payment_terms_days, 0) AS payment_terms_days, store_credit, created_at`
+
+type clientsRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewClientsRepo(db *sqlx.DB) usecase.ClientsRepo {
+	return &clientsRepoImpl{db: db}
+}
+
+func (r *clientsRepoImpl) GetClient(in *entity.ClientID) (*entity.Client, error) {
+	client := &entity.Client{}
+	err := r.db.Get(client, `SELECT `+clientColumns+` FROM clients WHERE id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	return client, nil
+}
+
+func (r *clientsRepoImpl) UpdateClientContact(in *entity.ClientContact) (*entity.Client, error) {
+	client := &entity.Client{}
+	query := `UPDATE clients
+	          SET phone            = COALESCE(NULLIF($1, ''), phone),
+	              language         = COALESCE(NULLIF($2, ''), language),
+	              telegram_chat_id = COALESCE(NULLIF($3, ''), telegram_chat_id)
+	          WHERE id = $4
+	          RETURNING ` + clientColumns
+	err := r.db.QueryRowx(query, in.Phone, in.Language, in.TelegramChatID, in.ID).StructScan(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update client contact: %w", err)
+	}
+
+	return client, nil
+}
+
+func (r *clientsRepoImpl) UpdateCreditLimit(in *entity.CreditLimitUpdate) (*entity.Client, error) {
+	client := &entity.Client{}
+	query := `UPDATE clients SET credit_limit = $1 WHERE id = $2 RETURNING ` + clientColumns
+	err := r.db.QueryRowx(query, in.CreditLimit, in.ID).StructScan(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update credit limit: %w", err)
+	}
+
+	return client, nil
+}
+
+func (r *clientsRepoImpl) UpdatePaymentTerms(in *entity.PaymentTerms) (*entity.Client, error) {
+	client := &entity.Client{}
+	query := `UPDATE clients SET payment_terms_days = $1 WHERE id = $2 RETURNING ` + clientColumns
+	err := r.db.QueryRowx(query, in.PaymentTermsDays, in.ID).StructScan(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment terms: %w", err)
+	}
+
+	return client, nil
+}
+
+func (r *clientsRepoImpl) GetCreditOverrides(in *entity.ClientID) (*entity.CreditOverrideList, error) {
+	var overrides []entity.CreditOverride
+	query := `SELECT id, client_id, sale_id, approved_by, reason, credit_limit, outstanding, sale_debt, created_at
+	          FROM credit_limit_overrides WHERE client_id = $1 ORDER BY created_at DESC`
+	err := r.db.Select(&overrides, query, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credit overrides: %w", err)
+	}
+
+	return &entity.CreditOverrideList{Overrides: overrides}, nil
+}
+
+func (r *clientsRepoImpl) GetCreditReport(in *entity.CreditReportFilter) (*entity.CreditReport, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limits and debts are compared in the base currency
+	report := &entity.CreditReport{Threshold: in.Threshold, Currency: base}
+	query := `
+		SELECT c.id AS client_id, c.full_name, c.credit_limit, o.outstanding,
+		       CASE WHEN c.credit_limit > 0 THEN o.outstanding / c.credit_limit ELSE 1 END AS utilization,
+		       CASE WHEN o.outstanding > c.credit_limit THEN 'over' ELSE 'near' END AS status
+		FROM clients c
+		JOIN LATERAL (
+			SELECT COALESCE(ROUND(SUM(d.amount_unpaid * s.exchange_rate), 2), 0) AS outstanding
+			FROM debts d JOIN sales s ON s.id = d.order_id
+			WHERE s.client_id = c.id AND NOT d.is_fully_paid
+		) o ON TRUE
+		WHERE c.credit_limit IS NOT NULL
+		  AND o.outstanding > 0
+		  AND o.outstanding >= c.credit_limit * $1
+		ORDER BY utilization DESC`
+	err = r.db.Select(&report.Clients, query, in.Threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build credit report: %w", err)
+	}
+
+	return report, nil
+}
+
+// checkCreditLimit locks the client row and verifies that a new debt fits into the credit limit.
+// Exceeding the limit requires an owner override with a reason, which is returned for recording.
+func checkCreditLimit(tx *sqlx.Tx, in *entity.SalesTotal, saleDebt float64) (*entity.CreditOverride, error) {
+	var limit sql.NullFloat64
+	err := tx.Get(&limit, `SELECT credit_limit FROM clients WHERE id = $1 FOR UPDATE`, in.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client credit limit: %w", err)
+	}
+	if !limit.Valid {
+		return nil, nil
+	}
+
+	var outstanding float64
+	err = tx.Get(&outstanding, `SELECT COALESCE(ROUND(SUM(d.amount_unpaid * s.exchange_rate), 2), 0)
+	                            FROM debts d JOIN sales s ON s.id = d.order_id
+	                            WHERE s.client_id = $1 AND NOT d.is_fully_paid`, in.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client outstanding debt: %w", err)
+	}
+
+	if outstanding+saleDebt <= limit.Float64 {
+		return nil, nil
+	}
+
+	if in.OverrideBy == "" || in.OverrideReason == "" {
+		return nil, &entity.CreditLimitError{
+			ClientID:    in.ClientID,
+			CreditLimit: limit.Float64,
+			Outstanding: outstanding,
+			SaleDebt:    saleDebt,
+		}
+	}
+
+	var role string
+	err = tx.Get(&role, `SELECT role FROM users WHERE user_id = $1`, in.OverrideBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overriding user: %w", err)
+	}
+	if role != "admin" {
+		return nil, errors.New("only an owner can override the credit limit")
+	}
+
+	return &entity.CreditOverride{
+		ClientID:    in.ClientID,
+		ApprovedBy:  in.OverrideBy,
+		Reason:      in.OverrideReason,
+		CreditLimit: limit.Float64,
+		Outstanding: outstanding,
+		SaleDebt:    saleDebt,
+	}, nil
+}
+
+func recordCreditOverride(tx *sqlx.Tx, in *entity.CreditOverride) error {
+	_, err := tx.Exec(`INSERT INTO credit_limit_overrides
+	                   (client_id, sale_id, approved_by, reason, credit_limit, outstanding, sale_debt)
+	                   VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		in.ClientID, in.SaleID, in.ApprovedBy, in.Reason, in.CreditLimit, in.Outstanding, in.SaleDebt)
+	if err != nil {
+		return fmt.Errorf("failed to record credit override: %w", err)
+	}
+
+	return nil
+}
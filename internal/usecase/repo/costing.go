@@ -0,0 +1,245 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+)
+
+const costLayerColumns = `id, product_id, COALESCE(purchase_item_id::text, '') AS purchase_item_id, quantity, remaining,
+	unit_cost, created_at`
+
+// costingMethod returns how sold goods are costed: fifo or average.
+func costingMethod(q sqlx.Queryer) (string, error) {
+	var method string
+	err := sqlx.Get(q, &method, `SELECT value FROM settings WHERE key = 'costing_method'`)
+	if err != nil {
+		return "", fmt.Errorf("failed to get costing method: %w", err)
+	}
+
+	return method, nil
+}
+
+// moveAverageCost adds a quantity at a unit cost to the moving average cost of a product, or takes it out
+// when the quantity is negative. It must run before the cost layers themselves change.
+func moveAverageCost(tx *sqlx.Tx, productID string, quantity int, value float64) error {
+	query := `UPDATE products p
+	          SET average_cost = ROUND((l.on_hand * p.average_cost + $3) / (l.on_hand + $2), 4)
+	          FROM (SELECT COALESCE(SUM(remaining), 0) AS on_hand FROM cost_layers WHERE product_id = $1) l
+	          WHERE p.id = $1 AND l.on_hand + $2 > 0`
+	_, err := tx.Exec(query, productID, quantity, value)
+	if err != nil {
+		return fmt.Errorf("failed to update average cost: %w", err)
+	}
+
+	return nil
+}
+
+// addCostLayer receives goods at a unit cost in the base currency.
+func addCostLayer(tx *sqlx.Tx, productID, purchaseItemID string, quantity int, unitCost float64) error {
+	if err := moveAverageCost(tx, productID, quantity, float64(quantity)*unitCost); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`INSERT INTO cost_layers (product_id, purchase_item_id, quantity, remaining, unit_cost)
+	                   VALUES ($1, NULLIF($2, '')::uuid, $3, $3, $4)`, productID, purchaseItemID, quantity, unitCost)
+	if err != nil {
+		return fmt.Errorf("failed to add cost layer: %w", err)
+	}
+
+	return nil
+}
+
+// consumeCostLayers takes a sold line out of the oldest cost layers of its product and stores its cost.
+// With FIFO each layer is costed at its own price, with the average method everything is costed at the
+// average cost of the product. Quantities sold beyond the recorded layers are costed at the average.
+func consumeCostLayers(tx *sqlx.Tx, method string, item *entity.SalesItem) error {
+	var average float64
+	err := tx.Get(&average, `SELECT average_cost FROM products WHERE id = $1 FOR UPDATE`, item.ProductID)
+	if err != nil {
+		return fmt.Errorf("failed to get product cost: %w", err)
+	}
+
+	var layers []entity.CostLayer
+	err = tx.Select(&layers, `SELECT `+costLayerColumns+` FROM cost_layers
+	                          WHERE product_id = $1 AND remaining > 0
+	                          ORDER BY created_at, id FOR UPDATE`, item.ProductID)
+	if err != nil {
+		return fmt.Errorf("failed to get cost layers: %w", err)
+	}
+
+	consume := `INSERT INTO cost_layer_consumptions (layer_id, sales_item_id, quantity, unit_cost)
+	            VALUES (NULLIF($1, '')::uuid, $2, $3, $4)`
+	left := item.Quantity
+	var cogs float64
+	for _, layer := range layers {
+		if left == 0 {
+			break
+		}
+
+		take := min(left, layer.Remaining)
+		cost := layer.UnitCost
+		if method == "average" {
+			cost = average
+		}
+
+		_, err := tx.Exec(`UPDATE cost_layers SET remaining = remaining - $1 WHERE id = $2`, take, layer.ID)
+		if err != nil {
+			return fmt.Errorf("failed to consume cost layer: %w", err)
+		}
+		if _, err := tx.Exec(consume, layer.ID, item.ID, take, cost); err != nil {
+			return fmt.Errorf("failed to record cost consumption: %w", err)
+		}
+
+		cogs += float64(take) * cost
+		left -= take
+	}
+
+	if left > 0 {
+		if _, err := tx.Exec(consume, "", item.ID, left, average); err != nil {
+			return fmt.Errorf("failed to record cost consumption: %w", err)
+		}
+		cogs += float64(left) * average
+	}
+
+	item.COGS = math.Round(cogs*100) / 100
+	_, err = tx.Exec(`UPDATE sales_items SET cogs = $1 WHERE id = $2`, item.COGS, item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save cost of goods sold: %w", err)
+	}
+
+	return nil
+}
+
+// restoreCostLayers puts the goods of the sales items matching the condition back into the layers they were
+// taken from, at the cost they were sold at. Goods sold beyond the recorded layers come back as new layers.
+func restoreCostLayers(tx *sqlx.Tx, condition string, args ...interface{}) error {
+	consumed := `SELECT c.layer_id, si.product_id, c.quantity, c.unit_cost
+	             FROM cost_layer_consumptions c JOIN sales_items si ON si.id = c.sales_item_id
+	             WHERE ` + condition
+
+	queries := []string{
+		`UPDATE products p
+		 SET average_cost = ROUND((l.on_hand * p.average_cost + c.value) / (l.on_hand + c.quantity), 4)
+		 FROM (SELECT product_id, SUM(quantity) AS quantity, SUM(quantity * unit_cost) AS value
+		       FROM (` + consumed + `) t GROUP BY product_id) c,
+		      LATERAL (SELECT COALESCE(SUM(remaining), 0) AS on_hand FROM cost_layers WHERE product_id = c.product_id) l
+		 WHERE p.id = c.product_id AND l.on_hand + c.quantity > 0`,
+		`UPDATE cost_layers l
+		 SET remaining = l.remaining + c.quantity
+		 FROM (SELECT layer_id, SUM(quantity) AS quantity FROM (` + consumed + `) t
+		       WHERE layer_id IS NOT NULL GROUP BY layer_id) c
+		 WHERE l.id = c.layer_id`,
+		`INSERT INTO cost_layers (product_id, quantity, remaining, unit_cost)
+		 SELECT product_id, quantity, quantity, unit_cost FROM (` + consumed + `) t WHERE layer_id IS NULL`,
+		`DELETE FROM cost_layer_consumptions c USING sales_items si
+		 WHERE si.id = c.sales_item_id AND ` + condition,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed to restore cost layers: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// removePurchaseCostLayers takes the goods of a purchase back out of stock value. A purchase
+// whose goods were already sold cannot be reversed this way.
+func removePurchaseCostLayers(tx *sqlx.Tx, purchaseID string) error {
+	var layers []entity.CostLayer
+	err := tx.Select(&layers, `SELECT `+costLayerColumns+` FROM cost_layers
+	                          WHERE purchase_item_id IN (SELECT id FROM purchase_items WHERE purchase_id = $1)
+	                          FOR UPDATE`, purchaseID)
+	if err != nil {
+		return fmt.Errorf("failed to get purchase cost layers: %w", err)
+	}
+
+	for _, layer := range layers {
+		if layer.Remaining < layer.Quantity {
+			return errors.New("goods of this purchase were already sold, the purchase cannot be deleted")
+		}
+	}
+
+	for _, layer := range layers {
+		if err := moveAverageCost(tx, layer.ProductID, -layer.Quantity, -float64(layer.Quantity)*layer.UnitCost); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM cost_layers WHERE id = $1`, layer.ID); err != nil {
+			return fmt.Errorf("failed to delete cost layer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// returnCostLayer takes goods sent back to the supplier out of the cost layer of their purchase line.
+// Lines received before cost layers were kept have no layer and leave the cost untouched.
+func returnCostLayer(tx *sqlx.Tx, purchaseItemID string, quantity int) error {
+	layer := &entity.CostLayer{}
+	err := tx.Get(layer, `SELECT `+costLayerColumns+` FROM cost_layers WHERE purchase_item_id = $1 FOR UPDATE`,
+		purchaseItemID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get purchase cost layer: %w", err)
+	}
+
+	if layer.Remaining < quantity {
+		return fmt.Errorf("only %d of the purchased goods are still in stock, cannot return %d", layer.Remaining, quantity)
+	}
+
+	if err := moveAverageCost(tx, layer.ProductID, -quantity, -float64(quantity)*layer.UnitCost); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE cost_layers SET quantity = quantity - $1, remaining = remaining - $1 WHERE id = $2`,
+		quantity, layer.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update cost layer: %w", err)
+	}
+
+	return nil
+}
+
+// shrinkCostLayers takes goods that left stock without being sold, e.g. lost or written off, out of the
+// oldest cost layers of their product and returns their value at the cost of those layers, quantities
+// beyond the recorded layers at the average cost. Taking goods out at their cost leaves the average as it is.
+func shrinkCostLayers(tx *sqlx.Tx, productID string, quantity int) (float64, error) {
+	var average float64
+	err := tx.Get(&average, `SELECT average_cost FROM products WHERE id = $1 FOR UPDATE`, productID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get product cost: %w", err)
+	}
+
+	var layers []entity.CostLayer
+	err = tx.Select(&layers, `SELECT `+costLayerColumns+` FROM cost_layers
+	                          WHERE product_id = $1 AND remaining > 0
+	                          ORDER BY created_at, id FOR UPDATE`, productID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cost layers: %w", err)
+	}
+
+	left := quantity
+	var value float64
+	for _, layer := range layers {
+		if left == 0 {
+			break
+		}
+
+		take := min(left, layer.Remaining)
+		_, err := tx.Exec(`UPDATE cost_layers SET remaining = remaining - $1 WHERE id = $2`, take, layer.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to shrink cost layer: %w", err)
+		}
+		value += float64(take) * layer.UnitCost
+		left -= take
+	}
+	value += float64(left) * average
+
+	return math.Round(value*100) / 100, nil
+}
@@ -0,0 +1,522 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"strings"
+)
+
+const debtColumns = `d.id, d.order_id, s.client_id, s.currency, d.amount_paid, d.amount_unpaid, d.total_debt,
+	COALESCE(TO_CHAR(d.next_payment, 'YYYY-MM-DD'), '') AS next_payment,
+	COALESCE(d.last_paid_day, d.created_at) AS last_paid_day, d.is_fully_paid, COALESCE(d.is_overdue, FALSE) AS is_overdue,
+	COALESCE(TO_CHAR(d.overdue_since, 'YYYY-MM-DD'), '') AS overdue_since, d.recipient_id, d.created_at`
+
+const installmentColumns = `id, debt_id, installment_no, TO_CHAR(due_date, 'YYYY-MM-DD') AS due_date, amount,
+	amount_paid, is_paid, COALESCE(TO_CHAR(paid_at, 'YYYY-MM-DD HH24:MI:SS'), '') AS paid_at`
+
+type debtsRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewDebtsRepo(db *sqlx.DB) usecase.DebtsRepo {
+	return &debtsRepoImpl{db: db}
+}
+
+// openDebt creates a debt for the unpaid part of a sale inside the sale transaction.
+func openDebt(tx *sqlx.Tx, saleID string, in *entity.SalesTotal) (*entity.Debt, error) {
+	debt := &entity.Debt{}
+	unpaid := in.TotalSalePrice - in.PaidAmount
+
+	query := `INSERT INTO debts (order_id, amount_paid, amount_unpaid, total_debt, next_payment, recipient_id)
+	          VALUES ($1, 0, $2, $2, NULLIF($3, '')::date, $4)
+	          RETURNING id, order_id, amount_paid, amount_unpaid, total_debt,
+	                    COALESCE(TO_CHAR(next_payment, 'YYYY-MM-DD'), '') AS next_payment,
+	                    last_paid_day, is_fully_paid, is_overdue, '' AS overdue_since, recipient_id, created_at`
+	err := tx.QueryRowx(query, saleID, unpaid, in.NextPayment, in.SoldBy).StructScan(debt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open debt: %w", err)
+	}
+	debt.ClientID = in.ClientID
+
+	if len(in.Schedule) > 0 {
+		debt.Schedule, err = saveSchedule(tx, debt.ID, in.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		debt.NextPayment = debt.Schedule[0].DueDate
+	}
+
+	return debt, nil
+}
+
+// saveSchedule replaces the installment plan of a debt. Plans that already received payments are kept.
+func saveSchedule(tx *sqlx.Tx, debtID string, installments []entity.DebtInstallment) ([]entity.DebtInstallment, error) {
+	var paid bool
+	err := tx.Get(&paid, `SELECT EXISTS (SELECT 1 FROM debt_installments WHERE debt_id = $1 AND amount_paid > 0)`, debtID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check debt schedule: %w", err)
+	}
+	if paid {
+		return nil, errors.New("debt schedule already has payments allocated")
+	}
+
+	_, err = tx.Exec(`DELETE FROM debt_installments WHERE debt_id = $1`, debtID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear debt schedule: %w", err)
+	}
+
+	var res []entity.DebtInstallment
+	for _, item := range installments {
+		installment := entity.DebtInstallment{}
+		query := `INSERT INTO debt_installments (debt_id, installment_no, due_date, amount)
+		          VALUES ($1, $2, $3, $4) RETURNING ` + installmentColumns
+		err := tx.QueryRowx(query, debtID, item.InstallmentNo, item.DueDate, item.Amount).StructScan(&installment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save installment: %w", err)
+		}
+		res = append(res, installment)
+	}
+
+	if err := refreshDebtStatus(tx, debtID); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// refreshDebtStatus moves next_payment to the earliest unpaid installment and recomputes the overdue flag.
+func refreshDebtStatus(tx *sqlx.Tx, debtID string) error {
+	_, err := tx.Exec(`UPDATE debts
+	                   SET next_payment = (SELECT MIN(due_date) FROM debt_installments
+	                                       WHERE debt_id = $1 AND NOT is_paid)
+	                   WHERE id = $1 AND EXISTS (SELECT 1 FROM debt_installments WHERE debt_id = $1)`, debtID)
+	if err != nil {
+		return fmt.Errorf("failed to update next payment: %w", err)
+	}
+
+	_, err = tx.Exec(`UPDATE debts
+	                  SET is_overdue    = COALESCE(NOT is_fully_paid AND next_payment < CURRENT_DATE, FALSE),
+	                      overdue_since = CASE WHEN NOT is_fully_paid AND next_payment < CURRENT_DATE
+	                                           THEN next_payment END
+	                  WHERE id = $1`, debtID)
+	if err != nil {
+		return fmt.Errorf("failed to update overdue status: %w", err)
+	}
+
+	return nil
+}
+
+// allocatePayment spreads a payment over unpaid installments, oldest first.
+func allocatePayment(tx *sqlx.Tx, debtID, paymentID string, amount float64) error {
+	var installments []entity.DebtInstallment
+	err := tx.Select(&installments, `SELECT `+installmentColumns+` FROM debt_installments
+	                                 WHERE debt_id = $1 AND NOT is_paid
+	                                 ORDER BY installment_no FOR UPDATE`, debtID)
+	if err != nil {
+		return fmt.Errorf("failed to get installments: %w", err)
+	}
+
+	remaining := amount
+	for _, item := range installments {
+		if remaining <= 0 {
+			break
+		}
+
+		portion := math.Min(remaining, math.Round((item.Amount-item.AmountPaid)*100)/100)
+		_, err := tx.Exec(`UPDATE debt_installments
+		                   SET amount_paid = amount_paid + $1,
+		                       is_paid     = amount_paid + $1 >= amount,
+		                       paid_at     = CASE WHEN amount_paid + $1 >= amount THEN NOW() END
+		                   WHERE id = $2`, portion, item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update installment: %w", err)
+		}
+
+		_, err = tx.Exec(`INSERT INTO debt_payment_allocations (payment_id, installment_id, amount)
+		                  VALUES ($1, $2, $3)`, paymentID, item.ID, portion)
+		if err != nil {
+			return fmt.Errorf("failed to allocate payment: %w", err)
+		}
+
+		remaining = math.Round((remaining-portion)*100) / 100
+	}
+
+	return nil
+}
+
+// reduceDebt takes an amount off what is owed on a debt, as when goods sold on credit are returned.
+// Unpaid installments shrink from the last one.
+func reduceDebt(tx *sqlx.Tx, debtID string, amount float64) error {
+	_, err := tx.Exec(`UPDATE debts
+	                   SET total_debt    = total_debt - $1,
+	                       amount_unpaid = amount_unpaid - $1,
+	                       is_fully_paid = amount_unpaid - $1 <= 0,
+	                       next_payment  = CASE WHEN amount_unpaid - $1 <= 0 THEN NULL ELSE next_payment END
+	                   WHERE id = $2`, amount, debtID)
+	if err != nil {
+		return fmt.Errorf("failed to reduce debt: %w", err)
+	}
+
+	var installments []entity.DebtInstallment
+	err = tx.Select(&installments, `SELECT `+installmentColumns+` FROM debt_installments
+	                                 WHERE debt_id = $1 AND NOT is_paid
+	                                 ORDER BY installment_no DESC FOR UPDATE`, debtID)
+	if err != nil {
+		return fmt.Errorf("failed to get installments: %w", err)
+	}
+
+	remaining := amount
+	for _, item := range installments {
+		if remaining <= 0 {
+			break
+		}
+
+		cut := math.Min(remaining, math.Round((item.Amount-item.AmountPaid)*100)/100)
+		_, err := tx.Exec(`UPDATE debt_installments
+		                   SET amount  = amount - $1,
+		                       is_paid = amount - $1 <= amount_paid,
+		                       paid_at = CASE WHEN amount - $1 <= amount_paid THEN NOW() END
+		                   WHERE id = $2`, cut, item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update installment: %w", err)
+		}
+
+		remaining = math.Round((remaining-cut)*100) / 100
+	}
+
+	return refreshDebtStatus(tx, debtID)
+}
+
+// lockDebt reads a debt and locks its row until the transaction ends.
+func lockDebt(tx *sqlx.Tx, id string) (*entity.Debt, error) {
+	debt := &entity.Debt{}
+	query := `SELECT ` + debtColumns + `
+	          FROM debts d JOIN sales s ON s.id = d.order_id
+	          WHERE d.id = $1 FOR UPDATE OF d`
+	err := tx.Get(debt, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt: %w", err)
+	}
+
+	return debt, nil
+}
+
+// applyDebtPayment records a payment and moves it from the unpaid to the paid amount.
+func applyDebtPayment(tx *sqlx.Tx, in *entity.DebtPaymentRequest) error {
+	var paymentID string
+	err := tx.Get(&paymentID, `INSERT INTO debt_payments (debt_id, amount, payment_method, paid_by)
+	                           VALUES ($1, $2, $3, $4) RETURNING id`, in.DebtID, in.Amount, in.PaymentMethod, in.PaidBy)
+	if err != nil {
+		return fmt.Errorf("failed to record debt payment: %w", err)
+	}
+
+	if err := allocatePayment(tx, in.DebtID, paymentID, in.Amount); err != nil {
+		return err
+	}
+
+	// The debt is paid in the currency of its sale
+	var currency string
+	err = tx.Get(&currency, `SELECT s.currency FROM debts d JOIN sales s ON s.id = d.order_id WHERE d.id = $1`, in.DebtID)
+	if err != nil {
+		return fmt.Errorf("failed to get debt currency: %w", err)
+	}
+
+	err = postCashFlow(tx, "debt_payment", &entity.CashFlowRequest{
+		UserID:        in.PaidBy,
+		Amount:        in.Amount,
+		Description:   "Debt payment",
+		PaymentMethod: in.PaymentMethod,
+		Currency:      currency,
+		ReferenceType: "debt_payment",
+		ReferenceID:   paymentID,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE debts
+	                  SET amount_paid   = amount_paid + $1,
+	                      amount_unpaid = amount_unpaid - $1,
+	                      is_fully_paid = amount_unpaid - $1 <= 0,
+	                      last_paid_day = NOW(),
+	                      next_payment  = CASE WHEN amount_unpaid - $1 <= 0 THEN NULL
+	                                           ELSE COALESCE(NULLIF($2, '')::date, next_payment) END
+	                  WHERE id = $3`, in.Amount, in.NextPayment, in.DebtID)
+	if err != nil {
+		return fmt.Errorf("failed to update debt: %w", err)
+	}
+
+	return refreshDebtStatus(tx, in.DebtID)
+}
+
+func (r *debtsRepoImpl) GetDebt(in *entity.DebtID) (*entity.Debt, error) {
+	debt := &entity.Debt{}
+	query := `SELECT ` + debtColumns + `
+	          FROM debts d JOIN sales s ON s.id = d.order_id
+	          WHERE d.id = $1`
+	err := r.db.Get(debt, query, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt: %w", err)
+	}
+
+	err = r.db.Select(&debt.Schedule, `SELECT `+installmentColumns+` FROM debt_installments
+	                                   WHERE debt_id = $1 ORDER BY installment_no`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt schedule: %w", err)
+	}
+
+	return debt, nil
+}
+
+func (r *debtsRepoImpl) GetDebtList(in *entity.DebtFilter) (*entity.DebtList, error) {
+	var debts []entity.Debt
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + debtColumns + `
+		FROM debts d JOIN sales s ON s.id = d.order_id
+		WHERE 1=1
+	`)
+
+	if in.ClientID != "" {
+		queryBuilder.WriteString(" AND s.client_id = $" + fmt.Sprint(argIndex))
+		args = append(args, in.ClientID)
+		argIndex++
+	}
+
+	if in.RecipientID != "" {
+		queryBuilder.WriteString(" AND d.recipient_id = $" + fmt.Sprint(argIndex))
+		args = append(args, in.RecipientID)
+		argIndex++
+	}
+
+	if in.IsFullyPaid != "" {
+		queryBuilder.WriteString(" AND d.is_fully_paid = $" + fmt.Sprint(argIndex))
+		args = append(args, in.IsFullyPaid == "true")
+		argIndex++
+	}
+
+	if in.IsOverdue != "" {
+		queryBuilder.WriteString(" AND COALESCE(d.is_overdue, FALSE) = $" + fmt.Sprint(argIndex))
+		args = append(args, in.IsOverdue == "true")
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY d.created_at DESC")
+
+	err := r.db.Select(&debts, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list debts: %w", err)
+	}
+
+	return &entity.DebtList{Debts: debts}, nil
+}
+
+func (r *debtsRepoImpl) PayDebt(in *entity.DebtPaymentRequest) (*entity.Debt, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	debt, err := lockDebt(tx, in.DebtID)
+	if err != nil {
+		return nil, err
+	}
+
+	if debt.IsFullyPaid {
+		return nil, errors.New("debt is already fully paid")
+	}
+	if in.Amount > debt.AmountUnpaid {
+		return nil, fmt.Errorf("payment %.2f exceeds unpaid amount %.2f", in.Amount, debt.AmountUnpaid)
+	}
+
+	if err := applyDebtPayment(tx, in); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetDebt(&entity.DebtID{ID: in.DebtID})
+}
+
+func (r *debtsRepoImpl) GetDebtPayments(in *entity.DebtID) (*entity.DebtPaymentList, error) {
+	var payments []entity.DebtPayment
+	query := `SELECT id, debt_id, payment_date, amount, COALESCE(payment_method, 'uzs') AS payment_method, paid_by
+	          FROM debt_payments WHERE debt_id = $1 ORDER BY payment_date`
+	err := r.db.Select(&payments, query, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list debt payments: %w", err)
+	}
+
+	return &entity.DebtPaymentList{Payments: payments}, nil
+}
+
+func (r *debtsRepoImpl) CloseDebt(in *entity.DebtClose) (*entity.Debt, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	debt, err := lockDebt(tx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if debt.IsFullyPaid {
+		return nil, errors.New("debt is already closed")
+	}
+
+	// Closing settles whatever is still unpaid with a final payment
+	payment := &entity.DebtPaymentRequest{
+		DebtID:        in.ID,
+		Amount:        debt.AmountUnpaid,
+		PaymentMethod: in.PaymentMethod,
+		PaidBy:        in.ClosedBy,
+	}
+	if err := applyDebtPayment(tx, payment); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetDebt(&entity.DebtID{ID: in.ID})
+}
+
+func (r *debtsRepoImpl) SetDebtSchedule(in *entity.DebtSchedule) (*entity.DebtSchedule, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	debt, err := lockDebt(tx, in.DebtID)
+	if err != nil {
+		return nil, err
+	}
+
+	if debt.IsFullyPaid {
+		return nil, errors.New("debt is already fully paid")
+	}
+
+	var planned float64
+	for _, item := range in.Installments {
+		planned += item.Amount
+	}
+	if math.Round(planned*100) != math.Round(debt.AmountUnpaid*100) {
+		return nil, fmt.Errorf("installments total %.2f does not match unpaid amount %.2f", planned, debt.AmountUnpaid)
+	}
+
+	installments, err := saveSchedule(tx, in.DebtID, in.Installments)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &entity.DebtSchedule{DebtID: in.DebtID, Installments: installments}, nil
+}
+
+func (r *debtsRepoImpl) GetDebtSchedule(in *entity.DebtID) (*entity.DebtSchedule, error) {
+	schedule := &entity.DebtSchedule{DebtID: in.ID}
+	err := r.db.Select(&schedule.Installments, `SELECT `+installmentColumns+` FROM debt_installments
+	                                            WHERE debt_id = $1 ORDER BY installment_no`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+func (r *debtsRepoImpl) FlagOverdueDebts() (int64, error) {
+	res, err := r.db.Exec(`UPDATE debts
+	                       SET is_overdue    = COALESCE(NOT is_fully_paid AND next_payment < CURRENT_DATE, FALSE),
+	                           overdue_since = CASE WHEN NOT is_fully_paid AND next_payment < CURRENT_DATE
+	                                                THEN next_payment END
+	                       WHERE is_overdue OR (NOT is_fully_paid AND next_payment < CURRENT_DATE)`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to flag overdue debts: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+
+	return rows, nil
+}
+
+func (r *debtsRepoImpl) GetDebtAging(in *entity.AgingFilter) (*entity.AgingReport, error) {
+	var groupID, groupName, group string
+	switch in.GroupBy {
+	case "", "client":
+		in.GroupBy = "client"
+		groupID = "o.client_id"
+		groupName = "COALESCE(g.full_name, '')"
+		group = `LEFT JOIN clients g ON g.id = o.client_id
+		         GROUP BY o.client_id, g.full_name`
+	case "seller":
+		groupID = "o.sold_by"
+		groupName = "COALESCE(g.first_name || ' ' || g.last_name, '')"
+		group = `LEFT JOIN users g ON g.user_id = o.sold_by
+		         GROUP BY o.sold_by, g.first_name, g.last_name`
+	default:
+		return nil, fmt.Errorf("unknown aging grouping %q", in.GroupBy)
+	}
+
+	// Scheduled debts age by installment, unscheduled ones by next_payment
+	query := `
+		WITH open_items AS (
+			SELECT s.client_id, s.sold_by, ROUND((i.amount - i.amount_paid) * s.exchange_rate, 2) AS amount, i.due_date
+			FROM debt_installments i
+			JOIN debts d ON d.id = i.debt_id
+			JOIN sales s ON s.id = d.order_id
+			WHERE NOT i.is_paid AND NOT d.is_fully_paid
+			UNION ALL
+			SELECT s.client_id, s.sold_by, ROUND(d.amount_unpaid * s.exchange_rate, 2), d.next_payment
+			FROM debts d
+			JOIN sales s ON s.id = d.order_id
+			WHERE NOT d.is_fully_paid
+			  AND NOT EXISTS (SELECT 1 FROM debt_installments i WHERE i.debt_id = d.id)
+		)
+		SELECT ` + groupID + `::text AS group_id, ` + groupName + ` AS name,
+		       COALESCE(SUM(o.amount) FILTER (WHERE o.due_date IS NULL OR o.due_date >= CURRENT_DATE), 0) AS current,
+		       COALESCE(SUM(o.amount) FILTER (WHERE CURRENT_DATE - o.due_date BETWEEN 1 AND 30), 0) AS days_1_30,
+		       COALESCE(SUM(o.amount) FILTER (WHERE CURRENT_DATE - o.due_date BETWEEN 31 AND 60), 0) AS days_31_60,
+		       COALESCE(SUM(o.amount) FILTER (WHERE CURRENT_DATE - o.due_date BETWEEN 61 AND 90), 0) AS days_61_90,
+		       COALESCE(SUM(o.amount) FILTER (WHERE CURRENT_DATE - o.due_date > 90), 0) AS over_90,
+		       COALESCE(SUM(o.amount), 0) AS total
+		FROM open_items o ` + group + `
+		ORDER BY total DESC`
+
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Debts in other currencies are aged in the base currency at their sale rate
+	report := &entity.AgingReport{GroupBy: in.GroupBy, Currency: base}
+	err = r.db.Select(&report.Rows, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build aging report: %w", err)
+	}
+
+	for _, row := range report.Rows {
+		report.Total.Current += row.Current
+		report.Total.Days1To30 += row.Days1To30
+		report.Total.Days31To60 += row.Days31To60
+		report.Total.Days61To90 += row.Days61To90
+		report.Total.Over90 += row.Over90
+		report.Total.Total += row.Total
+	}
+
+	return report, nil
+}
@@ -0,0 +1,329 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strings"
+)
+
+type lotsRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewLotsRepo(db *sqlx.DB) usecase.LotsRepo {
+	return &lotsRepoImpl{db: db}
+}
+
+const lotColumns = `l.id, l.product_id, p.name AS product_name, COALESCE(l.purchase_item_id::text, '') AS purchase_item_id,
+	COALESCE(l.lot_number, '') AS lot_number, COALESCE(TO_CHAR(l.expiry_date, 'YYYY-MM-DD'), '') AS expiry_date,
+	l.expiry_date - CURRENT_DATE AS days_left, COALESCE(l.expiry_date < CURRENT_DATE, FALSE) AS is_expired,
+	l.quantity, l.remaining, l.created_at`
+
+// blockExpiredSales tells whether expired lots may not be sold.
+func blockExpiredSales(q sqlx.Queryer) (bool, error) {
+	var block bool
+	err := sqlx.Get(q, &block, `SELECT value::boolean FROM settings WHERE key = 'block_expired_sales'`)
+	if err != nil {
+		return false, fmt.Errorf("failed to get expired sales setting: %w", err)
+	}
+
+	return block, nil
+}
+
+// expiredStock returns how much of each product sits in expired lots.
+func expiredStock(q sqlx.Queryer, productIDs []string) (map[string]int, error) {
+	rows, err := q.Queryx(`SELECT product_id, SUM(remaining) FROM stock_lots
+	                       WHERE product_id = ANY($1) AND remaining > 0 AND expiry_date < CURRENT_DATE
+	                       GROUP BY product_id`, pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired stock: %w", err)
+	}
+	defer rows.Close()
+
+	expired := map[string]int{}
+	for rows.Next() {
+		var id string
+		var quantity int
+		if err := rows.Scan(&id, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to read expired stock: %w", err)
+		}
+		expired[id] = quantity
+	}
+
+	return expired, rows.Err()
+}
+
+// addLot keeps received goods as a lot when they come with a lot number or an expiry date.
+func addLot(tx *sqlx.Tx, productID, purchaseItemID, lotNumber, expiryDate string, quantity int) error {
+	if lotNumber == "" && expiryDate == "" {
+		return nil
+	}
+
+	_, err := tx.Exec(`INSERT INTO stock_lots (product_id, purchase_item_id, lot_number, expiry_date, quantity, remaining)
+	                   VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, ''), NULLIF($4, '')::date, $5, $5)`,
+		productID, purchaseItemID, lotNumber, expiryDate, quantity)
+	if err != nil {
+		return fmt.Errorf("failed to add lot: %w", err)
+	}
+
+	return nil
+}
+
+// fefoLots locks the lots of a product still in stock, first expired first. Lots without an expiry date
+// come last, oldest first.
+func fefoLots(tx *sqlx.Tx, productID string, skipExpired bool) ([]entity.Lot, error) {
+	var lots []entity.Lot
+	err := tx.Select(&lots, `SELECT `+lotColumns+` FROM stock_lots l JOIN products p ON p.id = l.product_id
+	                         WHERE l.product_id = $1 AND l.remaining > 0
+	                           AND NOT ($2 AND COALESCE(l.expiry_date < CURRENT_DATE, FALSE))
+	                         ORDER BY l.expiry_date NULLS LAST, l.created_at, l.id
+	                         FOR UPDATE OF l`, productID, skipExpired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lots: %w", err)
+	}
+
+	return lots, nil
+}
+
+// allocateLots draws a sold line from the lots of its product, first expired first, and records which lots
+// it came from. Quantities beyond the lots come from stock received without a lot.
+func allocateLots(tx *sqlx.Tx, item *entity.SalesItem, skipExpired bool) error {
+	lots, err := fefoLots(tx, item.ProductID, skipExpired)
+	if err != nil {
+		return err
+	}
+
+	left := item.Quantity
+	for _, lot := range lots {
+		if left == 0 {
+			break
+		}
+
+		take := min(left, lot.Remaining)
+		_, err := tx.Exec(`UPDATE stock_lots SET remaining = remaining - $1 WHERE id = $2`, take, lot.ID)
+		if err != nil {
+			return fmt.Errorf("failed to take from lot: %w", err)
+		}
+		_, err = tx.Exec(`INSERT INTO lot_allocations (lot_id, sales_item_id, quantity) VALUES ($1, $2, $3)`,
+			lot.ID, item.ID, take)
+		if err != nil {
+			return fmt.Errorf("failed to record lot allocation: %w", err)
+		}
+		left -= take
+	}
+
+	return nil
+}
+
+// restoreLots puts the goods of the sales items matching the condition back into the lots they were sold from.
+func restoreLots(tx *sqlx.Tx, condition string, args ...interface{}) error {
+	queries := []string{
+		`UPDATE stock_lots l
+		 SET remaining = l.remaining + a.quantity
+		 FROM (SELECT a.lot_id, SUM(a.quantity) AS quantity
+		       FROM lot_allocations a JOIN sales_items si ON si.id = a.sales_item_id
+		       WHERE ` + condition + ` GROUP BY a.lot_id) a
+		 WHERE l.id = a.lot_id`,
+		`DELETE FROM lot_allocations a USING sales_items si
+		 WHERE si.id = a.sales_item_id AND ` + condition,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed to restore lots: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// returnLots puts returned goods of a sold line back into the lots it was sold from, the latest expiring first.
+func returnLots(tx *sqlx.Tx, salesItemID string, quantity int) error {
+	var allocations []struct {
+		ID       string `db:"id"`
+		LotID    string `db:"lot_id"`
+		Quantity int    `db:"quantity"`
+	}
+	err := tx.Select(&allocations, `SELECT a.id, a.lot_id, a.quantity
+	                                FROM lot_allocations a JOIN stock_lots l ON l.id = a.lot_id
+	                                WHERE a.sales_item_id = $1
+	                                ORDER BY l.expiry_date DESC NULLS FIRST, l.created_at DESC
+	                                FOR UPDATE OF a`, salesItemID)
+	if err != nil {
+		return fmt.Errorf("failed to get lot allocations: %w", err)
+	}
+
+	left := quantity
+	for _, a := range allocations {
+		if left == 0 {
+			break
+		}
+
+		back := min(left, a.Quantity)
+		_, err := tx.Exec(`UPDATE stock_lots SET remaining = remaining + $1 WHERE id = $2`, back, a.LotID)
+		if err != nil {
+			return fmt.Errorf("failed to return goods to lot: %w", err)
+		}
+		if back == a.Quantity {
+			_, err = tx.Exec(`DELETE FROM lot_allocations WHERE id = $1`, a.ID)
+		} else {
+			_, err = tx.Exec(`UPDATE lot_allocations SET quantity = quantity - $1 WHERE id = $2`, back, a.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update lot allocation: %w", err)
+		}
+		left -= back
+	}
+
+	return nil
+}
+
+// shrinkLots takes goods that left stock without being sold out of the lots of their product, first expired
+// first, so that writing off expired goods empties the expired lots.
+func shrinkLots(tx *sqlx.Tx, productID string, quantity int) error {
+	lots, err := fefoLots(tx, productID, false)
+	if err != nil {
+		return err
+	}
+
+	left := quantity
+	for _, lot := range lots {
+		if left == 0 {
+			break
+		}
+
+		take := min(left, lot.Remaining)
+		_, err := tx.Exec(`UPDATE stock_lots SET remaining = remaining - $1 WHERE id = $2`, take, lot.ID)
+		if err != nil {
+			return fmt.Errorf("failed to shrink lot: %w", err)
+		}
+		left -= take
+	}
+
+	return nil
+}
+
+// returnPurchaseLot takes goods sent back to the supplier out of the lot of their purchase line. When part
+// of that lot was already sold or written off, the rest comes out of the other lots of the product, first
+// expired first, so that the lots never hold more than is in stock.
+func returnPurchaseLot(tx *sqlx.Tx, purchaseItemID string, quantity int) error {
+	var lot struct {
+		ID        string `db:"id"`
+		ProductID string `db:"product_id"`
+		Remaining int    `db:"remaining"`
+	}
+	err := tx.Get(&lot, `SELECT id, product_id, remaining FROM stock_lots WHERE purchase_item_id = $1 FOR UPDATE`,
+		purchaseItemID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get purchase lot: %w", err)
+	}
+
+	take := min(quantity, lot.Remaining)
+	_, err = tx.Exec(`UPDATE stock_lots SET remaining = remaining - $1 WHERE id = $2`, take, lot.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update purchase lot: %w", err)
+	}
+	if take < quantity {
+		return shrinkLots(tx, lot.ProductID, quantity-take)
+	}
+
+	return nil
+}
+
+// removePurchaseLots drops the lots of a deleted purchase. A purchase whose lots were already sold from
+// cannot be deleted.
+func removePurchaseLots(tx *sqlx.Tx, purchaseID string) error {
+	var sold bool
+	err := tx.Get(&sold, `SELECT EXISTS (SELECT 1 FROM stock_lots l JOIN lot_allocations a ON a.lot_id = l.id
+	                                     WHERE l.purchase_item_id IN (SELECT id FROM purchase_items
+	                                                                  WHERE purchase_id = $1))`, purchaseID)
+	if err != nil {
+		return fmt.Errorf("failed to check purchase lots: %w", err)
+	}
+	if sold {
+		return errors.New("goods of this purchase were already sold, the purchase cannot be deleted")
+	}
+
+	_, err = tx.Exec(`DELETE FROM stock_lots
+	                  WHERE purchase_item_id IN (SELECT id FROM purchase_items WHERE purchase_id = $1)`, purchaseID)
+	if err != nil {
+		return fmt.Errorf("failed to delete purchase lots: %w", err)
+	}
+
+	return nil
+}
+
+func (r *lotsRepoImpl) GetLots(in *entity.LotFilter) (*entity.LotList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + lotColumns + ` FROM stock_lots l
+		JOIN products p ON p.id = l.product_id
+		WHERE 1=1`)
+
+	if in.ProductID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND l.product_id = $%d", argIndex))
+		args = append(args, in.ProductID)
+		argIndex++
+	}
+	if !in.All {
+		queryBuilder.WriteString(" AND l.remaining > 0")
+	}
+
+	queryBuilder.WriteString(" ORDER BY p.name, l.expiry_date NULLS LAST, l.created_at")
+
+	list := &entity.LotList{Lots: []entity.Lot{}}
+	err := r.db.Select(&list.Lots, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lots: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *lotsRepoImpl) GetExpiringLots(in *entity.ExpiringLotsFilter) (*entity.ExpiringLots, error) {
+	query := `SELECT ` + lotColumns + ` FROM stock_lots l
+	          JOIN products p ON p.id = l.product_id
+	          WHERE l.remaining > 0 AND l.expiry_date <= CURRENT_DATE + $1::int
+	            AND ($2 = '' OR p.category_id = NULLIF($2, '')::uuid)
+	          ORDER BY l.expiry_date, p.name`
+
+	res := &entity.ExpiringLots{Days: in.Days, Lots: []entity.Lot{}}
+	err := r.db.Select(&res.Lots, query, in.Days, in.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expiring lots: %w", err)
+	}
+	for _, lot := range res.Lots {
+		res.Quantity += lot.Remaining
+	}
+
+	return res, nil
+}
+
+func (r *lotsRepoImpl) GetLotSettings() (*entity.LotSettings, error) {
+	block, err := blockExpiredSales(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.LotSettings{BlockExpiredSales: block}, nil
+}
+
+func (r *lotsRepoImpl) SetLotSettings(in *entity.LotSettings) (*entity.LotSettings, error) {
+	_, err := r.db.Exec(`INSERT INTO settings (key, value) VALUES ('block_expired_sales', $1)
+	                     ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value`, fmt.Sprint(in.BlockExpiredSales))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save lot settings: %w", err)
+	}
+
+	return r.GetLotSettings()
+}
@@ -0,0 +1,234 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+type payablesRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewPayablesRepo(db *sqlx.DB) usecase.PayablesRepo {
+	return &payablesRepoImpl{db: db}
+}
+
+const supplierPaymentColumns = `id, purchase_id, supplier_id, amount, payment_method, paid_by,
+	COALESCE(description, '') AS description, payment_date`
+
+// addSupplierPayment records a payment to the supplier and raises the purchase's paid amount.
+func addSupplierPayment(tx *sqlx.Tx, purchaseID, supplierID string, in *entity.SupplierPaymentRequest) (*entity.SupplierPayment, error) {
+	payment := &entity.SupplierPayment{}
+	query := `INSERT INTO supplier_payments (purchase_id, supplier_id, amount, payment_method, paid_by, description)
+	          VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + supplierPaymentColumns
+	err := tx.QueryRowx(query, purchaseID, supplierID, in.Amount, in.PaymentMethod, in.PaidBy, in.Description).
+		StructScan(payment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record supplier payment: %w", err)
+	}
+
+	_, err = tx.Exec(`UPDATE purchases SET amount_paid = amount_paid + $1 WHERE id = $2`, in.Amount, purchaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update purchase paid amount: %w", err)
+	}
+
+	// The supplier is paid in the currency of the purchase
+	var currency string
+	err = tx.Get(&currency, `SELECT currency FROM purchases WHERE id = $1`, purchaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase currency: %w", err)
+	}
+
+	err = postCashFlow(tx, "supplier_payment", &entity.CashFlowRequest{
+		UserID:        in.PaidBy,
+		Amount:        in.Amount,
+		Description:   "Supplier payment",
+		PaymentMethod: in.PaymentMethod,
+		Currency:      currency,
+		ReferenceType: "supplier_payment",
+		ReferenceID:   payment.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (r *payablesRepoImpl) PaySupplier(in *entity.SupplierPaymentRequest) (*entity.SupplierPayment, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var supplierID string
+	var balance float64
+	err = tx.QueryRowx(`SELECT supplier_id, total_cost - returned_amount - amount_paid FROM purchases
+	                    WHERE id = $1 FOR UPDATE`, in.PurchaseID).Scan(&supplierID, &balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("purchase not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock purchase: %w", err)
+	}
+
+	if balance <= 0 {
+		return nil, errors.New("purchase is already fully paid")
+	}
+	if in.Amount > balance {
+		return nil, fmt.Errorf("payment %.2f exceeds outstanding balance %.2f", in.Amount, balance)
+	}
+
+	payment, err := addSupplierPayment(tx, in.PurchaseID, supplierID, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit supplier payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+func (r *payablesRepoImpl) GetSupplierPayments(in *entity.SupplierPaymentFilter) (*entity.SupplierPaymentList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + supplierPaymentColumns + ` FROM supplier_payments WHERE 1=1`)
+
+	if in.SupplierID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND supplier_id = $%d", argIndex))
+		args = append(args, in.SupplierID)
+		argIndex++
+	}
+	if in.PurchaseID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND purchase_id = $%d", argIndex))
+		args = append(args, in.PurchaseID)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY payment_date DESC")
+
+	payments := &entity.SupplierPaymentList{}
+	err := r.db.Select(&payments.Payments, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list supplier payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+func (r *payablesRepoImpl) GetPayables(in *entity.PayableFilter) (*entity.PayableList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+
+	queryBuilder.WriteString(`
+		SELECT p.id AS purchase_id, p.supplier_id, COALESCE(c.full_name, '') AS supplier_name,
+		       p.total_cost, p.returned_amount AS returned, p.amount_paid,
+		       p.total_cost - p.returned_amount - p.amount_paid AS balance, p.currency,
+		       ROUND((p.total_cost - p.returned_amount - p.amount_paid) * p.exchange_rate, 2) AS base_balance,
+		       COALESCE(TO_CHAR(p.due_date, 'YYYY-MM-DD'), '') AS due_date, p.created_at
+		FROM purchases p
+		LEFT JOIN clients c ON c.id = p.supplier_id
+		WHERE p.amount_paid < p.total_cost - p.returned_amount
+	`)
+
+	if in.SupplierID != "" {
+		queryBuilder.WriteString(" AND p.supplier_id = $1")
+		args = append(args, in.SupplierID)
+	}
+
+	queryBuilder.WriteString(" ORDER BY p.due_date NULLS LAST, p.created_at")
+
+	payables := &entity.PayableList{}
+	err := r.db.Select(&payables.Payables, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payables: %w", err)
+	}
+
+	return payables, nil
+}
+
+func (r *payablesRepoImpl) GetSupplierBalances() (*entity.SupplierBalanceList, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Balances are summed in the base currency at each purchase's rate
+	query := `
+		SELECT p.supplier_id, COALESCE(c.full_name, '') AS supplier_name,
+		       COUNT(*) AS purchases,
+		       ROUND(SUM((p.total_cost - p.returned_amount - p.amount_paid) * p.exchange_rate), 2) AS balance,
+		       ROUND(COALESCE(SUM((p.total_cost - p.returned_amount - p.amount_paid) * p.exchange_rate)
+		                      FILTER (WHERE p.due_date < CURRENT_DATE), 0), 2) AS overdue
+		FROM purchases p
+		LEFT JOIN clients c ON c.id = p.supplier_id
+		WHERE p.amount_paid < p.total_cost - p.returned_amount
+		GROUP BY p.supplier_id, c.full_name
+		ORDER BY balance DESC`
+
+	res := &entity.SupplierBalanceList{Currency: base}
+	err = r.db.Select(&res.Suppliers, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supplier balances: %w", err)
+	}
+
+	for _, s := range res.Suppliers {
+		res.Total += s.Balance
+	}
+
+	return res, nil
+}
+
+func (r *payablesRepoImpl) GetPayablesAging() (*entity.AgingReport, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open balances are aged in the base currency at each purchase's rate
+	query := `
+		WITH open_items AS (
+			SELECT p.supplier_id, p.due_date,
+			       ROUND((p.total_cost - p.returned_amount - p.amount_paid) * p.exchange_rate, 2) AS amount
+			FROM purchases p
+			WHERE p.amount_paid < p.total_cost - p.returned_amount
+		)
+		SELECT o.supplier_id::text AS group_id, COALESCE(c.full_name, '') AS name,
+		       COALESCE(SUM(o.amount) FILTER (WHERE o.due_date IS NULL OR o.due_date >= CURRENT_DATE), 0) AS current,
+		       COALESCE(SUM(o.amount) FILTER (WHERE CURRENT_DATE - o.due_date BETWEEN 1 AND 30), 0) AS days_1_30,
+		       COALESCE(SUM(o.amount) FILTER (WHERE CURRENT_DATE - o.due_date BETWEEN 31 AND 60), 0) AS days_31_60,
+		       COALESCE(SUM(o.amount) FILTER (WHERE CURRENT_DATE - o.due_date BETWEEN 61 AND 90), 0) AS days_61_90,
+		       COALESCE(SUM(o.amount) FILTER (WHERE CURRENT_DATE - o.due_date > 90), 0) AS over_90,
+		       COALESCE(SUM(o.amount), 0) AS total
+		FROM open_items o
+		LEFT JOIN clients c ON c.id = o.supplier_id
+		GROUP BY o.supplier_id, c.full_name
+		ORDER BY total DESC`
+
+	report := &entity.AgingReport{GroupBy: "supplier", Currency: base}
+	err = r.db.Select(&report.Rows, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build payables aging report: %w", err)
+	}
+
+	for _, row := range report.Rows {
+		report.Total.Current += row.Current
+		report.Total.Days1To30 += row.Days1To30
+		report.Total.Days31To60 += row.Days31To60
+		report.Total.Days61To90 += row.Days61To90
+		report.Total.Over90 += row.Over90
+		report.Total.Total += row.Total
+	}
+
+	return report, nil
+}
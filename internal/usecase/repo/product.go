@@ -3,11 +3,19 @@ package repo
 import (
 	"crm-admin/internal/entity"
 	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"math"
 	"strings"
 )
 
+const productColumns = `id, category_id, name, bill_format, incoming_price, standard_price, total_count,
+	COALESCE(sku, '') AS sku, COALESCE(barcode, '') AS barcode, COALESCE(location, '') AS location,
+	COALESCE(parent_id::text, '') AS parent_id, COALESCE(attributes::text, '') AS attributes,
+	COALESCE(variant_options::text, '') AS variant_options, own_price, created_by, created_at`
+
 type productRepo struct {
 	db *sqlx.DB
 }
@@ -87,13 +95,12 @@ func (p *productRepo) CreateProduct(in *entity.ProductRequest) (*entity.Product,
 	var product entity.Product
 
 	query := `
-		INSERT INTO products (category_id, name, bill_format, incoming_price, standard_price, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, category_id, name, bill_format, incoming_price, standard_price, total_count, created_by, created_at
-	`
-	err := p.db.QueryRowx(query, in.CategoryID, in.Name, in.BillFormat, in.IncomingPrice, in.StandardPrice, in.CreatedBy).
-		Scan(&product.ID, &product.CategoryID, &product.Name, &product.BillFormat, &product.IncomingPrice,
-			&product.StandardPrice, &product.TotalCount, &product.CreatedBy, &product.CreatedAt)
+		INSERT INTO products (category_id, name, bill_format, incoming_price, standard_price, sku, barcode, location,
+		                      created_by)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9)
+		RETURNING ` + productColumns
+	err := p.db.QueryRowx(query, in.CategoryID, in.Name, in.BillFormat, in.IncomingPrice, in.StandardPrice,
+		in.SKU, in.Barcode, in.Location, in.CreatedBy).StructScan(&product)
 
 	if err != nil {
 		return nil, err
@@ -103,7 +110,7 @@ func (p *productRepo) CreateProduct(in *entity.ProductRequest) (*entity.Product,
 }
 
 func (p *productRepo) UpdateProduct(in *entity.ProductUpdate) (*entity.Product, error) {
-	var product *entity.Product
+	product := &entity.Product{}
 	query := `UPDATE products SET `
 	var args []interface{}
 	argCounter := 1
@@ -134,20 +141,52 @@ func (p *productRepo) UpdateProduct(in *entity.ProductUpdate) (*entity.Product,
 		args = append(args, in.StandardPrice)
 		argCounter++
 	}
+	if in.SKU != "" {
+		query += fmt.Sprintf("sku = $%d, ", argCounter)
+		args = append(args, in.SKU)
+		argCounter++
+	}
+	if in.Barcode != "" {
+		query += fmt.Sprintf("barcode = $%d, ", argCounter)
+		args = append(args, in.Barcode)
+		argCounter++
+	}
+	if in.Location != "" {
+		query += fmt.Sprintf("location = $%d, ", argCounter)
+		args = append(args, in.Location)
+		argCounter++
+	}
 
 	// Remove trailing comma and space, add WHERE clause
-	query = query[:len(query)-2] + fmt.Sprintf(" WHERE id = $%d "+
-		"RETURNING id, category_id, name, bill_format, incoming_price, standard_price, total_count, created_by, created_at", argCounter)
+	query = query[:len(query)-2] + fmt.Sprintf(" WHERE id = $%d RETURNING "+productColumns, argCounter)
 	args = append(args, in.ID)
 
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// Execute the query
-	err := p.db.QueryRowx(query, args...).Scan(&product.ID, &product.CategoryID, &product.Name, &product.BillFormat,
-		&product.IncomingPrice, &product.StandardPrice, &product.TotalCount, &product.CreatedBy, &product.CreatedAt)
+	err = tx.QueryRowx(query, args...).StructScan(product)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 
+	// Variants without prices of their own follow the prices of their product
+	if in.IncomingPrice != 0 || in.StandardPrice != 0 {
+		_, err = tx.Exec(`UPDATE products SET incoming_price = $1, standard_price = $2
+		                  WHERE parent_id = $3 AND NOT own_price`, product.IncomingPrice, product.StandardPrice, product.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update variant prices: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit product update: %w", err)
+	}
+
 	return product, nil
 }
 
@@ -166,8 +205,7 @@ func (p *productRepo) DeleteProduct(in *entity.ProductID) (*entity.Message, erro
 func (p *productRepo) GetProduct(in *entity.ProductID) (*entity.Product, error) {
 	var product *entity.Product
 
-	query := `SELECT id, category_id, name, bill_format, incoming_price, standard_price,
-       total_count, created_by, created_at FROM products WHERE id = $1`
+	query := `SELECT ` + productColumns + ` FROM products WHERE id = $1`
 
 	err := p.db.Get(&product, query, in.ID)
 
@@ -175,20 +213,28 @@ func (p *productRepo) GetProduct(in *entity.ProductID) (*entity.Product, error)
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
 
-	return product, nil
+	list := []entity.Product{*product}
+	if err := attachVariants(p.db, list); err != nil {
+		return nil, err
+	}
+
+	return &list[0], nil
 }
 
+// GetProductList lists the catalog with the variants of each product grouped under it.
 func (p *productRepo) GetProductList(in *entity.FilterProduct) (*entity.ProductList, error) {
 	var products []entity.Product
 	var args []interface{}
-	var filters []string
+	// Variants are listed under their product
+	filters := []string{`parent_id IS NULL`}
 
-	query := `
-		SELECT id, category_id, name, bill_format, incoming_price, standard_price, total_count, created_by, created_at
-		FROM products 
-	`
+	query := `SELECT ` + productColumns + ` FROM products `
 
 	// Dynamically build the WHERE clause based on filters
+	if in.SKU != "" {
+		filters = append(filters, `(sku = ? OR id IN (SELECT parent_id FROM products WHERE sku = ?))`)
+		args = append(args, in.SKU, in.SKU)
+	}
 	if in.CategoryId != "" {
 		filters = append(filters, `category_id = ?`)
 		args = append(args, in.CategoryId)
@@ -215,56 +261,190 @@ func (p *productRepo) GetProductList(in *entity.FilterProduct) (*entity.ProductL
 	query += " ORDER BY created_at DESC"
 
 	// Execute the query
-	err := p.db.Select(&products, query, args...)
+	err := p.db.Select(&products, p.db.Rebind(query), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
 
+	if err := attachVariants(p.db, products); err != nil {
+		return nil, err
+	}
+
 	return &entity.ProductList{Products: products}, nil
 }
 
+func (p *productRepo) GetCostingMethod() (*entity.CostingMethod, error) {
+	method, err := costingMethod(p.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CostingMethod{Method: method}, nil
+}
+
+// SetCostingMethod changes how goods sold from now on are costed, sales already made keep their cost.
+func (p *productRepo) SetCostingMethod(in *entity.CostingMethod) (*entity.CostingMethod, error) {
+	_, err := p.db.Exec(`INSERT INTO settings (key, value) VALUES ('costing_method', $1)
+	                     ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value`, in.Method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set costing method: %w", err)
+	}
+
+	return in, nil
+}
+
+func (p *productRepo) GetCostLayers(in *entity.ProductID) (*entity.CostLayerList, error) {
+	method, err := costingMethod(p.db)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &entity.CostLayerList{ProductID: in.ID, Method: method}
+	err = p.db.Get(&list.AverageCost, `SELECT average_cost FROM products WHERE id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product cost: %w", err)
+	}
+
+	err = p.db.Select(&list.Layers, `SELECT `+costLayerColumns+` FROM cost_layers
+	                                 WHERE product_id = $1 AND remaining > 0 ORDER BY created_at, id`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cost layers: %w", err)
+	}
+
+	for _, layer := range list.Layers {
+		list.OnHand += layer.Remaining
+		list.Value += float64(layer.Remaining) * layer.UnitCost
+	}
+	if method == "average" {
+		list.Value = float64(list.OnHand) * list.AverageCost
+	}
+	list.Value = math.Round(list.Value*100) / 100
+
+	return list, nil
+}
+
+// GetInventoryHistory returns the movements of a product between two dates, both included, with the stock
+// before the first and after the last day.
+func (p *productRepo) GetInventoryHistory(in *entity.InventoryMovementFilter) (*entity.InventoryHistory, error) {
+	history := &entity.InventoryHistory{From: in.From, To: in.To, Movements: []entity.InventoryMovement{}}
+
+	query := `SELECT p.id AS product_id, p.name AS product_name,
+	                 COALESCE(SUM(m.quantity) FILTER (WHERE m.created_at < $2::date), 0) AS opening,
+	                 COALESCE(SUM(m.quantity) FILTER (WHERE m.created_at >= $2::date AND m.created_at < $3::date + 1
+	                                                    AND m.quantity > 0), 0) AS incoming,
+	                 COALESCE(-SUM(m.quantity) FILTER (WHERE m.created_at >= $2::date AND m.created_at < $3::date + 1
+	                                                     AND m.quantity < 0), 0) AS outgoing,
+	                 COALESCE(SUM(m.quantity) FILTER (WHERE m.created_at < $3::date + 1), 0) AS closing
+	          FROM products p LEFT JOIN inventory_movements m ON m.product_id = p.id
+	          WHERE p.id = $1
+	          GROUP BY p.id, p.name`
+	err := p.db.Get(history, query, in.ProductID, in.From, in.To)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("product not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock balances: %w", err)
+	}
+
+	var queryBuilder strings.Builder
+	args := []interface{}{in.ProductID, in.From, in.To}
+
+	queryBuilder.WriteString(`SELECT ` + inventoryMovementColumns + ` FROM inventory_movements
+	                          WHERE product_id = $1 AND created_at >= $2::date AND created_at < $3::date + 1`)
+	if in.MovementType != "" {
+		queryBuilder.WriteString(" AND movement_type = $4")
+		args = append(args, in.MovementType)
+	}
+	queryBuilder.WriteString(" ORDER BY created_at, id")
+
+	err = p.db.Select(&history.Movements, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory movements: %w", err)
+	}
+
+	return history, nil
+}
+
 // ------------------- End Product CRUD ------------------------------------------------------------------------
 
 // -------------------------------------------- Must fix end Do Reflect -------------------------------------
 
+// AddProduct receives stock through the inventory ledger.
 func (p *productQuantity) AddProduct(in *entity.CountProductReq) (*entity.ProductNumber, error) {
-	var product *entity.ProductNumber
-
-	query := `
-		UPDATE products
-		SET total_count = total_count + $1
-		WHERE id = $2
-		RETURNING id, total_count
-	`
-	err := p.db.QueryRowx(query, in.Count, in.Id).
-		Scan(product.ID, &product.TotalCount)
-	if err != nil {
-		return nil, fmt.Errorf("failed to add product stock: %w", err)
+	if in.Count <= 0 {
+		return nil, errors.New("count must be positive")
 	}
 
-	return product, nil
+	return p.moveStock(in, in.Count)
 }
 
+// RemoveProduct takes stock out through the inventory ledger, never below zero.
 func (p *productQuantity) RemoveProduct(in *entity.CountProductReq) (*entity.ProductNumber, error) {
-	var res *entity.ProductNumber
+	if in.Count <= 0 {
+		return nil, errors.New("count must be positive")
+	}
 
-	query := `UPDATE products SET total_count = total_count - $1
-		RETURNING id, total_count`
+	return p.moveStock(in, -in.Count)
+}
 
-	err := p.db.Get(res, query, in)
+func (p *productQuantity) moveStock(in *entity.CountProductReq, quantity int) (*entity.ProductNumber, error) {
+	tx, err := p.db.Beginx()
 	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := rejectVariantParents(tx, []string{in.Id}); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	product, err := moveStock(tx, &entity.InventoryMovementRequest{
+		ProductID:     in.Id,
+		MovementType:  in.MovementType,
+		Quantity:      quantity,
+		ReferenceType: in.ReferenceType,
+		ReferenceID:   in.ReferenceID,
+		UserID:        in.UserID,
+		Note:          in.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Cost layers follow the stock: added goods come in at the average cost, removed ones leave the oldest layers
+	if quantity > 0 {
+		var unitCost float64
+		err = tx.Get(&unitCost, `SELECT average_cost FROM products WHERE id = $1`, in.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get average cost: %w", err)
+		}
+		err = addCostLayer(tx, in.Id, "", quantity, unitCost)
+	} else {
+		_, err = shrinkCostLayers(tx, in.Id, -quantity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if quantity < 0 {
+		if err := shrinkLots(tx, in.Id, -quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit stock change: %w", err)
+	}
+
+	return product, nil
 }
 
 func (p *productQuantity) GetProductCount(in *entity.ProductID) (*entity.ProductNumber, error) {
-	var res *entity.ProductNumber
+	res := &entity.ProductNumber{}
 
 	query := `SELECT id, total_count from products WHERE id = $1`
 
-	err := p.db.Get(res, query, in)
+	err := p.db.Get(res, query, in.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -275,7 +455,7 @@ func (p *productQuantity) GetProductCount(in *entity.ProductID) (*entity.Product
 func (p *productQuantity) ProductCountChecker(in *entity.CountProductReq) (bool, error) {
 	var res bool
 
-	query := `select 'true' from products where id = $1 and total_count >= $2`
+	query := `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1 AND total_count >= $2)`
 
 	err := p.db.Get(&res, query, in.Id, in.Count)
 	if err != nil {
@@ -17,20 +17,112 @@ func NewPurchasesRepo(db *sqlx.DB) usecase.PurchasesRepo {
 	return &purchasesRepoImpl{db: db}
 }
 
+const purchaseColumns = `id, supplier_id, purchased_by, total_cost, amount_paid, returned_amount,
+	COALESCE(TO_CHAR(due_date, 'YYYY-MM-DD'), '') AS due_date, COALESCE(description, '') AS description,
+	payment_method, currency, exchange_rate, created_at`
+
 func (r *purchasesRepoImpl) CreatePurchase(in *entity.PurchaseRequest) (*entity.PurchaseResponse, error) {
-	purchase := &entity.PurchaseResponse{}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	query := `INSERT INTO purchases (supplier_id, purchased_by, total_cost, payment_method, description)
-	          VALUES (:supplier_id, :purchased_by, :total_cost, :payment_method, :description) RETURNING id, created_at`
-	err := r.db.QueryRowx(query, in).StructScan(purchase)
+	purchase, err := insertPurchase(tx, in)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit purchase: %w", err)
+	}
+
+	purchase.PurchaseItem = in.PurchaseItem
+
+	return purchase, nil
+}
+
+// insertPurchase records a purchase, receives its lines into stock and cost layers and books the payment made
+// upfront. Purchase orders record each of their receipts with it.
+func insertPurchase(tx *sqlx.Tx, in *entity.PurchaseRequest) (*entity.PurchaseResponse, error) {
+	ids := make([]string, 0, len(*in.PurchaseItem))
 	for _, item := range *in.PurchaseItem {
+		ids = append(ids, item.ProductID)
+	}
+	if err := rejectVariantParents(tx, ids); err != nil {
+		return nil, err
+	}
+
+	// Срок оплаты остатка: явный или по условиям поставщика
+	var dueDate interface{}
+	if in.PaidAmount < in.TotalCost {
+		if in.DueDate != "" {
+			dueDate = in.DueDate
+		} else {
+			err := tx.QueryRowx(`SELECT CURRENT_DATE + COALESCE(payment_terms_days, 0) FROM clients WHERE id = $1`,
+				in.SupplierID).Scan(&dueDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get supplier payment terms: %w", err)
+			}
+		}
+	}
+
+	// Закупка фиксирует курс своей валюты к базовой на сегодня
+	currency, rate, err := resolveCurrency(tx, in.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	purchase := &entity.PurchaseResponse{}
+	query := `INSERT INTO purchases (supplier_id, purchased_by, total_cost, amount_paid, due_date, payment_method,
+	                                 description, currency, exchange_rate)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING ` + purchaseColumns
+	err = tx.QueryRowx(query, in.SupplierID, in.PurchasedBy, in.TotalCost, in.PaidAmount, dueDate,
+		in.PaymentMethod, in.Description, currency, rate).StructScan(purchase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create purchase: %w", err)
+	}
+
+	// Every line becomes a cost layer valued in the base currency
+	for _, item := range *in.PurchaseItem {
+		var itemID string
 		itemQuery := `INSERT INTO purchase_items (purchase_id, product_id, quantity, purchase_price, total_price)
-		              VALUES ($1, $2, $3, $4, $5)`
-		_, err := r.db.Exec(itemQuery, purchase.ID, item.ProductID, item.Quantity, item.PurchasePrice, item.TotalPrice)
+		              VALUES ($1, $2, $3, $4, $5) RETURNING id`
+		err := tx.Get(&itemID, itemQuery, purchase.ID, item.ProductID, item.Quantity, item.PurchasePrice, item.TotalPrice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create purchase item: %w", err)
+		}
+
+		err = addCostLayer(tx, item.ProductID, itemID, item.Quantity, item.PurchasePrice*rate)
+		if err != nil {
+			return nil, err
+		}
+
+		err = addLot(tx, item.ProductID, itemID, item.LotNumber, item.ExpiryDate, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = moveStock(tx, &entity.InventoryMovementRequest{
+			ProductID:     item.ProductID,
+			MovementType:  "purchase",
+			Quantity:      item.Quantity,
+			ReferenceType: "purchase",
+			ReferenceID:   purchase.ID,
+			UserID:        in.PurchasedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if in.PaidAmount > 0 {
+		_, err = addSupplierPayment(tx, purchase.ID, in.SupplierID, &entity.SupplierPaymentRequest{
+			Amount:        in.PaidAmount,
+			PaymentMethod: in.PaymentMethod,
+			PaidBy:        in.PurchasedBy,
+			Description:   "Initial payment",
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -66,7 +158,7 @@ func (r *purchasesRepoImpl) UpdatePurchase(in *entity.PurchaseUpdate) (*entity.P
 
 	// Объединяем части обновляемых полей
 	query += strings.Join(updates, ", ")
-	query += " WHERE id = :id RETURNING id, supplier_id, purchased_by, total_cost, description, payment_method, created_at"
+	query += " WHERE id = :id RETURNING " + purchaseColumns
 
 	// Выполняем запрос
 	purchase := &entity.PurchaseResponse{}
@@ -80,20 +172,24 @@ func (r *purchasesRepoImpl) UpdatePurchase(in *entity.PurchaseUpdate) (*entity.P
 
 // GetPurchase возвращает закупку по ID
 func (r *purchasesRepoImpl) GetPurchase(in *entity.PurchaseID) (*entity.PurchaseResponse, error) {
-	query := `SELECT id, supplier_id, purchased_by, total_cost, payment_method, description, created_at
-	          FROM purchases WHERE id = $1`
+	query := `SELECT ` + purchaseColumns + ` FROM purchases WHERE id = $1`
 	purchase := &entity.PurchaseResponse{}
 	err := r.db.Get(purchase, query, in.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	itemsQuery := `SELECT id, purchase_id, product_id, quantity, purchase_price, total_price
-	               FROM purchase_items WHERE purchase_id = $1`
-	err = r.db.Select(&purchase.PurchaseItem, itemsQuery, in.ID)
+	var items []entity.PurchaseItemReq
+	itemsQuery := `SELECT i.product_id, i.quantity, i.purchase_price, i.total_price,
+	                      COALESCE(l.lot_number, '') AS lot_number,
+	                      COALESCE(TO_CHAR(l.expiry_date, 'YYYY-MM-DD'), '') AS expiry_date
+	               FROM purchase_items i LEFT JOIN stock_lots l ON l.purchase_item_id = i.id
+	               WHERE i.purchase_id = $1`
+	err = r.db.Select(&items, itemsQuery, in.ID)
 	if err != nil {
 		return nil, err
 	}
+	purchase.PurchaseItem = &items
 
 	return purchase, nil
 }
@@ -106,8 +202,9 @@ func (r *purchasesRepoImpl) GetPurchaseList(in *entity.FilterPurchase) (*entity.
 
 	// Базовый запрос
 	queryBuilder.WriteString(`
-		SELECT p.id, p.supplier_id, p.purchased_by, p.total_cost, p.description, 
-		       p.payment_method, p.created_at 
+		SELECT p.id, p.supplier_id, p.purchased_by, p.total_cost, p.amount_paid, p.returned_amount,
+		       COALESCE(TO_CHAR(p.due_date, 'YYYY-MM-DD'), '') AS due_date, p.description,
+		       p.payment_method, p.currency, p.exchange_rate, p.created_at 
 		FROM purchases p JOIN purchase_items i ON p.id = i.purchase_id
 		WHERE 1=1
 	`)
@@ -147,11 +244,73 @@ func (r *purchasesRepoImpl) GetPurchaseList(in *entity.FilterPurchase) (*entity.
 }
 
 func (r *purchasesRepoImpl) DeletePurchase(in *entity.PurchaseID) (*entity.Message, error) {
-	_, err := r.db.Exec(`DELETE FROM purchase_items WHERE purchase_id = $1`, in.ID)
+	tx, err := r.db.Beginx()
 	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Goods already sent back are documented by the supplier return, which needs the purchase lines
+	var returned bool
+	err = tx.Get(&returned, `SELECT EXISTS (SELECT 1 FROM supplier_returns WHERE purchase_id = $1)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check supplier returns: %w", err)
+	}
+	if returned {
+		return nil, errors.New("goods of this purchase were returned to the supplier, the purchase cannot be deleted")
+	}
+
+	// A receipt against a purchase order keeps the quantities received on the order
+	var received bool
+	err = tx.Get(&received, `SELECT EXISTS (SELECT 1 FROM purchase_order_receipts WHERE purchase_id = $1)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check purchase order receipts: %w", err)
+	}
+	if received {
+		return nil, errors.New("the purchase was received against a purchase order and cannot be deleted")
+	}
+
+	if err := removePurchaseCostLayers(tx, in.ID); err != nil {
 		return nil, err
 	}
-	result, err := r.db.Exec(`DELETE FROM purchases WHERE id = $1`, in.ID)
+	if err := removePurchaseLots(tx, in.ID); err != nil {
+		return nil, err
+	}
+
+	// Received goods leave stock again, which fails when they were already sold
+	var items []entity.PurchaseItemReq
+	err = tx.Select(&items, `SELECT product_id, quantity FROM purchase_items WHERE purchase_id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase items: %w", err)
+	}
+	for _, item := range items {
+		_, err := moveStock(tx, &entity.InventoryMovementRequest{
+			ProductID:     item.ProductID,
+			MovementType:  "purchase",
+			Quantity:      -item.Quantity,
+			ReferenceType: "purchase",
+			ReferenceID:   in.ID,
+			Note:          "Purchase deleted",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = removeCashFlows(tx, `reference_type = 'supplier_payment'
+		AND reference_id IN (SELECT id FROM supplier_payments WHERE purchase_id = $1)`, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(`DELETE FROM supplier_payments WHERE purchase_id = $1`, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(`DELETE FROM purchase_items WHERE purchase_id = $1`, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := tx.Exec(`DELETE FROM purchases WHERE id = $1`, in.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -159,5 +318,10 @@ func (r *purchasesRepoImpl) DeletePurchase(in *entity.PurchaseID) (*entity.Messa
 	if rowsAffected == 0 {
 		return nil, errors.New("purchase not found")
 	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit purchase deletion: %w", err)
+	}
+
 	return &entity.Message{Message: "Purchase deleted successfully"}, nil
 }
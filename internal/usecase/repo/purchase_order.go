@@ -0,0 +1,441 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"strings"
+)
+
+type purchaseOrdersRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewPurchaseOrdersRepo(db *sqlx.DB) usecase.PurchaseOrdersRepo {
+	return &purchaseOrdersRepoImpl{db: db}
+}
+
+const purchaseOrderColumns = `o.id, o.order_number, o.supplier_id, COALESCE(c.full_name, '') AS supplier_name,
+	o.created_by, o.status, o.currency, COALESCE(TO_CHAR(o.expected_date, 'YYYY-MM-DD'), '') AS expected_date,
+	COALESCE(o.description, '') AS description, o.total_cost, o.created_at,
+	COALESCE(TO_CHAR(o.sent_at, 'YYYY-MM-DD"T"HH24:MI:SS'), '') AS sent_at,
+	COALESCE(TO_CHAR(o.closed_at, 'YYYY-MM-DD"T"HH24:MI:SS'), '') AS closed_at`
+
+const purchaseOrderItemColumns = `oi.id, oi.order_id, oi.product_id, p.name AS product_name, oi.quantity, oi.received,
+	GREATEST(oi.quantity - oi.received, 0) AS remaining, oi.unit_price,
+	ROUND(oi.quantity * oi.unit_price, 2) AS total_price`
+
+const purchaseOrderReceiptItemColumns = `ri.id, ri.receipt_id, ri.order_item_id, ri.product_id, p.name AS product_name,
+	ri.expected, ri.received, ri.received - ri.expected AS difference, COALESCE(ri.note, '') AS note`
+
+// lockPurchaseOrder locks an order and checks it is in one of the given statuses.
+func lockPurchaseOrder(tx *sqlx.Tx, id string, statuses ...string) error {
+	var status string
+	err := tx.Get(&status, `SELECT status FROM purchase_orders WHERE id = $1 FOR UPDATE`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("purchase order not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock purchase order: %w", err)
+	}
+
+	for _, s := range statuses {
+		if status == s {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("purchase order is %s", strings.ReplaceAll(status, "_", " "))
+}
+
+// savePurchaseOrderItems writes the lines of an order and returns its total.
+func savePurchaseOrderItems(tx *sqlx.Tx, orderID string, items []entity.PurchaseOrderItemReq) (float64, error) {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProductID)
+	}
+	if err := rejectVariantParents(tx, ids); err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, item := range items {
+		_, err := tx.Exec(`INSERT INTO purchase_order_items (order_id, product_id, quantity, unit_price)
+		                   VALUES ($1, $2, $3, $4)`, orderID, item.ProductID, item.Quantity, item.UnitPrice)
+		if err != nil {
+			return 0, fmt.Errorf("failed to save purchase order line: %w", err)
+		}
+		total += math.Round(float64(item.Quantity)*item.UnitPrice*100) / 100
+	}
+
+	return math.Round(total*100) / 100, nil
+}
+
+func (r *purchaseOrdersRepoImpl) CreatePurchaseOrder(in *entity.PurchaseOrderRequest) (*entity.PurchaseOrder, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if in.Currency == "" {
+		if in.Currency, err = baseCurrency(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	var id string
+	err = tx.Get(&id, `INSERT INTO purchase_orders (supplier_id, created_by, currency, expected_date, description,
+	                                                total_cost)
+	                   VALUES ($1, $2, $3, NULLIF($4, '')::date, NULLIF($5, ''), 0) RETURNING id`,
+		in.SupplierID, in.CreatedBy, in.Currency, in.ExpectedDate, in.Description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create purchase order: %w", err)
+	}
+
+	total, err := savePurchaseOrderItems(tx, id, in.Items)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(`UPDATE purchase_orders SET total_cost = $1 WHERE id = $2`, total, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update purchase order total: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit purchase order: %w", err)
+	}
+
+	return r.GetPurchaseOrder(&entity.PurchaseOrderID{ID: id})
+}
+
+// UpdatePurchaseOrder replaces the supplier, terms and lines of an order that was not sent yet.
+func (r *purchaseOrdersRepoImpl) UpdatePurchaseOrder(in *entity.PurchaseOrderRequest) (*entity.PurchaseOrder, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockPurchaseOrder(tx, in.ID, "draft"); err != nil {
+		return nil, err
+	}
+
+	if in.Currency == "" {
+		if in.Currency, err = baseCurrency(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(`DELETE FROM purchase_order_items WHERE order_id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear purchase order lines: %w", err)
+	}
+	total, err := savePurchaseOrderItems(tx, in.ID, in.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`UPDATE purchase_orders
+	                  SET supplier_id = $1, currency = $2, expected_date = NULLIF($3, '')::date,
+	                      description = NULLIF($4, ''), total_cost = $5
+	                  WHERE id = $6`,
+		in.SupplierID, in.Currency, in.ExpectedDate, in.Description, total, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit purchase order: %w", err)
+	}
+
+	return r.GetPurchaseOrder(&entity.PurchaseOrderID{ID: in.ID})
+}
+
+func (r *purchaseOrdersRepoImpl) GetPurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error) {
+	order := &entity.PurchaseOrder{}
+	err := r.db.Get(order, `SELECT `+purchaseOrderColumns+` FROM purchase_orders o
+	                        LEFT JOIN clients c ON c.id = o.supplier_id
+	                        WHERE o.id = $1`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("purchase order not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+
+	err = r.db.Select(&order.Items, `SELECT `+purchaseOrderItemColumns+`
+	                                 FROM purchase_order_items oi JOIN products p ON p.id = oi.product_id
+	                                 WHERE oi.order_id = $1 ORDER BY p.name`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order lines: %w", err)
+	}
+
+	err = r.db.Select(&order.Receipts, `SELECT id, order_id, purchase_id, received_by, has_differences,
+	                                           COALESCE(note, '') AS note, created_at
+	                                    FROM purchase_order_receipts WHERE order_id = $1
+	                                    ORDER BY created_at`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order receipts: %w", err)
+	}
+	for i := range order.Receipts {
+		err := r.db.Select(&order.Receipts[i].Items, `SELECT `+purchaseOrderReceiptItemColumns+`
+		                   FROM purchase_order_receipt_items ri JOIN products p ON p.id = ri.product_id
+		                   WHERE ri.receipt_id = $1 ORDER BY p.name`, order.Receipts[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get received lines: %w", err)
+		}
+	}
+
+	return order, nil
+}
+
+func (r *purchaseOrdersRepoImpl) GetPurchaseOrders(in *entity.PurchaseOrderFilter) (*entity.PurchaseOrderList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + purchaseOrderColumns + ` FROM purchase_orders o
+		LEFT JOIN clients c ON c.id = o.supplier_id
+		WHERE 1=1`)
+
+	if in.SupplierID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND o.supplier_id = $%d", argIndex))
+		args = append(args, in.SupplierID)
+		argIndex++
+	}
+	if in.Status != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND o.status = $%d", argIndex))
+		args = append(args, in.Status)
+		argIndex++
+	}
+	if in.From != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND o.created_at >= $%d::date", argIndex))
+		args = append(args, in.From)
+		argIndex++
+	}
+	if in.To != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND o.created_at < $%d::date + 1", argIndex))
+		args = append(args, in.To)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY o.created_at DESC")
+
+	list := &entity.PurchaseOrderList{Orders: []entity.PurchaseOrder{}}
+	err := r.db.Select(&list.Orders, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purchase orders: %w", err)
+	}
+
+	return list, nil
+}
+
+// setPurchaseOrderStatus moves an order from one of the given statuses to the next one.
+func (r *purchaseOrdersRepoImpl) setPurchaseOrderStatus(id, status, column string, from ...string) (*entity.PurchaseOrder, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockPurchaseOrder(tx, id, from...); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`UPDATE purchase_orders SET status = $1, `+column+` = NOW() WHERE id = $2`, status, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update purchase order status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit purchase order: %w", err)
+	}
+
+	return r.GetPurchaseOrder(&entity.PurchaseOrderID{ID: id})
+}
+
+func (r *purchaseOrdersRepoImpl) SendPurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error) {
+	return r.setPurchaseOrderStatus(in.ID, "sent", "sent_at", "draft")
+}
+
+// ClosePurchaseOrder stops waiting for the rest of a partially received order.
+func (r *purchaseOrdersRepoImpl) ClosePurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error) {
+	return r.setPurchaseOrderStatus(in.ID, "closed", "closed_at", "partially_received")
+}
+
+// CancelPurchaseOrder drops an order nothing was received against.
+func (r *purchaseOrdersRepoImpl) CancelPurchaseOrder(in *entity.PurchaseOrderID) (*entity.PurchaseOrder, error) {
+	return r.setPurchaseOrderStatus(in.ID, "cancelled", "closed_at", "draft", "sent")
+}
+
+// ReceivePurchaseOrder records what arrived against an open order. Every line still expected is part of the
+// receipt, lines left out count as not delivered, and any line received short or over flags the receipt.
+// The goods received become a purchase at the ordered prices, which brings them into stock.
+func (r *purchaseOrdersRepoImpl) ReceivePurchaseOrder(in *entity.PurchaseOrderReceiptRequest) (*entity.PurchaseOrderReceipt, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockPurchaseOrder(tx, in.OrderID, "sent", "partially_received"); err != nil {
+		return nil, err
+	}
+
+	var order struct {
+		Number     int    `db:"order_number"`
+		SupplierID string `db:"supplier_id"`
+		Currency   string `db:"currency"`
+	}
+	err = tx.Get(&order, `SELECT order_number, supplier_id, currency FROM purchase_orders WHERE id = $1`, in.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+
+	var lines []entity.PurchaseOrderItem
+	err = tx.Select(&lines, `SELECT `+purchaseOrderItemColumns+`
+	                         FROM purchase_order_items oi JOIN products p ON p.id = oi.product_id
+	                         WHERE oi.order_id = $1 ORDER BY p.name`, in.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order lines: %w", err)
+	}
+
+	onOrder := map[string]bool{}
+	for _, line := range lines {
+		onOrder[line.ID] = true
+	}
+	received := map[string]entity.PurchaseOrderReceiptItemReq{}
+	for _, item := range in.Items {
+		if !onOrder[item.OrderItemID] {
+			return nil, fmt.Errorf("line %s is not part of the purchase order", item.OrderItemID)
+		}
+		if _, ok := received[item.OrderItemID]; ok {
+			return nil, fmt.Errorf("line %s is received twice", item.OrderItemID)
+		}
+		received[item.OrderItemID] = item
+	}
+
+	var items []entity.PurchaseOrderReceiptItem
+	var purchaseItems []entity.PurchaseItemReq
+	var total float64
+	complete := true
+	for _, line := range lines {
+		req, listed := received[line.ID]
+		if !listed && line.Remaining == 0 {
+			continue
+		}
+
+		items = append(items, entity.PurchaseOrderReceiptItem{
+			OrderItemID: line.ID,
+			ProductID:   line.ProductID,
+			ProductName: line.ProductName,
+			Expected:    line.Remaining,
+			Received:    req.Quantity,
+			Difference:  req.Quantity - line.Remaining,
+			Note:        req.Note,
+		})
+		if line.Received+req.Quantity < line.Quantity {
+			complete = false
+		}
+
+		if req.Quantity > 0 {
+			item := entity.PurchaseItemReq{
+				ProductID:     line.ProductID,
+				Quantity:      req.Quantity,
+				PurchasePrice: line.UnitPrice,
+				TotalPrice:    math.Round(float64(req.Quantity)*line.UnitPrice*100) / 100,
+				LotNumber:     req.LotNumber,
+				ExpiryDate:    req.ExpiryDate,
+			}
+			purchaseItems = append(purchaseItems, item)
+			total += item.TotalPrice
+		}
+	}
+	if len(purchaseItems) == 0 {
+		return nil, errors.New("nothing was received")
+	}
+	total = math.Round(total*100) / 100
+
+	// Without credit the receipt is paid in full on delivery
+	paid := total
+	if in.OnCredit {
+		if in.PaidAmount < 0 || in.PaidAmount > total {
+			return nil, fmt.Errorf("paid amount must be between 0 and %.2f", total)
+		}
+		paid = in.PaidAmount
+	}
+
+	purchase, err := insertPurchase(tx, &entity.PurchaseRequest{
+		SupplierID:    order.SupplierID,
+		PurchasedBy:   in.ReceivedBy,
+		TotalCost:     total,
+		Currency:      order.Currency,
+		PaidAmount:    paid,
+		DueDate:       in.DueDate,
+		Description:   fmt.Sprintf("Receipt for purchase order #%d", order.Number),
+		PaymentMethod: in.PaymentMethod,
+		PurchaseItem:  &purchaseItems,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hasDifferences := false
+	for _, item := range items {
+		if item.Difference != 0 {
+			hasDifferences = true
+		}
+	}
+
+	receipt := &entity.PurchaseOrderReceipt{}
+	err = tx.Get(receipt, `INSERT INTO purchase_order_receipts (order_id, purchase_id, received_by, has_differences,
+	                                                             note)
+	                       VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+	                       RETURNING id, order_id, purchase_id, received_by, has_differences,
+	                                 COALESCE(note, '') AS note, created_at`,
+		in.OrderID, purchase.ID, in.ReceivedBy, hasDifferences, in.Note)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create receipt: %w", err)
+	}
+
+	for i := range items {
+		item := &items[i]
+		item.ReceiptID = receipt.ID
+		err := tx.Get(&item.ID, `INSERT INTO purchase_order_receipt_items (receipt_id, order_item_id, product_id,
+		                                                                    expected, received, note)
+		                         VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')) RETURNING id`,
+			receipt.ID, item.OrderItemID, item.ProductID, item.Expected, item.Received, item.Note)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save received line: %w", err)
+		}
+
+		_, err = tx.Exec(`UPDATE purchase_order_items SET received = received + $1 WHERE id = $2`,
+			item.Received, item.OrderItemID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update received quantity: %w", err)
+		}
+	}
+
+	status := "partially_received"
+	if complete {
+		status = "received"
+	}
+	_, err = tx.Exec(`UPDATE purchase_orders
+	                  SET status = $1, closed_at = CASE WHEN $1 = 'received' THEN NOW() END
+	                  WHERE id = $2`, status, in.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update purchase order status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit receipt: %w", err)
+	}
+
+	receipt.Items = items
+	return receipt, nil
+}
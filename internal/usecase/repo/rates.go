@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"strings"
+)
+
+type ratesRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewRatesRepo(db *sqlx.DB) usecase.RatesRepo {
+	return &ratesRepoImpl{db: db}
+}
+
+const exchangeRateColumns = `id, currency, rate, TO_CHAR(rate_date, 'YYYY-MM-DD') AS rate_date, source, created_at`
+
+// baseCurrency returns the currency all reports are converted to.
+func baseCurrency(q sqlx.Queryer) (string, error) {
+	var base string
+	err := sqlx.Get(q, &base, `SELECT value FROM settings WHERE key = 'base_currency'`)
+	if err != nil {
+		return "", fmt.Errorf("failed to get base currency: %w", err)
+	}
+
+	return base, nil
+}
+
+// resolveCurrency defaults an empty currency to the base one and returns the rate to fix on a document:
+// base currency units per unit of the currency, taken from the latest rate up to today.
+func resolveCurrency(q sqlx.Queryer, currency string) (string, float64, error) {
+	base, err := baseCurrency(q)
+	if err != nil {
+		return "", 0, err
+	}
+	if currency == "" || currency == base {
+		return base, 1, nil
+	}
+
+	var rate float64
+	err = sqlx.Get(q, &rate, `SELECT rate FROM exchange_rates
+	                          WHERE currency = $1 AND rate_date <= CURRENT_DATE
+	                          ORDER BY rate_date DESC LIMIT 1`, currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", 0, fmt.Errorf("no exchange rate for %s", currency)
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	return currency, rate, nil
+}
+
+// convertAmount converts an amount between currencies given their rates to the base currency.
+func convertAmount(amount, fromRate, toRate float64) float64 {
+	return math.Round(amount*fromRate/toRate*100) / 100
+}
+
+func (r *ratesRepoImpl) SaveRates(in []entity.ExchangeRateRequest, source string) ([]entity.ExchangeRate, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var rates []entity.ExchangeRate
+	query := `INSERT INTO exchange_rates (currency, rate, rate_date, source)
+	          VALUES ($1, $2, COALESCE(NULLIF($3, '')::date, CURRENT_DATE), $4)
+	          ON CONFLICT (currency, rate_date) DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source
+	          RETURNING ` + exchangeRateColumns
+	for _, rate := range in {
+		var saved entity.ExchangeRate
+		err := tx.QueryRowx(query, rate.Currency, rate.Rate, rate.RateDate, source).StructScan(&saved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save exchange rate: %w", err)
+		}
+		rates = append(rates, saved)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit exchange rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (r *ratesRepoImpl) GetRates(in *entity.ExchangeRateFilter) (*entity.ExchangeRateList, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + exchangeRateColumns + ` FROM exchange_rates WHERE 1=1`)
+
+	if in.Currency != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND currency = $%d", argIndex))
+		args = append(args, in.Currency)
+		argIndex++
+	}
+	if in.From != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND rate_date >= $%d", argIndex))
+		args = append(args, in.From)
+		argIndex++
+	}
+	if in.To != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND rate_date <= $%d", argIndex))
+		args = append(args, in.To)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY rate_date DESC, currency")
+
+	rates := &entity.ExchangeRateList{BaseCurrency: base}
+	err = r.db.Select(&rates.Rates, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (r *ratesRepoImpl) GetBaseCurrency() (*entity.BaseCurrency, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.BaseCurrency{Currency: base}, nil
+}
+
+func (r *ratesRepoImpl) SetBaseCurrency(in *entity.BaseCurrency) (*entity.BaseCurrency, error) {
+	// Stored rates and the rates fixed on documents are all relative to the base currency
+	var used bool
+	err := r.db.Get(&used, `SELECT EXISTS (SELECT 1 FROM exchange_rates)
+	                        OR EXISTS (SELECT 1 FROM sales WHERE currency <> $1)
+	                        OR EXISTS (SELECT 1 FROM purchases WHERE currency <> $1)`, in.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check exchange rate usage: %w", err)
+	}
+	if used {
+		return nil, errors.New("base currency cannot be changed once exchange rates or documents in other currencies exist")
+	}
+
+	_, err = r.db.Exec(`UPDATE settings SET value = $1 WHERE key = 'base_currency'`, in.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set base currency: %w", err)
+	}
+
+	return in, nil
+}
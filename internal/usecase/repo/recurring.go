@@ -0,0 +1,207 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type recurringRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewRecurringRepo(db *sqlx.DB) usecase.RecurringRepo {
+	return &recurringRepoImpl{db: db}
+}
+
+const recurringExpenseColumns = `e.id, e.name, e.amount, e.category_id, c.name AS category_name,
+	e.wallet_id, w.name AS wallet_name, w.currency, e.schedule, TO_CHAR(e.start_date, 'YYYY-MM-DD') AS start_date,
+	COALESCE(TO_CHAR(e.end_date, 'YYYY-MM-DD'), '') AS end_date, COALESCE(e.description, '') AS description,
+	e.is_active, e.created_by,
+	COALESCE((SELECT TO_CHAR(MAX(r.scheduled_at), 'YYYY-MM-DD HH24:MI:SS')
+	          FROM recurring_expense_runs r WHERE r.expense_id = e.id), '') AS last_posted_at,
+	e.created_at`
+
+const recurringExpenseFrom = ` FROM recurring_expenses e
+	JOIN cash_category c ON c.id = e.category_id
+	JOIN wallets w ON w.id = e.wallet_id`
+
+// checkExpenseCategory allows only manual categories that are not reserved for income.
+func checkExpenseCategory(q sqlx.Queryer, categoryID string) error {
+	var categoryType, code string
+	err := q.QueryRowx(`SELECT COALESCE(transaction_type::text, ''), COALESCE(code, '') FROM cash_category WHERE id = $1`,
+		categoryID).Scan(&categoryType, &code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("cash category not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check cash category: %w", err)
+	}
+
+	if code != "" {
+		return fmt.Errorf("category %q is reserved for automatic postings", code)
+	}
+	if categoryType == "income" {
+		return errors.New("category is for income entries")
+	}
+
+	return nil
+}
+
+func (r *recurringRepoImpl) getExpense(q sqlx.Queryer, id string) (*entity.RecurringExpense, error) {
+	expense := &entity.RecurringExpense{}
+	err := sqlx.Get(q, expense, `SELECT `+recurringExpenseColumns+recurringExpenseFrom+` WHERE e.id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("recurring expense not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring expense: %w", err)
+	}
+
+	return expense, nil
+}
+
+func (r *recurringRepoImpl) CreateRecurringExpense(in *entity.RecurringExpenseRequest) (*entity.RecurringExpense, error) {
+	if err := checkExpenseCategory(r.db, in.CategoryID); err != nil {
+		return nil, err
+	}
+
+	var id string
+	query := `INSERT INTO recurring_expenses (name, amount, category_id, wallet_id, schedule, start_date, end_date,
+	                                          description, is_active, created_by)
+	          VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, '')::date, CURRENT_DATE), NULLIF($7, '')::date,
+	                  NULLIF($8, ''), $9, $10)
+	          RETURNING id`
+	err := r.db.Get(&id, query, in.Name, in.Amount, in.CategoryID, in.WalletID, in.Schedule, in.StartDate,
+		in.EndDate, in.Description, in.IsActive, in.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recurring expense: %w", err)
+	}
+
+	return r.getExpense(r.db, id)
+}
+
+func (r *recurringRepoImpl) UpdateRecurringExpense(in *entity.RecurringExpenseRequest) (*entity.RecurringExpense, error) {
+	if err := checkExpenseCategory(r.db, in.CategoryID); err != nil {
+		return nil, err
+	}
+
+	// Past postings stay as they were, the new terms apply to the next runs
+	query := `UPDATE recurring_expenses
+	          SET name = $1, amount = $2, category_id = $3, wallet_id = $4, schedule = $5,
+	              start_date = COALESCE(NULLIF($6, '')::date, start_date), end_date = NULLIF($7, '')::date,
+	              description = NULLIF($8, ''), is_active = $9
+	          WHERE id = $10`
+	result, err := r.db.Exec(query, in.Name, in.Amount, in.CategoryID, in.WalletID, in.Schedule, in.StartDate,
+		in.EndDate, in.Description, in.IsActive, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update recurring expense: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, errors.New("recurring expense not found")
+	}
+
+	return r.getExpense(r.db, in.ID)
+}
+
+func (r *recurringRepoImpl) DeleteRecurringExpense(in *entity.RecurringExpenseID) (*entity.Message, error) {
+	var posted bool
+	err := r.db.Get(&posted, `SELECT EXISTS (SELECT 1 FROM recurring_expense_runs WHERE expense_id = $1)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check recurring expense postings: %w", err)
+	}
+	if posted {
+		return nil, errors.New("recurring expense has postings, deactivate it instead")
+	}
+
+	result, err := r.db.Exec(`DELETE FROM recurring_expenses WHERE id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete recurring expense: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, errors.New("recurring expense not found")
+	}
+
+	return &entity.Message{Message: "Recurring expense deleted successfully"}, nil
+}
+
+func (r *recurringRepoImpl) GetRecurringExpense(in *entity.RecurringExpenseID) (*entity.RecurringExpense, error) {
+	return r.getExpense(r.db, in.ID)
+}
+
+func (r *recurringRepoImpl) GetRecurringExpenses() (*entity.RecurringExpenseList, error) {
+	expenses := &entity.RecurringExpenseList{}
+	err := r.db.Select(&expenses.Expenses, `SELECT `+recurringExpenseColumns+recurringExpenseFrom+` ORDER BY e.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring expenses: %w", err)
+	}
+
+	return expenses, nil
+}
+
+// PostRecurringExpense posts one scheduled run to the cash flow. A run that was already posted is skipped
+// and reported as false, so catching up after downtime never doubles an expense.
+func (r *recurringRepoImpl) PostRecurringExpense(id string, scheduledAt time.Time) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	at := scheduledAt.Format("2006-01-02 15:04:05")
+
+	var runID string
+	err = tx.Get(&runID, `INSERT INTO recurring_expense_runs (expense_id, scheduled_at) VALUES ($1, $2)
+	                      ON CONFLICT (expense_id, scheduled_at) DO NOTHING RETURNING id`, id, at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record recurring expense run: %w", err)
+	}
+
+	var expense entity.RecurringExpenseRequest
+	err = tx.Get(&expense, `SELECT id, name, amount, category_id, wallet_id, COALESCE(description, '') AS description,
+	                               created_by
+	                        FROM recurring_expenses WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to get recurring expense: %w", err)
+	}
+
+	description := expense.Name
+	if expense.Description != "" {
+		description += ": " + expense.Description
+	}
+
+	flowID, err := insertCashFlow(tx, &entity.CashFlowRequest{
+		UserID:          expense.CreatedBy,
+		Amount:          expense.Amount,
+		TransactionType: "expense",
+		CategoryID:      expense.CategoryID,
+		Description:     description,
+		WalletID:        expense.WalletID,
+		TransactionDate: at,
+		ReferenceType:   "recurring_expense",
+		ReferenceID:     runID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(`UPDATE recurring_expense_runs SET cash_flow_id = $1 WHERE id = $2`, flowID, runID)
+	if err != nil {
+		return false, fmt.Errorf("failed to link recurring expense run: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit recurring expense run: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,177 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+const reminderRuleColumns = `id, name, kind, days, channel, template_uz, template_ru, is_active, created_at`
+
+type remindersRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewRemindersRepo(db *sqlx.DB) usecase.RemindersRepo {
+	return &remindersRepoImpl{db: db}
+}
+
+func (r *remindersRepoImpl) CreateReminderRule(in *entity.ReminderRule) (*entity.ReminderRule, error) {
+	rule := &entity.ReminderRule{}
+	query := `INSERT INTO reminder_rules (name, kind, days, channel, template_uz, template_ru)
+	          VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + reminderRuleColumns
+	err := r.db.QueryRowx(query, in.Name, in.Kind, in.Days, in.Channel, in.TemplateUz, in.TemplateRu).StructScan(rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reminder rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (r *remindersRepoImpl) UpdateReminderRule(in *entity.ReminderRule) (*entity.ReminderRule, error) {
+	rule := &entity.ReminderRule{}
+	query := `UPDATE reminder_rules
+	          SET name = $1, kind = $2, days = $3, channel = $4, template_uz = $5, template_ru = $6, is_active = $7
+	          WHERE id = $8 RETURNING ` + reminderRuleColumns
+	err := r.db.QueryRowx(query, in.Name, in.Kind, in.Days, in.Channel, in.TemplateUz, in.TemplateRu, in.IsActive, in.ID).
+		StructScan(rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reminder rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (r *remindersRepoImpl) GetReminderRuleList() (*entity.ReminderRuleList, error) {
+	var rules []entity.ReminderRule
+	err := r.db.Select(&rules, `SELECT `+reminderRuleColumns+` FROM reminder_rules ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminder rules: %w", err)
+	}
+
+	return &entity.ReminderRuleList{Rules: rules}, nil
+}
+
+func (r *remindersRepoImpl) DeleteReminderRule(in *entity.ReminderRuleID) (*entity.Message, error) {
+	// Rules that already produced reminders are deactivated to keep the delivery log intact
+	res, err := r.db.Exec(`UPDATE reminder_rules SET is_active = FALSE
+	                       WHERE id = $1 AND EXISTS (SELECT 1 FROM reminder_logs WHERE rule_id = $1)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete reminder rule: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows > 0 {
+		return &entity.Message{Message: "Reminder rule deactivated"}, nil
+	}
+
+	res, err = r.db.Exec(`DELETE FROM reminder_rules WHERE id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete reminder rule: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return nil, errors.New("reminder rule not found")
+	}
+
+	return &entity.Message{Message: "Reminder rule deleted successfully"}, nil
+}
+
+// GetDueReminders matches active rules against open installments and unscheduled debts for today.
+// Reminders already sent today for the same rule and installment are skipped.
+func (r *remindersRepoImpl) GetDueReminders() ([]entity.DueReminder, error) {
+	var reminders []entity.DueReminder
+	query := `
+		WITH due_items AS (
+			SELECT d.id AS debt_id, i.id AS installment_id, i.due_date, i.amount - i.amount_paid AS amount, s.currency,
+			       s.client_id
+			FROM debt_installments i
+			JOIN debts d ON d.id = i.debt_id
+			JOIN sales s ON s.id = d.order_id
+			WHERE NOT i.is_paid AND NOT d.is_fully_paid
+			UNION ALL
+			SELECT d.id, NULL, d.next_payment, d.amount_unpaid, s.currency, s.client_id
+			FROM debts d
+			JOIN sales s ON s.id = d.order_id
+			WHERE NOT d.is_fully_paid AND d.next_payment IS NOT NULL
+			  AND NOT EXISTS (SELECT 1 FROM debt_installments i WHERE i.debt_id = d.id)
+		)
+		SELECT r.id AS rule_id, r.channel,
+		       CASE WHEN c.language = 'ru' THEN r.template_ru ELSE r.template_uz END AS template,
+		       di.debt_id, COALESCE(di.installment_id::text, '') AS installment_id,
+		       TO_CHAR(di.due_date, 'YYYY-MM-DD') AS due_date, di.amount, di.currency, c.full_name AS client_name,
+		       COALESCE(CASE WHEN r.channel = 'telegram' THEN c.telegram_chat_id ELSE c.phone END, '') AS recipient,
+		       GREATEST(CURRENT_DATE - di.due_date, 0) AS days_overdue
+		FROM reminder_rules r
+		JOIN due_items di ON (r.kind = 'before_due' AND di.due_date = CURRENT_DATE + r.days)
+		                  OR (r.kind = 'on_due' AND di.due_date = CURRENT_DATE)
+		                  OR (r.kind = 'overdue' AND di.due_date < CURRENT_DATE
+		                      AND (CURRENT_DATE - di.due_date) % GREATEST(r.days, 1) = 0)
+		JOIN clients c ON c.id = di.client_id
+		WHERE r.is_active
+		  AND NOT EXISTS (SELECT 1 FROM reminder_logs l
+		                  WHERE l.rule_id = r.id AND l.debt_id = di.debt_id
+		                    AND l.installment_id IS NOT DISTINCT FROM di.installment_id
+		                    AND l.status = 'sent' AND l.created_at::date = CURRENT_DATE)`
+
+	err := r.db.Select(&reminders, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+func (r *remindersRepoImpl) LogReminder(in *entity.ReminderLog) error {
+	query := `INSERT INTO reminder_logs (rule_id, debt_id, installment_id, due_date, channel, recipient, message, status, error)
+	          VALUES (NULLIF($1, '')::uuid, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::date, $5, $6, $7, $8, NULLIF($9, ''))`
+	_, err := r.db.Exec(query, in.RuleID, in.DebtID, in.InstallmentID, in.DueDate, in.Channel, in.Recipient,
+		in.Message, in.Status, in.Error)
+	if err != nil {
+		return fmt.Errorf("failed to log reminder: %w", err)
+	}
+
+	return nil
+}
+
+func (r *remindersRepoImpl) GetReminderLogs(in *entity.ReminderLogFilter) (*entity.ReminderLogList, error) {
+	var logs []entity.ReminderLog
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`
+		SELECT id, COALESCE(rule_id::text, '') AS rule_id, debt_id, COALESCE(installment_id::text, '') AS installment_id,
+		       COALESCE(TO_CHAR(due_date, 'YYYY-MM-DD'), '') AS due_date, channel, COALESCE(recipient, '') AS recipient,
+		       message, status, COALESCE(error, '') AS error, created_at
+		FROM reminder_logs
+		WHERE 1=1
+	`)
+
+	if in.DebtID != "" {
+		queryBuilder.WriteString(" AND debt_id = $" + fmt.Sprint(argIndex))
+		args = append(args, in.DebtID)
+		argIndex++
+	}
+
+	if in.Status != "" {
+		queryBuilder.WriteString(" AND status = $" + fmt.Sprint(argIndex))
+		args = append(args, in.Status)
+		argIndex++
+	}
+
+	if in.Channel != "" {
+		queryBuilder.WriteString(" AND channel = $" + fmt.Sprint(argIndex))
+		args = append(args, in.Channel)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY created_at DESC")
+
+	err := r.db.Select(&logs, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminder logs: %w", err)
+	}
+
+	return &entity.ReminderLogList{Logs: logs}, nil
+}
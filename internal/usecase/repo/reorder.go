@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+type reorderRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewReorderRepo(db *sqlx.DB) usecase.ReorderRepo {
+	return &reorderRepoImpl{db: db}
+}
+
+const reorderPointColumns = `p.id AS product_id, p.name AS product_name, COALESCE(p.total_count, 0) AS stock,
+	p.min_stock, p.reorder_qty, COALESCE(p.preferred_supplier_id::text, '') AS preferred_supplier_id,
+	COALESCE(c.full_name, '') AS supplier_name`
+
+func (r *reorderRepoImpl) SetReorderPoint(in *entity.ReorderPoint) (*entity.ReorderPoint, error) {
+	point := &entity.ReorderPoint{}
+	query := `WITH p AS (
+	              UPDATE products
+	              SET min_stock = $1, reorder_qty = $2, preferred_supplier_id = NULLIF($3, '')::uuid
+	              WHERE id = $4
+	              RETURNING *
+	          )
+	          SELECT ` + reorderPointColumns + ` FROM p LEFT JOIN clients c ON c.id = p.preferred_supplier_id`
+	err := r.db.Get(point, query, in.MinStock, in.ReorderQty, in.PreferredSupplierID, in.ProductID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("product not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to set reorder point: %w", err)
+	}
+
+	return point, nil
+}
+
+func (r *reorderRepoImpl) GetReorderPoint(in *entity.ProductID) (*entity.ReorderPoint, error) {
+	point := &entity.ReorderPoint{}
+	err := r.db.Get(point, `SELECT `+reorderPointColumns+` FROM products p
+	                        LEFT JOIN clients c ON c.id = p.preferred_supplier_id
+	                        WHERE p.id = $1`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("product not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reorder point: %w", err)
+	}
+
+	return point, nil
+}
+
+func (r *reorderRepoImpl) GetLowStock(in *entity.LowStockFilter) (*entity.LowStockList, error) {
+	query := `SELECT p.id AS product_id, p.name AS product_name, p.category_id, COALESCE(p.total_count, 0) AS stock,
+	                 p.min_stock, COALESCE(p.reorder_qty, 0) AS reorder_qty,
+	                 p.min_stock - COALESCE(p.total_count, 0) AS shortfall,
+	                 COALESCE(p.preferred_supplier_id::text, '') AS preferred_supplier_id,
+	                 COALESCE(c.full_name, '') AS supplier_name
+	          FROM products p
+	          LEFT JOIN clients c ON c.id = p.preferred_supplier_id
+	          WHERE p.min_stock IS NOT NULL AND COALESCE(p.total_count, 0) <= p.min_stock
+	            AND ($1 = '' OR p.category_id = NULLIF($1, '')::uuid)
+	          ORDER BY COALESCE(p.total_count, 0) = 0 DESC, shortfall DESC, p.name`
+
+	list := &entity.LowStockList{Products: []entity.LowStockItem{}}
+	err := r.db.Select(&list.Products, query, in.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list low stock products: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetReorderCandidates returns the watched products and those sold in the last days with their net sales.
+// Products are ordered from their preferred supplier, otherwise from the supplier they were last bought
+// from, at the last purchase price; products never bought fall back to their incoming price.
+func (r *reorderRepoImpl) GetReorderCandidates(days int, supplierID string) ([]entity.ReorderCandidate, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `WITH sold AS (
+	              SELECT si.product_id, SUM(si.quantity) AS quantity
+	              FROM sales_items si JOIN sales s ON s.id = si.sale_id
+	              WHERE s.created_at >= NOW() - make_interval(days => $1::int)
+	              GROUP BY si.product_id
+	          ), returned AS (
+	              SELECT ri.product_id, SUM(ri.quantity) AS quantity
+	              FROM sale_return_items ri JOIN sale_returns sr ON sr.id = ri.return_id
+	              WHERE sr.created_at >= NOW() - make_interval(days => $1::int)
+	              GROUP BY ri.product_id
+	          ), last_purchase AS (
+	              SELECT DISTINCT ON (pi.product_id) pi.product_id, pu.supplier_id, pi.purchase_price, pu.currency
+	              FROM purchase_items pi JOIN purchases pu ON pu.id = pi.purchase_id
+	              ORDER BY pi.product_id, pu.created_at DESC
+	          ), candidates AS (
+	              SELECT p.id AS product_id, p.name AS product_name, COALESCE(p.total_count, 0) AS stock,
+	                     COALESCE(p.min_stock, 0) AS min_stock, COALESCE(p.reorder_qty, 0) AS reorder_qty,
+	                     p.min_stock IS NOT NULL AS is_watched,
+	                     GREATEST(COALESCE(s.quantity, 0) - COALESCE(rt.quantity, 0), 0) AS sold,
+	                     COALESCE(p.preferred_supplier_id, lp.supplier_id) AS supplier_id,
+	                     COALESCE(lp.purchase_price, p.incoming_price) AS unit_price,
+	                     COALESCE(lp.currency, $2) AS currency
+	              FROM products p
+	              LEFT JOIN sold s ON s.product_id = p.id
+	              LEFT JOIN returned rt ON rt.product_id = p.id
+	              LEFT JOIN last_purchase lp ON lp.product_id = p.id
+	              WHERE p.min_stock IS NOT NULL OR s.quantity > 0
+	          )
+	          SELECT k.product_id, k.product_name, k.stock, k.min_stock, k.reorder_qty, k.is_watched, k.sold,
+	                 COALESCE(k.supplier_id::text, '') AS supplier_id, COALESCE(c.full_name, '') AS supplier_name,
+	                 k.unit_price, k.currency
+	          FROM candidates k
+	          LEFT JOIN clients c ON c.id = k.supplier_id
+	          WHERE $3 = '' OR k.supplier_id = NULLIF($3, '')::uuid
+	          ORDER BY supplier_name, k.product_name`
+
+	var rows []entity.ReorderCandidate
+	err = r.db.Select(&rows, query, days, base, supplierID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reorder candidates: %w", err)
+	}
+
+	return rows, nil
+}
+
+func (r *reorderRepoImpl) GetStorekeeperPhones() ([]string, error) {
+	var phones []string
+	err := r.db.Select(&phones, `SELECT phone_number FROM users
+	                             WHERE role = 'storekeeper' AND COALESCE(phone_number, '') <> ''`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storekeeper phones: %w", err)
+	}
+
+	return phones, nil
+}
+
+func (r *reorderRepoImpl) LogStockAlert(in *entity.StockAlert) error {
+	query := `INSERT INTO stock_alerts (products, channel, recipient, status, error)
+	          VALUES ($1, $2, $3, $4, NULLIF($5, ''))`
+	_, err := r.db.Exec(query, in.Products, in.Channel, in.Recipient, in.Status, in.Error)
+	if err != nil {
+		return fmt.Errorf("failed to log stock alert: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reorderRepoImpl) GetStockAlerts() (*entity.StockAlertList, error) {
+	alerts := &entity.StockAlertList{}
+	err := r.db.Select(&alerts.Alerts, `SELECT id, products, channel, recipient, status, COALESCE(error, '') AS error,
+	                                           sent_at
+	                                    FROM stock_alerts ORDER BY sent_at DESC LIMIT 100`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock alerts: %w", err)
+	}
+
+	return alerts, nil
+}
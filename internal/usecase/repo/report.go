@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type reportsRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewReportsRepo(db *sqlx.DB) usecase.ReportsRepo {
+	return &reportsRepoImpl{db: db}
+}
+
+// branchJoins find the branch of a sale or cash flow entry through the register of its shift.
+const branchJoins = ` LEFT JOIN shifts sh ON sh.id = %[1]s.shift_id
+	LEFT JOIN registers rg ON rg.id = sh.register_id
+	LEFT JOIN branches b ON b.id = rg.branch_id`
+
+// pnlGroups returns the key and name columns and the joins of a breakdown of sales.
+func pnlGroups(groupBy string) (string, string, string) {
+	switch groupBy {
+	case "category":
+		return `p.category_id::text`, `pc.name`, ` JOIN product_categories pc ON pc.id = p.category_id`
+	case "seller":
+		return `s.sold_by::text`, `u.first_name || ' ' || u.last_name`, ` JOIN users u ON u.user_id = s.sold_by`
+	default:
+		return `COALESCE(b.id::text, '')`, `COALESCE(b.name, '')`, fmt.Sprintf(branchJoins, "s")
+	}
+}
+
+func (r *reportsRepoImpl) GetBaseCurrency() (string, error) {
+	return baseCurrency(r.db)
+}
+
+// GetPnLSales returns revenue and the cost of goods sold stored on the sold lines, in the base currency,
+// for sales made in [from, to).
+func (r *reportsRepoImpl) GetPnLSales(from, to time.Time, groupBy string) ([]entity.PnLSalesRow, error) {
+	key, name, joins := pnlGroups(groupBy)
+
+	query := `SELECT ` + key + ` AS key, ` + name + ` AS name,
+	                 COALESCE(ROUND(SUM(si.total_price * s.exchange_rate), 2), 0) AS revenue,
+	                 COALESCE(SUM(si.cogs), 0) AS cogs
+	          FROM sales s
+	          JOIN sales_items si ON si.sale_id = s.id
+	          JOIN products p ON p.id = si.product_id` + joins + `
+	          WHERE s.created_at >= $1 AND s.created_at < $2
+	          GROUP BY 1, 2`
+
+	var rows []entity.PnLSalesRow
+	err := r.db.Select(&rows, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales for profit and loss: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetPnLReturns returns the value of goods returned in [from, to) and the cost of those put back into stock,
+// in the base currency at the rate of their sales. Returns are attributed like the sales they came from.
+func (r *reportsRepoImpl) GetPnLReturns(from, to time.Time, groupBy string) ([]entity.PnLReturnRow, error) {
+	key, name, joins := pnlGroups(groupBy)
+
+	query := `SELECT ` + key + ` AS key, ` + name + ` AS name,
+	                 COALESCE(ROUND(SUM(ri.total_price * s.exchange_rate), 2), 0) AS returns,
+	                 COALESCE(SUM(ri.cogs) FILTER (WHERE ri.disposition = 'restock'), 0) AS cogs
+	          FROM sale_returns sr
+	          JOIN sale_return_items ri ON ri.return_id = sr.id
+	          JOIN sales s ON s.id = sr.sale_id
+	          JOIN products p ON p.id = ri.product_id` + joins + `
+	          WHERE sr.created_at >= $1 AND sr.created_at < $2
+	          GROUP BY 1, 2`
+
+	var rows []entity.PnLReturnRow
+	err := r.db.Select(&rows, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returns for profit and loss: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetPnLExpenses returns operating expenses in the base currency for [from, to) by cash category.
+// Supplier payments are left out since goods are counted through their cost when sold, refunds of returns
+// since they are netted from revenue, and transfers between wallets. Only the branch breakdown attributes
+// expenses, through the recording shift. The cost of approved write-offs is a loss without a cash movement
+// and is reported in the write-off category.
+func (r *reportsRepoImpl) GetPnLExpenses(from, to time.Time, groupBy string) ([]entity.PnLExpenseRow, error) {
+	key, name, joins := `''`, `''`, ``
+	if groupBy == "branch" {
+		key, name, joins = `COALESCE(b.id::text, '')`, `COALESCE(b.name, '')`, fmt.Sprintf(branchJoins, "f")
+	}
+
+	query := `SELECT ` + key + ` AS key, ` + name + ` AS name, c.id AS category_id, c.name AS category_name,
+	                 ROUND(SUM(f.amount * f.exchange_rate), 2) AS amount
+	          FROM cash_flow f
+	          JOIN cash_category c ON c.id = f.category_id` + joins + `
+	          WHERE f.transaction_type = 'expense'
+	            AND COALESCE(c.code, '') NOT IN ('supplier_payment', 'sales_return', 'transfer')
+	            AND f.transaction_date >= $1 AND f.transaction_date < $2
+	          GROUP BY 1, 2, 3, 4
+	          UNION ALL
+	          SELECT '', '', c.id, c.name, SUM(w.total_cost)
+	          FROM write_offs w
+	          JOIN cash_category c ON c.code = 'write_off'
+	          WHERE w.status = 'approved' AND w.approved_at >= $1 AND w.approved_at < $2
+	          GROUP BY c.id, c.name`
+
+	var rows []entity.PnLExpenseRow
+	err := r.db.Select(&rows, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses for profit and loss: %w", err)
+	}
+
+	return rows, nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"math"
 	"strings"
 )
 
@@ -17,12 +18,54 @@ func NewSalesRepo(db *sqlx.DB) usecase.SalesRepo {
 	return &salesRepoImpl{db: db}
 }
 
+const salePaymentColumns = `id, sale_id, payment_method, currency, amount, change_amount, exchange_rate, created_at`
+
 func (r *salesRepoImpl) CreateSale(in *entity.SalesTotal) (*entity.SaleResponse, error) {
-	sale := &entity.SaleResponse{}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
 
-	query := `INSERT INTO sales (client_id, sold_by, total_sale_price, payment_method)
-	          VALUES (:client_id, :sold_by, :total_sale_price, :payment_method) RETURNING id, created_at`
-	err := r.db.QueryRowx(query, in).StructScan(sale)
+	sale := &entity.SaleResponse{
+		ClientID:       in.ClientID,
+		SoldBy:         in.SoldBy,
+		TotalSalePrice: in.TotalSalePrice,
+		PaymentMethod:  in.PaymentMethod,
+	}
+
+	// The sale keeps the rate of its currency to the base currency as of today
+	in.Currency, in.ExchangeRate, err = resolveCurrency(tx, in.Currency)
+	if err != nil {
+		return nil, err
+	}
+	sale.Currency, sale.ExchangeRate = in.Currency, in.ExchangeRate
+
+	sale.Change, err = settleSalePayments(tx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sale belongs to the seller's open shift, if there is one
+	query := `INSERT INTO sales (client_id, sold_by, total_sale_price, payment_method, currency, exchange_rate,
+	                             change_amount, shift_id)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT id FROM shifts WHERE user_id = $2 AND status = 'open'))
+	          RETURNING id, created_at, COALESCE(shift_id::text, '')`
+	err = tx.QueryRowx(query, in.ClientID, in.SoldBy, in.TotalSalePrice, in.PaymentMethod, in.Currency, in.ExchangeRate,
+		sale.Change).Scan(&sale.ID, &sale.CreatedAt, &sale.ShiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockExpired, err := blockExpiredSales(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := takeStock(tx, sale.ID, in.SoldBy, in.SoldProducts, blockExpired); err != nil {
+		return nil, err
+	}
+
+	method, err := costingMethod(tx)
 	if err != nil {
 		return nil, err
 	}
@@ -30,16 +73,165 @@ func (r *salesRepoImpl) CreateSale(in *entity.SalesTotal) (*entity.SaleResponse,
 	for _, item := range in.SoldProducts {
 		item.SaleID = sale.ID
 		itemQuery := `INSERT INTO sales_items (sale_id, product_id, quantity, sale_price, total_price)
-		              VALUES (:sale_id, :product_id, :quantity, :sale_price, :total_price)`
-		_, err := r.db.NamedExec(itemQuery, item)
+		              VALUES ($1, $2, $3, $4, $5) RETURNING id`
+		err := tx.Get(&item.ID, itemQuery, item.SaleID, item.ProductID, item.Quantity, item.SalePrice, item.TotalPrice)
 		if err != nil {
 			return nil, err
 		}
+
+		if err := consumeCostLayers(tx, method, &item); err != nil {
+			return nil, err
+		}
+		if err := allocateLots(tx, &item, blockExpired); err != nil {
+			return nil, err
+		}
+		sale.SoldProducts = append(sale.SoldProducts, item)
+	}
+
+	// The unpaid remainder of a credit sale becomes a debt within the client's credit limit
+	if in.OnCredit && in.TotalSalePrice > in.PaidAmount {
+		// Credit limits are kept in the base currency
+		override, err := checkCreditLimit(tx, in, (in.TotalSalePrice-in.PaidAmount)*in.ExchangeRate)
+		if err != nil {
+			return nil, err
+		}
+
+		sale.Debt, err = openDebt(tx, sale.ID, in)
+		if err != nil {
+			return nil, err
+		}
+
+		if override != nil {
+			override.SaleID = sale.ID
+			if err := recordCreditOverride(tx, override); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sale.Payments, err = saveSalePayments(tx, sale.ID, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
 	}
 
 	return sale, nil
 }
 
+// settleSalePayments fixes the rate of every payment and checks that the payments cover the sale,
+// or for a credit sale sets the paid part. Overpayment is returned as change from the cash payments.
+func settleSalePayments(tx *sqlx.Tx, in *entity.SalesTotal) (float64, error) {
+	for i := range in.Payments {
+		p := &in.Payments[i]
+		if p.Currency == "" {
+			p.Currency = in.Currency
+		}
+		currency, rate, err := resolveCurrency(tx, p.Currency)
+		if err != nil {
+			return 0, err
+		}
+		p.Currency, p.ExchangeRate = currency, rate
+	}
+
+	return settleChange(in)
+}
+
+// settleChange checks that the payments of a sale, with their rates fixed, cover it and works out the change.
+// Change is given from cash only, so card payments cannot exceed the total, and it is handed out from the last
+// cash payments first in their own currencies. It sets the paid amount of the sale and the change of every
+// payment and returns the change in the sale currency.
+func settleChange(in *entity.SalesTotal) (float64, error) {
+	var paid, cash float64
+	for _, p := range in.Payments {
+		amount := convertAmount(p.Amount, p.ExchangeRate, in.ExchangeRate)
+		paid += amount
+		if p.PaymentMethod != "card" {
+			cash += amount
+		}
+	}
+
+	paid = math.Round(paid*100) / 100
+	if paid < in.TotalSalePrice && !in.OnCredit {
+		return 0, fmt.Errorf("payments of %.2f do not cover the sale total %.2f", paid, in.TotalSalePrice)
+	}
+
+	change := math.Max(0, math.Round((paid-in.TotalSalePrice)*100)/100)
+	if change > cash {
+		return 0, fmt.Errorf("change of %.2f exceeds the cash paid, card payments cannot exceed the total", change)
+	}
+	in.PaidAmount = paid - change
+
+	left := change
+	for i := len(in.Payments) - 1; i >= 0 && left > 0; i-- {
+		p := &in.Payments[i]
+		if p.PaymentMethod == "card" {
+			continue
+		}
+		amount := convertAmount(p.Amount, p.ExchangeRate, in.ExchangeRate)
+		take := math.Min(left, amount)
+		p.Change = math.Min(p.Amount, convertAmount(take, in.ExchangeRate, p.ExchangeRate))
+		left = math.Round((left-take)*100) / 100
+	}
+
+	return change, nil
+}
+
+// saveSalePayments records the payments of a sale and posts what stayed in the till to the cash flow.
+func saveSalePayments(tx *sqlx.Tx, saleID string, in *entity.SalesTotal) ([]entity.SalePayment, error) {
+	payments := []entity.SalePayment{}
+	query := `INSERT INTO sale_payments (sale_id, payment_method, currency, amount, change_amount, exchange_rate)
+	          VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + salePaymentColumns
+	for _, p := range in.Payments {
+		var payment entity.SalePayment
+		err := tx.QueryRowx(query, saleID, p.PaymentMethod, p.Currency, p.Amount, p.Change, p.ExchangeRate).
+			StructScan(&payment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record sale payment: %w", err)
+		}
+		payments = append(payments, payment)
+
+		if p.Amount-p.Change <= 0 {
+			continue
+		}
+		err = postCashFlow(tx, "sales", &entity.CashFlowRequest{
+			UserID:        in.SoldBy,
+			Amount:        p.Amount - p.Change,
+			Description:   "Sale payment",
+			PaymentMethod: p.PaymentMethod,
+			Currency:      p.Currency,
+			ReferenceType: "sale",
+			ReferenceID:   saleID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return payments, nil
+}
+
+// salePaymentTotals sums sale payments by method and currency for the sales matching the condition.
+func salePaymentTotals(q sqlx.Queryer, condition string, args ...interface{}) ([]entity.SalePaymentTotal, error) {
+	totals := []entity.SalePaymentTotal{}
+	query := `SELECT p.payment_method, p.currency, COUNT(*) AS payments,
+	                 SUM(p.amount) AS amount, SUM(p.change_amount) AS change_amount,
+	                 SUM(p.amount - p.change_amount) AS net,
+	                 ROUND(SUM((p.amount - p.change_amount) * p.exchange_rate), 2) AS base_net
+	          FROM sale_payments p JOIN sales s ON s.id = p.sale_id
+	          WHERE ` + condition + `
+	          GROUP BY p.payment_method, p.currency
+	          ORDER BY p.payment_method, p.currency`
+	err := sqlx.Select(q, &totals, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum sale payments: %w", err)
+	}
+
+	return totals, nil
+}
+
 func (r *salesRepoImpl) UpdateSale(in *entity.SaleUpdate) (*entity.SaleResponse, error) {
 	query := `UPDATE sales SET `
 	updates := []string{}
@@ -71,7 +263,8 @@ func (r *salesRepoImpl) UpdateSale(in *entity.SaleUpdate) (*entity.SaleResponse,
 }
 
 func (r *salesRepoImpl) GetSale(in *entity.SaleID) (*entity.SaleResponse, error) {
-	query := `SELECT id, client_id, sold_by, total_sale_price, payment_method, created_at
+	query := `SELECT id, client_id, sold_by, total_sale_price, payment_method, currency, exchange_rate,
+	                 change_amount, COALESCE(shift_id::text, '') AS shift_id, created_at
 	          FROM sales WHERE id = $1`
 	sale := &entity.SaleResponse{}
 	err := r.db.Get(sale, query, in.ID)
@@ -79,13 +272,18 @@ func (r *salesRepoImpl) GetSale(in *entity.SaleID) (*entity.SaleResponse, error)
 		return nil, err
 	}
 
-	itemsQuery := `SELECT id, sale_id, product_id, quantity, sale_price, total_price
+	itemsQuery := `SELECT id, sale_id, product_id, quantity, sale_price, total_price, cogs
 	               FROM sales_items WHERE sale_id = $1`
 	err = r.db.Select(&sale.SoldProducts, itemsQuery, in.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	err = r.db.Select(&sale.Payments, `SELECT `+salePaymentColumns+` FROM sale_payments WHERE sale_id = $1 ORDER BY created_at`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale payments: %w", err)
+	}
+
 	return sale, nil
 }
 
@@ -97,7 +295,8 @@ func (r *salesRepoImpl) GetSaleList(in *entity.SaleFilter) (*entity.SaleList, er
 
 	queryBuilder.WriteString(`
 		SELECT s.id, s.client_id, s.sold_by, s.total_sale_price, 
-		       s.payment_method, s.created_at 
+		       s.payment_method, s.currency, s.exchange_rate, s.change_amount,
+		       COALESCE(s.shift_id::text, '') AS shift_id, s.created_at 
 		FROM sales s JOIN sales_items i ON s.id = i.sale_id
 		WHERE 1=1
 	`)
@@ -137,12 +336,68 @@ func (r *salesRepoImpl) GetSaleList(in *entity.SaleFilter) (*entity.SaleList, er
 	return &entity.SaleList{Sales: sales}, nil
 }
 
+// deleteSaleDebts removes the debt of a sale with everything recorded against it, including its cash postings.
+func deleteSaleDebts(tx *sqlx.Tx, saleID string) error {
+	queries := []string{
+		`DELETE FROM reminder_logs WHERE debt_id IN (SELECT id FROM debts WHERE order_id = $1)`,
+		`DELETE FROM debt_payment_allocations
+		 WHERE payment_id IN (SELECT p.id FROM debt_payments p JOIN debts d ON d.id = p.debt_id WHERE d.order_id = $1)`,
+		`DELETE FROM debt_installments WHERE debt_id IN (SELECT id FROM debts WHERE order_id = $1)`,
+		`DELETE FROM debt_payments WHERE debt_id IN (SELECT id FROM debts WHERE order_id = $1)`,
+		`DELETE FROM debts WHERE order_id = $1`,
+		`DELETE FROM credit_limit_overrides WHERE sale_id = $1`,
+		`DELETE FROM sale_payments WHERE sale_id = $1`,
+	}
+
+	err := removeCashFlows(tx, `(reference_type = 'sale' AND reference_id = $1)
+		OR (reference_type = 'debt_payment' AND reference_id IN (
+		    SELECT p.id FROM debt_payments p JOIN debts d ON d.id = p.debt_id WHERE d.order_id = $1))`, saleID)
+	if err != nil {
+		return err
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query, saleID); err != nil {
+			return fmt.Errorf("failed to delete sale debt: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *salesRepoImpl) DeleteSale(in *entity.SaleID) (*entity.Message, error) {
-	_, err := r.db.Exec(`DELETE FROM sales_items WHERE sale_id = $1`, in.ID)
+	tx, err := r.db.Beginx()
 	if err != nil {
 		return nil, err
 	}
-	result, err := r.db.Exec(`DELETE FROM sales WHERE id = $1`, in.ID)
+	defer tx.Rollback()
+
+	var returned bool
+	err = tx.Get(&returned, `SELECT EXISTS (SELECT 1 FROM sale_returns WHERE sale_id = $1)`, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if returned {
+		return nil, errors.New("sale has returns and cannot be deleted")
+	}
+
+	if err := deleteSaleDebts(tx, in.ID); err != nil {
+		return nil, err
+	}
+	if err := restoreCostLayers(tx, `si.sale_id = $1`, in.ID); err != nil {
+		return nil, err
+	}
+	if err := restoreLots(tx, `si.sale_id = $1`, in.ID); err != nil {
+		return nil, err
+	}
+	if err := returnStock(tx, in.ID); err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(`DELETE FROM sales_items WHERE sale_id = $1`, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := tx.Exec(`DELETE FROM sales WHERE id = $1`, in.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -151,5 +406,39 @@ func (r *salesRepoImpl) DeleteSale(in *entity.SaleID) (*entity.Message, error) {
 		return nil, errors.New("sale not found")
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return &entity.Message{Message: "Sale deleted successfully"}, nil
 }
+
+func (r *salesRepoImpl) GetDailyReport(in *entity.DailySalesFilter) (*entity.DailySalesReport, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.DailySalesReport{Date: in.Date, Currency: base}
+	query := `SELECT COUNT(*) AS sales_count,
+	                 COALESCE(ROUND(SUM(s.total_sale_price * s.exchange_rate), 2), 0) AS sales_total,
+	                 COALESCE(ROUND(SUM(COALESCE(d.total_debt, 0) * s.exchange_rate), 2), 0) AS credit_total,
+	                 COALESCE(ROUND(SUM(s.change_amount * s.exchange_rate), 2), 0) AS change_total
+	          FROM sales s
+	          LEFT JOIN debts d ON d.order_id = s.id
+	          WHERE DATE(s.created_at) = $1::date`
+	err = r.db.Get(report, query, in.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build daily sales report: %w", err)
+	}
+
+	report.Payments, err = salePaymentTotals(r.db, `DATE(s.created_at) = $1::date`, in.Date)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range report.Payments {
+		report.PaidTotal += p.BaseNet
+	}
+
+	return report, nil
+}
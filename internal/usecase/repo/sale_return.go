@@ -0,0 +1,308 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"strings"
+)
+
+type returnsRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewReturnsRepo(db *sqlx.DB) usecase.ReturnedProductsRepo {
+	return &returnsRepoImpl{db: db}
+}
+
+const saleReturnColumns = `r.id, r.sale_id, s.client_id, r.returned_by, COALESCE(r.reason, '') AS reason, s.currency,
+	r.total_amount, r.debt_reduction, r.refund_amount, r.refund_method, r.created_at`
+
+const saleReturnItemColumns = `ri.id, ri.return_id, ri.sales_item_id, ri.product_id, p.name AS product_name, ri.quantity,
+	ri.unit_price, ri.total_price, ri.cogs, ri.disposition`
+
+// returnableItems lists the lines of a sale with the quantity already returned.
+func returnableItems(q sqlx.Queryer, saleID string) ([]entity.ReturnableItem, error) {
+	query := `SELECT si.id AS sales_item_id, si.product_id, p.name AS product_name, si.quantity AS sold,
+	                 COALESCE(r.quantity, 0) AS returned, si.quantity - COALESCE(r.quantity, 0) AS returnable,
+	                 si.sale_price, si.cogs
+	          FROM sales_items si
+	          JOIN products p ON p.id = si.product_id
+	          LEFT JOIN (SELECT sales_item_id, SUM(quantity) AS quantity FROM sale_return_items
+	                     GROUP BY sales_item_id) r ON r.sales_item_id = si.id
+	          WHERE si.sale_id = $1
+	          ORDER BY p.name`
+
+	items := []entity.ReturnableItem{}
+	err := sqlx.Select(q, &items, query, saleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale lines: %w", err)
+	}
+
+	return items, nil
+}
+
+// CreateReturn takes lines of a sale back. Restocked goods come back into stock at the cost they were sold at,
+// written-off goods are recorded as returned and written off at once. The value of the return first reduces
+// the unpaid debt of the sale and the rest is refunded from a wallet or added to the client's store credit.
+func (r *returnsRepoImpl) CreateReturn(in *entity.SaleReturnRequest) (*entity.SaleReturn, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the sale serializes returns of the same sale
+	sale := &entity.SaleResponse{}
+	err = tx.Get(sale, `SELECT id, client_id, payment_method, currency, exchange_rate FROM sales
+	                    WHERE id = $1 FOR UPDATE`, in.SaleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("sale not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+
+	lines, err := returnableItems(tx, in.SaleID)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]*entity.ReturnableItem{}
+	for i := range lines {
+		byID[lines[i].SalesItemID] = &lines[i]
+	}
+
+	var items []entity.SaleReturnItem
+	var total float64
+	for _, req := range in.Items {
+		line, ok := byID[req.SalesItemID]
+		if !ok {
+			return nil, fmt.Errorf("line %s is not part of the sale", req.SalesItemID)
+		}
+		if req.Quantity > line.Returnable {
+			return nil, fmt.Errorf("cannot return %d of %s, only %d left to return", req.Quantity, line.ProductName,
+				line.Returnable)
+		}
+		line.Returnable -= req.Quantity
+
+		item := entity.SaleReturnItem{
+			SalesItemID: line.SalesItemID,
+			ProductID:   line.ProductID,
+			ProductName: line.ProductName,
+			Quantity:    req.Quantity,
+			UnitPrice:   line.SalePrice,
+			TotalPrice:  math.Round(float64(req.Quantity)*line.SalePrice*100) / 100,
+			COGS:        math.Round(line.COGS*float64(req.Quantity)/float64(line.Sold)*100) / 100,
+			Disposition: req.Disposition,
+		}
+		items = append(items, item)
+		total += item.TotalPrice
+	}
+	total = math.Round(total*100) / 100
+
+	// What is still owed on the sale is forgiven first
+	var debtID string
+	var unpaid float64
+	err = tx.QueryRowx(`SELECT id, amount_unpaid FROM debts WHERE order_id = $1 AND NOT is_fully_paid
+	                    FOR UPDATE`, in.SaleID).Scan(&debtID, &unpaid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get sale debt: %w", err)
+	}
+	reduction := math.Min(total, unpaid)
+	refund := math.Round((total-reduction)*100) / 100
+
+	switch {
+	case refund == 0:
+		in.RefundMethod = "none"
+	case in.RefundMethod == "" || in.RefundMethod == "none":
+		in.RefundMethod = sale.PaymentMethod
+	}
+
+	var id string
+	err = tx.Get(&id, `INSERT INTO sale_returns (sale_id, returned_by, reason, total_amount, debt_reduction,
+	                                              refund_amount, refund_method)
+	                   VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7) RETURNING id`,
+		in.SaleID, in.ReturnedBy, in.Reason, total, reduction, refund, in.RefundMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sale return: %w", err)
+	}
+
+	for _, item := range items {
+		_, err := tx.Exec(`INSERT INTO sale_return_items (return_id, sales_item_id, product_id, quantity, unit_price,
+		                                                  total_price, cogs, disposition)
+		                   VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			id, item.SalesItemID, item.ProductID, item.Quantity, item.UnitPrice, item.TotalPrice, item.COGS,
+			item.Disposition)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save returned line: %w", err)
+		}
+
+		if err := returnItemStock(tx, id, in.ReturnedBy, &item); err != nil {
+			return nil, err
+		}
+	}
+
+	if reduction > 0 {
+		if err := reduceDebt(tx, debtID, reduction); err != nil {
+			return nil, err
+		}
+	}
+
+	switch in.RefundMethod {
+	case "none":
+	case "store_credit":
+		// Store credit is kept in the base currency at the rate of the sale
+		_, err := tx.Exec(`UPDATE clients SET store_credit = store_credit + ROUND($1 * $2, 2) WHERE id = $3`,
+			refund, sale.ExchangeRate, sale.ClientID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add store credit: %w", err)
+		}
+	default:
+		err := postCashFlow(tx, "sales_return", &entity.CashFlowRequest{
+			UserID:        in.ReturnedBy,
+			Amount:        refund,
+			Description:   "Sale return refund",
+			PaymentMethod: in.RefundMethod,
+			Currency:      sale.Currency,
+			ReferenceType: "sale_return",
+			ReferenceID:   id,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit sale return: %w", err)
+	}
+
+	return r.GetReturn(&entity.SaleReturnID{ID: id})
+}
+
+// returnItemStock brings a returned line back into stock. Restocked goods go back to the lots they were sold
+// from and get a cost layer at the cost they were sold at, written-off goods leave stock again straight away.
+func returnItemStock(tx *sqlx.Tx, returnID, userID string, item *entity.SaleReturnItem) error {
+	_, err := moveStock(tx, &entity.InventoryMovementRequest{
+		ProductID:     item.ProductID,
+		MovementType:  "return",
+		Quantity:      item.Quantity,
+		ReferenceType: "sale_return",
+		ReferenceID:   returnID,
+		UserID:        userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if item.Disposition == "write_off" {
+		_, err := moveStock(tx, &entity.InventoryMovementRequest{
+			ProductID:     item.ProductID,
+			MovementType:  "write_off",
+			Quantity:      -item.Quantity,
+			ReferenceType: "sale_return",
+			ReferenceID:   returnID,
+			UserID:        userID,
+			Note:          "Returned goods written off",
+		})
+		return err
+	}
+
+	if err := returnLots(tx, item.SalesItemID, item.Quantity); err != nil {
+		return err
+	}
+
+	return addCostLayer(tx, item.ProductID, "", item.Quantity, item.COGS/float64(item.Quantity))
+}
+
+func (r *returnsRepoImpl) GetReturn(in *entity.SaleReturnID) (*entity.SaleReturn, error) {
+	ret := &entity.SaleReturn{}
+	err := r.db.Get(ret, `SELECT `+saleReturnColumns+` FROM sale_returns r JOIN sales s ON s.id = r.sale_id
+	                      WHERE r.id = $1`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("sale return not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale return: %w", err)
+	}
+
+	err = r.db.Select(&ret.Items, `SELECT `+saleReturnItemColumns+`
+	                               FROM sale_return_items ri JOIN products p ON p.id = ri.product_id
+	                               WHERE ri.return_id = $1 ORDER BY p.name`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returned lines: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (r *returnsRepoImpl) GetReturnList(in *entity.SaleReturnFilter) (*entity.SaleReturnList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + saleReturnColumns + ` FROM sale_returns r JOIN sales s ON s.id = r.sale_id
+		WHERE 1=1`)
+
+	if in.SaleID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND r.sale_id = $%d", argIndex))
+		args = append(args, in.SaleID)
+		argIndex++
+	}
+	if in.ClientID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND s.client_id = $%d", argIndex))
+		args = append(args, in.ClientID)
+		argIndex++
+	}
+	if in.ReturnedBy != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND r.returned_by = $%d", argIndex))
+		args = append(args, in.ReturnedBy)
+		argIndex++
+	}
+	if in.RefundMethod != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND r.refund_method = $%d", argIndex))
+		args = append(args, in.RefundMethod)
+		argIndex++
+	}
+	if in.From != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND r.created_at >= $%d::date", argIndex))
+		args = append(args, in.From)
+		argIndex++
+	}
+	if in.To != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND r.created_at < $%d::date + 1", argIndex))
+		args = append(args, in.To)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY r.created_at DESC")
+
+	list := &entity.SaleReturnList{}
+	err := r.db.Select(&list.Returns, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sale returns: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *returnsRepoImpl) GetReturnableItems(in *entity.SaleID) (*entity.ReturnableItemList, error) {
+	var exists bool
+	err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM sales WHERE id = $1)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+	if !exists {
+		return nil, errors.New("sale not found")
+	}
+
+	items, err := returnableItems(r.db, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ReturnableItemList{SaleID: in.ID, Items: items}, nil
+}
@@ -0,0 +1,122 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"testing"
+)
+
+func TestSettleChange(t *testing.T) {
+	const usd = 12500
+
+	uzs := func(method string, amount float64) entity.SalePayment {
+		return entity.SalePayment{PaymentMethod: method, Currency: "UZS", Amount: amount, ExchangeRate: 1}
+	}
+	dollars := func(amount float64) entity.SalePayment {
+		return entity.SalePayment{PaymentMethod: "usd", Currency: "USD", Amount: amount, ExchangeRate: usd}
+	}
+
+	tests := []struct {
+		name     string
+		total    float64
+		rate     float64
+		onCredit bool
+		payments []entity.SalePayment
+		change   float64
+		paid     float64
+		changes  []float64
+		wantErr  bool
+	}{
+		{
+			name: "exact cash", total: 100, rate: 1,
+			payments: []entity.SalePayment{uzs("uzs", 100)},
+			change:   0, paid: 100, changes: []float64{0},
+		},
+		{
+			name: "change from cash", total: 100, rate: 1,
+			payments: []entity.SalePayment{uzs("uzs", 150)},
+			change:   50, paid: 100, changes: []float64{50},
+		},
+		{
+			name: "card overpayment is rejected", total: 100, rate: 1,
+			payments: []entity.SalePayment{uzs("card", 150)},
+			wantErr:  true,
+		},
+		{
+			name: "card and cash, change from cash only", total: 100, rate: 1,
+			payments: []entity.SalePayment{uzs("card", 60), uzs("uzs", 50)},
+			change:   10, paid: 100, changes: []float64{0, 10},
+		},
+		{
+			name: "change larger than the cash is rejected", total: 100, rate: 1,
+			payments: []entity.SalePayment{uzs("uzs", 10), uzs("card", 120)},
+			wantErr:  true,
+		},
+		{
+			name: "payments short of the total", total: 100, rate: 1,
+			payments: []entity.SalePayment{uzs("card", 60), uzs("uzs", 30)},
+			wantErr:  true,
+		},
+		{
+			name: "credit sale pays part", total: 100, rate: 1, onCredit: true,
+			payments: []entity.SalePayment{uzs("uzs", 30)},
+			change:   0, paid: 30, changes: []float64{0},
+		},
+		{
+			name: "credit sale without payments", total: 100, rate: 1, onCredit: true,
+			change: 0, paid: 0,
+		},
+		{
+			name: "dollars and sums, change from the last cash payment", total: 150000, rate: 1,
+			payments: []entity.SalePayment{dollars(10), uzs("uzs", 50000)},
+			change:   25000, paid: 150000, changes: []float64{0, 25000},
+		},
+		{
+			name: "change in dollars when they are the only cash", total: 100000, rate: 1,
+			payments: []entity.SalePayment{uzs("card", 50000), dollars(5)},
+			change:   12500, paid: 100000, changes: []float64{0, 1},
+		},
+		{
+			name: "change spread over two cash payments", total: 120000, rate: 1,
+			payments: []entity.SalePayment{dollars(10), uzs("uzs", 5000)},
+			change:   10000, paid: 120000, changes: []float64{0.4, 5000},
+		},
+		{
+			name: "dollar sale paid in sums", total: 100, rate: usd,
+			payments: []entity.SalePayment{uzs("uzs", 1300000)},
+			change:   4, paid: 100, changes: []float64{50000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &entity.SalesTotal{
+				TotalSalePrice: tt.total,
+				ExchangeRate:   tt.rate,
+				OnCredit:       tt.onCredit,
+				Payments:       tt.payments,
+			}
+
+			change, err := settleChange(in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("settleChange() = %v, want error", change)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("settleChange() error: %v", err)
+			}
+			if change != tt.change {
+				t.Errorf("change = %v, want %v", change, tt.change)
+			}
+			if in.PaidAmount != tt.paid {
+				t.Errorf("paid amount = %v, want %v", in.PaidAmount, tt.paid)
+			}
+			for i, p := range in.Payments {
+				if p.Change != tt.changes[i] {
+					t.Errorf("payment %d change = %v, want %v", i, p.Change, tt.changes[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+type shiftsRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewShiftsRepo(db *sqlx.DB) usecase.ShiftsRepo {
+	return &shiftsRepoImpl{db: db}
+}
+
+const shiftColumns = `s.id, s.register_id, r.name AS register_name, r.wallet_id, s.user_id, s.status, s.opening_cash,
+	s.opened_at, s.expected_cash, s.counted_cash, s.discrepancy, COALESCE(s.note, '') AS note,
+	COALESCE(TO_CHAR(s.closed_at, 'YYYY-MM-DD"T"HH24:MI:SS'), '') AS closed_at`
+
+const shiftFrom = ` FROM shifts s JOIN registers r ON r.id = s.register_id`
+
+const registerColumns = `r.id, r.name, r.wallet_id, w.name AS wallet_name, r.branch_id, b.name AS branch_name, r.created_at`
+
+const registerFrom = ` FROM registers r JOIN wallets w ON w.id = r.wallet_id JOIN branches b ON b.id = r.branch_id`
+
+// shiftReport builds the X-report of a shift: sales and money taken by payment method and the cash
+// expected in the drawer, which is the opening float plus the net cash moved through the drawer wallet.
+func shiftReport(q sqlx.Queryer, shift *entity.Shift) (*entity.ShiftReport, error) {
+	base, err := baseCurrency(q)
+	if err != nil {
+		return nil, err
+	}
+	report := &entity.ShiftReport{Type: "X", Shift: *shift, Currency: base}
+
+	// Sales in other currencies count at their own rate to the base currency
+	err = q.QueryRowx(`SELECT COUNT(*), COALESCE(ROUND(SUM(total_sale_price * exchange_rate), 2), 0)
+	                   FROM sales WHERE shift_id = $1`, shift.ID).
+		Scan(&report.SalesCount, &report.SalesTotal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift sales: %w", err)
+	}
+
+	query := `SELECT payment_method,
+	                 COALESCE(SUM(amount) FILTER (WHERE transaction_type = 'income'), 0) AS income,
+	                 COALESCE(SUM(amount) FILTER (WHERE transaction_type = 'expense'), 0) AS expense,
+	                 COALESCE(SUM(CASE WHEN transaction_type = 'income' THEN amount ELSE -amount END), 0) AS net
+	          FROM cash_flow WHERE shift_id = $1
+	          GROUP BY payment_method ORDER BY payment_method`
+	err = sqlx.Select(q, &report.ByMethod, query, shift.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift totals: %w", err)
+	}
+
+	report.Payments, err = salePaymentTotals(q, `s.shift_id = $1`, shift.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var drawerNet float64
+	err = q.QueryRowx(`SELECT COALESCE(SUM(CASE WHEN transaction_type = 'income' THEN amount ELSE -amount END), 0)
+	                   FROM cash_flow WHERE shift_id = $1 AND wallet_id = $2`, shift.ID, shift.WalletID).
+		Scan(&drawerNet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift drawer total: %w", err)
+	}
+	report.ExpectedCash = shift.OpeningCash + drawerNet
+
+	if shift.Status == "closed" {
+		report.Type = "Z"
+		report.CountedCash = shift.CountedCash
+		report.Discrepancy = shift.Discrepancy
+		if shift.ExpectedCash != nil {
+			report.ExpectedCash = *shift.ExpectedCash
+		}
+	}
+
+	return report, nil
+}
+
+func (r *shiftsRepoImpl) CreateBranch(in *entity.BranchRequest) (*entity.Branch, error) {
+	branch := &entity.Branch{}
+	err := r.db.QueryRowx(`INSERT INTO branches (name) VALUES ($1) RETURNING id, name, created_at`, in.Name).
+		StructScan(branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create branch: %w", err)
+	}
+
+	return branch, nil
+}
+
+func (r *shiftsRepoImpl) GetBranches() (*entity.BranchList, error) {
+	branches := &entity.BranchList{}
+	err := r.db.Select(&branches.Branches, `SELECT id, name, created_at FROM branches ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	return branches, nil
+}
+
+// CreateRegister places the register in the given branch, or in the first one when none is given.
+func (r *shiftsRepoImpl) CreateRegister(in *entity.RegisterRequest) (*entity.Register, error) {
+	var id string
+	query := `INSERT INTO registers (name, wallet_id, branch_id)
+	          VALUES ($1, $2, COALESCE(NULLIF($3, '')::uuid, (SELECT id FROM branches ORDER BY created_at LIMIT 1)))
+	          RETURNING id`
+	err := r.db.Get(&id, query, in.Name, in.WalletID, in.BranchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create register: %w", err)
+	}
+
+	register := &entity.Register{}
+	err = r.db.Get(register, `SELECT `+registerColumns+registerFrom+` WHERE r.id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get register: %w", err)
+	}
+
+	return register, nil
+}
+
+func (r *shiftsRepoImpl) GetRegisters() (*entity.RegisterList, error) {
+	registers := &entity.RegisterList{}
+	err := r.db.Select(&registers.Registers, `SELECT `+registerColumns+registerFrom+` ORDER BY b.name, r.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registers: %w", err)
+	}
+
+	return registers, nil
+}
+
+func (r *shiftsRepoImpl) OpenShift(in *entity.ShiftOpen) (*entity.Shift, error) {
+	var busy string
+	err := r.db.Get(&busy, `SELECT CASE WHEN register_id = $1 THEN 'register' ELSE 'user' END
+	                        FROM shifts WHERE status = 'open' AND (register_id = $1 OR user_id = $2)
+	                        LIMIT 1`, in.RegisterID, in.UserID)
+	if err == nil {
+		return nil, fmt.Errorf("the %s already has an open shift", busy)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check open shifts: %w", err)
+	}
+
+	var id string
+	err = r.db.Get(&id, `INSERT INTO shifts (register_id, user_id, opening_cash) VALUES ($1, $2, $3) RETURNING id`,
+		in.RegisterID, in.UserID, in.OpeningCash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open shift: %w", err)
+	}
+
+	return r.GetShift(&entity.ShiftID{ID: id})
+}
+
+func (r *shiftsRepoImpl) CloseShift(in *entity.ShiftClose) (*entity.ShiftReport, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	shift := &entity.Shift{}
+	err = tx.Get(shift, `SELECT `+shiftColumns+shiftFrom+` WHERE s.id = $1 FOR UPDATE OF s`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("shift not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift: %w", err)
+	}
+	if shift.Status != "open" {
+		return nil, errors.New("shift is already closed")
+	}
+
+	report, err := shiftReport(tx, shift)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`UPDATE shifts
+	                  SET status = 'closed', closed_at = NOW(), expected_cash = $1, counted_cash = $2,
+	                      discrepancy = $2 - $1, note = NULLIF($3, '')
+	                  WHERE id = $4`, report.ExpectedCash, in.CountedCash, in.Note, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close shift: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit shift closing: %w", err)
+	}
+
+	return r.GetShiftReport(&entity.ShiftID{ID: in.ID})
+}
+
+func (r *shiftsRepoImpl) GetShift(in *entity.ShiftID) (*entity.Shift, error) {
+	shift := &entity.Shift{}
+	err := r.db.Get(shift, `SELECT `+shiftColumns+shiftFrom+` WHERE s.id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift: %w", err)
+	}
+
+	return shift, nil
+}
+
+func (r *shiftsRepoImpl) GetShifts(in *entity.ShiftFilter) (*entity.ShiftList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + shiftColumns + shiftFrom + ` WHERE 1=1`)
+
+	if in.RegisterID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND s.register_id = $%d", argIndex))
+		args = append(args, in.RegisterID)
+		argIndex++
+	}
+	if in.UserID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND s.user_id = $%d", argIndex))
+		args = append(args, in.UserID)
+		argIndex++
+	}
+	if in.Status != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND s.status = $%d", argIndex))
+		args = append(args, in.Status)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY s.opened_at DESC")
+
+	shifts := &entity.ShiftList{}
+	err := r.db.Select(&shifts.Shifts, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shifts: %w", err)
+	}
+
+	return shifts, nil
+}
+
+func (r *shiftsRepoImpl) GetShiftReport(in *entity.ShiftID) (*entity.ShiftReport, error) {
+	shift, err := r.GetShift(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return shiftReport(r.db, shift)
+}
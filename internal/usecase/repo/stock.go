@@ -0,0 +1,153 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const inventoryMovementColumns = `id, product_id, movement_type, quantity, balance, COALESCE(reference_type, '') AS reference_type,
+	COALESCE(reference_id::text, '') AS reference_id, COALESCE(user_id::text, '') AS user_id,
+	COALESCE(note, '') AS note, created_at`
+
+// moveStock changes the stock of a product and records the movement in the inventory ledger with the
+// balance it leaves. Stock cannot go below zero.
+func moveStock(tx *sqlx.Tx, in *entity.InventoryMovementRequest) (*entity.ProductNumber, error) {
+	if in.MovementType == "" {
+		in.MovementType = "adjustment"
+	}
+
+	product := &entity.ProductNumber{}
+	err := tx.QueryRowx(`UPDATE products SET total_count = total_count + $1
+	                     WHERE id = $2 AND total_count + $1 >= 0
+	                     RETURNING id, total_count`, in.Quantity, in.ProductID).
+		Scan(&product.ID, &product.TotalCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("product %s not found or not enough stock", in.ProductID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product stock: %w", err)
+	}
+
+	_, err = tx.Exec(`INSERT INTO inventory_movements (product_id, movement_type, quantity, balance, reference_type,
+	                                                  reference_id, user_id, note)
+	                  VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, '')::uuid, NULLIF($7, '')::uuid, NULLIF($8, ''))`,
+		in.ProductID, in.MovementType, in.Quantity, product.TotalCount, in.ReferenceType, in.ReferenceID, in.UserID, in.Note)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record inventory movement: %w", err)
+	}
+
+	return product, nil
+}
+
+// takeStock locks the products of the lines of a sale and takes their quantities out of stock. Rows are locked
+// in id order so that concurrent sales of the same products wait for each other instead of deadlocking.
+// When any line asks for more than is left, nothing is taken and a StockError lists every such line.
+// With blockExpired, goods in expired lots do not count as left.
+func takeStock(tx *sqlx.Tx, saleID, userID string, items []entity.SalesItem, blockExpired bool) error {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProductID)
+	}
+	if err := rejectVariantParents(tx, ids); err != nil {
+		return err
+	}
+
+	rows, err := tx.Queryx(`SELECT id, name, total_count FROM products
+	                        WHERE id = ANY($1) ORDER BY id FOR UPDATE`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to lock products: %w", err)
+	}
+	names, left := map[string]string{}, map[string]int{}
+	for rows.Next() {
+		var id, name string
+		var count int
+		if err := rows.Scan(&id, &name, &count); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read product stock: %w", err)
+		}
+		names[id], left[id] = name, count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read product stock: %w", err)
+	}
+
+	if blockExpired {
+		expired, err := expiredStock(tx, ids)
+		if err != nil {
+			return err
+		}
+		for id, quantity := range expired {
+			left[id] -= quantity
+		}
+	}
+
+	// Lines of the same product draw on what the lines before them left
+	stockErr := &entity.StockError{}
+	for i, item := range items {
+		if _, ok := names[item.ProductID]; !ok {
+			return fmt.Errorf("product %s not found", item.ProductID)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("quantity of line %d must be positive", i)
+		}
+		if item.Quantity > left[item.ProductID] {
+			stockErr.Lines = append(stockErr.Lines, entity.StockShortage{
+				Line:        i,
+				ProductID:   item.ProductID,
+				ProductName: names[item.ProductID],
+				Requested:   item.Quantity,
+				Available:   max(left[item.ProductID], 0),
+			})
+		}
+		left[item.ProductID] -= item.Quantity
+	}
+	if len(stockErr.Lines) > 0 {
+		return stockErr
+	}
+
+	for _, item := range items {
+		_, err := moveStock(tx, &entity.InventoryMovementRequest{
+			ProductID:     item.ProductID,
+			MovementType:  "sale",
+			Quantity:      -item.Quantity,
+			ReferenceType: "sale",
+			ReferenceID:   saleID,
+			UserID:        userID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// returnStock puts the goods of a deleted sale back into stock.
+func returnStock(tx *sqlx.Tx, saleID string) error {
+	var items []entity.SalesItem
+	err := tx.Select(&items, `SELECT product_id, quantity FROM sales_items WHERE sale_id = $1`, saleID)
+	if err != nil {
+		return fmt.Errorf("failed to get sold products: %w", err)
+	}
+
+	for _, item := range items {
+		_, err := moveStock(tx, &entity.InventoryMovementRequest{
+			ProductID:     item.ProductID,
+			MovementType:  "sale",
+			Quantity:      item.Quantity,
+			ReferenceType: "sale",
+			ReferenceID:   saleID,
+			Note:          "Sale deleted",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
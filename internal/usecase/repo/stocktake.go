@@ -0,0 +1,323 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+)
+
+type stocktakesRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewStocktakesRepo(db *sqlx.DB) usecase.StocktakesRepo {
+	return &stocktakesRepoImpl{db: db}
+}
+
+const stocktakeColumns = `s.id, s.status, COALESCE(s.category_id::text, '') AS category_id,
+	COALESCE(s.location, '') AS location, COALESCE(s.note, '') AS note,
+	(SELECT COUNT(*) FROM stocktake_items i WHERE i.stocktake_id = s.id) AS products,
+	(SELECT COUNT(DISTINCT c.product_id) FROM stocktake_counts c WHERE c.stocktake_id = s.id) AS counted,
+	s.created_by, s.created_at, COALESCE(s.posted_by::text, '') AS posted_by,
+	COALESCE(TO_CHAR(s.posted_at, 'YYYY-MM-DD"T"HH24:MI:SS'), '') AS posted_at`
+
+// lockStocktake locks a stocktake that is still being counted.
+func lockStocktake(tx *sqlx.Tx, id string) error {
+	var status string
+	err := tx.Get(&status, `SELECT status FROM stocktakes WHERE id = $1 FOR UPDATE`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("stocktake not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock stocktake: %w", err)
+	}
+	if status != "counting" {
+		return fmt.Errorf("stocktake is already %s", status)
+	}
+
+	return nil
+}
+
+// stocktakeLines compares the expected stock of every product of a stocktake with the sum of its counts.
+// Products never counted have no variance.
+func stocktakeLines(q sqlx.Queryer, id string) ([]entity.StocktakeLine, error) {
+	query := `SELECT i.product_id, p.name AS product_name, COALESCE(p.barcode, '') AS barcode,
+	                 COALESCE(p.location, '') AS location, i.expected, COALESCE(c.counted, 0) AS counted,
+	                 c.product_id IS NOT NULL AS is_counted,
+	                 COALESCE(c.counted - i.expected, 0) AS variance, i.unit_cost,
+	                 ROUND(COALESCE(c.counted - i.expected, 0) * i.unit_cost, 2) AS variance_value
+	          FROM stocktake_items i
+	          JOIN products p ON p.id = i.product_id
+	          LEFT JOIN (SELECT product_id, SUM(quantity) AS counted FROM stocktake_counts
+	                     WHERE stocktake_id = $1 GROUP BY product_id) c ON c.product_id = i.product_id
+	          WHERE i.stocktake_id = $1
+	          ORDER BY p.location NULLS LAST, p.name`
+
+	lines := []entity.StocktakeLine{}
+	err := sqlx.Select(q, &lines, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stocktake lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// CreateStocktake snapshots the current stock and average cost of the products in scope.
+func (r *stocktakesRepoImpl) CreateStocktake(in *entity.StocktakeRequest) (*entity.Stocktake, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.Get(&id, `INSERT INTO stocktakes (category_id, location, note, created_by)
+	                   VALUES (NULLIF($1, '')::uuid, NULLIF($2, ''), NULLIF($3, ''), $4) RETURNING id`,
+		in.CategoryID, in.Location, in.Note, in.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stocktake: %w", err)
+	}
+
+	// Products with variants are counted by variant
+	res, err := tx.Exec(`INSERT INTO stocktake_items (stocktake_id, product_id, expected, unit_cost)
+	                     SELECT $1, id, COALESCE(total_count, 0), average_cost FROM products
+	                     WHERE ($2 = '' OR category_id = NULLIF($2, '')::uuid)
+	                       AND ($3 = '' OR location = $3)
+	                       AND NOT EXISTS (SELECT 1 FROM products v WHERE v.parent_id = products.id)`,
+		id, in.CategoryID, in.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot stock: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return nil, errors.New("no products to count in this scope")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit stocktake: %w", err)
+	}
+
+	return r.GetStocktake(&entity.StocktakeID{ID: id})
+}
+
+func (r *stocktakesRepoImpl) GetStocktake(in *entity.StocktakeID) (*entity.Stocktake, error) {
+	stocktake := &entity.Stocktake{}
+	err := r.db.Get(stocktake, `SELECT `+stocktakeColumns+` FROM stocktakes s WHERE s.id = $1`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("stocktake not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stocktake: %w", err)
+	}
+
+	return stocktake, nil
+}
+
+func (r *stocktakesRepoImpl) GetStocktakes(in *entity.StocktakeFilter) (*entity.StocktakeList, error) {
+	list := &entity.StocktakeList{}
+	err := r.db.Select(&list.Stocktakes, `SELECT `+stocktakeColumns+` FROM stocktakes s
+	                                      WHERE $1 = '' OR s.status = $1
+	                                      ORDER BY s.created_at DESC`, in.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stocktakes: %w", err)
+	}
+
+	return list, nil
+}
+
+// AddCounts records counted quantities. Products are found by ID or barcode and must be in the stocktake.
+func (r *stocktakesRepoImpl) AddCounts(in *entity.StocktakeCountRequest) (*entity.Stocktake, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockStocktake(tx, in.ID); err != nil {
+		return nil, err
+	}
+
+	for _, item := range in.Items {
+		productID := item.ProductID
+		if item.Barcode != "" {
+			err := tx.Get(&productID, `SELECT id FROM products WHERE barcode = $1`, item.Barcode)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("no product with barcode %s", item.Barcode)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to find product by barcode: %w", err)
+			}
+		}
+		if err := rejectVariantParents(tx, []string{productID}); err != nil {
+			return nil, err
+		}
+
+		res, err := tx.Exec(`INSERT INTO stocktake_counts (stocktake_id, product_id, quantity, counted_by)
+		                     SELECT stocktake_id, product_id, $3, $4 FROM stocktake_items
+		                     WHERE stocktake_id = $1 AND product_id = $2`,
+			in.ID, productID, item.Quantity, in.CountedBy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record count: %w", err)
+		}
+		if rows, _ := res.RowsAffected(); rows == 0 {
+			return nil, fmt.Errorf("product %s is not part of the stocktake", productID)
+		}
+	}
+
+	// Corrections cannot take a count below zero
+	var negative string
+	err = tx.Get(&negative, `SELECT p.name FROM stocktake_counts c JOIN products p ON p.id = c.product_id
+	                         WHERE c.stocktake_id = $1
+	                         GROUP BY p.id, p.name HAVING SUM(c.quantity) < 0 LIMIT 1`, in.ID)
+	if err == nil {
+		return nil, fmt.Errorf("count of %s cannot be negative", negative)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check counts: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit counts: %w", err)
+	}
+
+	return r.GetStocktake(&entity.StocktakeID{ID: in.ID})
+}
+
+// GetVariance returns the lines of a stocktake with the value of the shortage and surplus over all lines.
+func (r *stocktakesRepoImpl) GetVariance(in *entity.StocktakeLineFilter) (*entity.StocktakeVariance, error) {
+	stocktake, err := r.GetStocktake(&entity.StocktakeID{ID: in.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := stocktakeLines(r.db, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &entity.StocktakeVariance{Stocktake: *stocktake, Currency: base, Lines: []entity.StocktakeLine{}}
+	for _, line := range lines {
+		switch {
+		case !line.IsCounted:
+			res.Uncounted++
+		case line.VarianceValue < 0:
+			res.Shortage -= line.VarianceValue
+		default:
+			res.Surplus += line.VarianceValue
+		}
+
+		if in.OnlyVariances && line.Variance == 0 || in.Uncounted && line.IsCounted {
+			continue
+		}
+		res.Lines = append(res.Lines, line)
+	}
+	res.Shortage = math.Round(res.Shortage*100) / 100
+	res.Surplus = math.Round(res.Surplus*100) / 100
+	res.Net = math.Round((res.Surplus-res.Shortage)*100) / 100
+
+	return res, nil
+}
+
+// PostStocktake adjusts stock by the variance of every counted line in one transaction. The variance is applied to
+// the current stock, so sales made while counting are kept. Surplus goods get a cost layer at the average
+// cost of the snapshot, missing goods leave the oldest layers.
+func (r *stocktakesRepoImpl) PostStocktake(in *entity.StocktakePost) (*entity.StocktakeVariance, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockStocktake(tx, in.ID); err != nil {
+		return nil, err
+	}
+
+	// Products nobody found are recorded as counted at zero
+	if in.ZeroUncounted {
+		_, err := tx.Exec(`INSERT INTO stocktake_counts (stocktake_id, product_id, quantity, counted_by)
+		                   SELECT i.stocktake_id, i.product_id, 0, $2 FROM stocktake_items i
+		                   WHERE i.stocktake_id = $1
+		                     AND NOT EXISTS (SELECT 1 FROM stocktake_counts c
+		                                     WHERE c.stocktake_id = i.stocktake_id AND c.product_id = i.product_id)`,
+			in.ID, in.PostedBy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to zero uncounted products: %w", err)
+		}
+	}
+
+	lines, err := stocktakeLines(tx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		variance := line.Variance
+		if variance == 0 {
+			continue
+		}
+		if err := rejectVariantParents(tx, []string{line.ProductID}); err != nil {
+			return nil, err
+		}
+
+		_, err := moveStock(tx, &entity.InventoryMovementRequest{
+			ProductID:     line.ProductID,
+			MovementType:  "adjustment",
+			Quantity:      variance,
+			ReferenceType: "stocktake",
+			ReferenceID:   in.ID,
+			UserID:        in.PostedBy,
+			Note:          "Stocktake variance",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot adjust %s: %w", line.ProductName, err)
+		}
+
+		if variance > 0 {
+			err = addCostLayer(tx, line.ProductID, "", variance, line.UnitCost)
+		} else {
+			_, err = shrinkCostLayers(tx, line.ProductID, -variance)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Missing goods come out of the lots, counted extras stay without a lot
+		if variance < 0 {
+			if err := shrinkLots(tx, line.ProductID, -variance); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE stocktakes SET status = 'posted', posted_by = $1, posted_at = NOW() WHERE id = $2`,
+		in.PostedBy, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post stocktake: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit stocktake posting: %w", err)
+	}
+
+	return r.GetVariance(&entity.StocktakeLineFilter{ID: in.ID, OnlyVariances: true})
+}
+
+func (r *stocktakesRepoImpl) CancelStocktake(in *entity.StocktakeID) (*entity.Stocktake, error) {
+	res, err := r.db.Exec(`UPDATE stocktakes SET status = 'cancelled' WHERE id = $1 AND status = 'counting'`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel stocktake: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return nil, errors.New("stocktake not found or no longer being counted")
+	}
+
+	return r.GetStocktake(in)
+}
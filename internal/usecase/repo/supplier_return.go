@@ -0,0 +1,256 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"strings"
+)
+
+const supplierReturnColumns = `r.id, r.purchase_id, r.supplier_id, r.returned_by, COALESCE(r.reason, '') AS reason,
+	p.currency, r.total_amount, r.payable_reduction, r.refund_amount, r.refund_method, r.created_at`
+
+const supplierReturnItemColumns = `ri.id, ri.return_id, ri.purchase_item_id, ri.product_id, pr.name AS product_name,
+	ri.quantity, ri.unit_price, ri.total_price`
+
+// returnablePurchaseItems lists the lines of a purchase with the quantity already sent back.
+func returnablePurchaseItems(q sqlx.Queryer, purchaseID string) ([]entity.ReturnablePurchaseItem, error) {
+	query := `SELECT pi.id AS purchase_item_id, pi.product_id, pr.name AS product_name, pi.quantity AS purchased,
+	                 COALESCE(r.quantity, 0) AS returned, pi.quantity - COALESCE(r.quantity, 0) AS returnable,
+	                 pi.purchase_price
+	          FROM purchase_items pi
+	          JOIN products pr ON pr.id = pi.product_id
+	          LEFT JOIN (SELECT purchase_item_id, SUM(quantity) AS quantity FROM supplier_return_items
+	                     GROUP BY purchase_item_id) r ON r.purchase_item_id = pi.id
+	          WHERE pi.purchase_id = $1
+	          ORDER BY pr.name`
+
+	items := []entity.ReturnablePurchaseItem{}
+	err := sqlx.Select(q, &items, query, purchaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase lines: %w", err)
+	}
+
+	return items, nil
+}
+
+// CreateSupplierReturn sends lines of a purchase back to the supplier. The goods leave stock and their cost
+// layer, the purchase itself stays as it was. What the goods are worth first reduces the unpaid balance of
+// the purchase and the rest is recorded as a refund from the supplier.
+func (r *purchasesRepoImpl) CreateSupplierReturn(in *entity.SupplierReturnRequest) (*entity.SupplierReturn, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the purchase serializes returns and payments of the same purchase
+	var supplierID, currency, paymentMethod string
+	var outstanding float64
+	err = tx.QueryRowx(`SELECT supplier_id, currency, payment_method, total_cost - returned_amount - amount_paid
+	                    FROM purchases WHERE id = $1 FOR UPDATE`, in.PurchaseID).
+		Scan(&supplierID, &currency, &paymentMethod, &outstanding)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("purchase not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock purchase: %w", err)
+	}
+
+	lines, err := returnablePurchaseItems(tx, in.PurchaseID)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]*entity.ReturnablePurchaseItem{}
+	for i := range lines {
+		byID[lines[i].PurchaseItemID] = &lines[i]
+	}
+
+	var items []entity.SupplierReturnItem
+	var total float64
+	for _, req := range in.Items {
+		line, ok := byID[req.PurchaseItemID]
+		if !ok {
+			return nil, fmt.Errorf("line %s is not part of the purchase", req.PurchaseItemID)
+		}
+		if req.Quantity > line.Returnable {
+			return nil, fmt.Errorf("cannot return %d of %s, only %d left to return", req.Quantity, line.ProductName,
+				line.Returnable)
+		}
+		line.Returnable -= req.Quantity
+
+		item := entity.SupplierReturnItem{
+			PurchaseItemID: line.PurchaseItemID,
+			ProductID:      line.ProductID,
+			ProductName:    line.ProductName,
+			Quantity:       req.Quantity,
+			UnitPrice:      line.PurchasePrice,
+			TotalPrice:     math.Round(float64(req.Quantity)*line.PurchasePrice*100) / 100,
+		}
+		items = append(items, item)
+		total += item.TotalPrice
+	}
+	total = math.Round(total*100) / 100
+
+	reduction := math.Min(total, math.Max(outstanding, 0))
+	refund := math.Round((total-reduction)*100) / 100
+
+	switch {
+	case refund == 0:
+		in.RefundMethod = "none"
+	case in.RefundMethod == "" || in.RefundMethod == "none":
+		in.RefundMethod = paymentMethod
+	}
+
+	var id string
+	err = tx.Get(&id, `INSERT INTO supplier_returns (purchase_id, supplier_id, returned_by, reason, total_amount,
+	                                                  payable_reduction, refund_amount, refund_method)
+	                   VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8) RETURNING id`,
+		in.PurchaseID, supplierID, in.ReturnedBy, in.Reason, total, reduction, refund, in.RefundMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create supplier return: %w", err)
+	}
+
+	for _, item := range items {
+		_, err := tx.Exec(`INSERT INTO supplier_return_items (return_id, purchase_item_id, product_id, quantity,
+		                                                      unit_price, total_price)
+		                   VALUES ($1, $2, $3, $4, $5, $6)`,
+			id, item.PurchaseItemID, item.ProductID, item.Quantity, item.UnitPrice, item.TotalPrice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save returned line: %w", err)
+		}
+
+		if err := returnCostLayer(tx, item.PurchaseItemID, item.Quantity); err != nil {
+			return nil, err
+		}
+		if err := returnPurchaseLot(tx, item.PurchaseItemID, item.Quantity); err != nil {
+			return nil, err
+		}
+
+		_, err = moveStock(tx, &entity.InventoryMovementRequest{
+			ProductID:     item.ProductID,
+			MovementType:  "return",
+			Quantity:      -item.Quantity,
+			ReferenceType: "supplier_return",
+			ReferenceID:   id,
+			UserID:        in.ReturnedBy,
+			Note:          "Returned to supplier",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reduction > 0 {
+		_, err := tx.Exec(`UPDATE purchases SET returned_amount = returned_amount + $1 WHERE id = $2`,
+			reduction, in.PurchaseID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reduce purchase balance: %w", err)
+		}
+	}
+
+	if refund > 0 {
+		err := postCashFlow(tx, "supplier_refund", &entity.CashFlowRequest{
+			UserID:        in.ReturnedBy,
+			Amount:        refund,
+			Description:   "Supplier return refund",
+			PaymentMethod: in.RefundMethod,
+			Currency:      currency,
+			ReferenceType: "supplier_return",
+			ReferenceID:   id,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit supplier return: %w", err)
+	}
+
+	return r.GetSupplierReturn(&entity.SupplierReturnID{ID: id})
+}
+
+func (r *purchasesRepoImpl) GetSupplierReturn(in *entity.SupplierReturnID) (*entity.SupplierReturn, error) {
+	ret := &entity.SupplierReturn{}
+	err := r.db.Get(ret, `SELECT `+supplierReturnColumns+` FROM supplier_returns r
+	                      JOIN purchases p ON p.id = r.purchase_id
+	                      WHERE r.id = $1`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("supplier return not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supplier return: %w", err)
+	}
+
+	err = r.db.Select(&ret.Items, `SELECT `+supplierReturnItemColumns+`
+	                               FROM supplier_return_items ri JOIN products pr ON pr.id = ri.product_id
+	                               WHERE ri.return_id = $1 ORDER BY pr.name`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returned lines: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (r *purchasesRepoImpl) GetSupplierReturnList(in *entity.SupplierReturnFilter) (*entity.SupplierReturnList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + supplierReturnColumns + ` FROM supplier_returns r
+		JOIN purchases p ON p.id = r.purchase_id
+		WHERE 1=1`)
+
+	if in.PurchaseID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND r.purchase_id = $%d", argIndex))
+		args = append(args, in.PurchaseID)
+		argIndex++
+	}
+	if in.SupplierID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND r.supplier_id = $%d", argIndex))
+		args = append(args, in.SupplierID)
+		argIndex++
+	}
+	if in.From != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND r.created_at >= $%d::date", argIndex))
+		args = append(args, in.From)
+		argIndex++
+	}
+	if in.To != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND r.created_at < $%d::date + 1", argIndex))
+		args = append(args, in.To)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY r.created_at DESC")
+
+	list := &entity.SupplierReturnList{}
+	err := r.db.Select(&list.Returns, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list supplier returns: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *purchasesRepoImpl) GetReturnablePurchaseItems(in *entity.PurchaseID) (*entity.ReturnablePurchaseItemList, error) {
+	var exists bool
+	err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM purchases WHERE id = $1)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase: %w", err)
+	}
+	if !exists {
+		return nil, errors.New("purchase not found")
+	}
+
+	items, err := returnablePurchaseItems(r.db, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ReturnablePurchaseItemList{PurchaseID: in.ID, Items: items}, nil
+}
@@ -0,0 +1,271 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var skuUnsafe = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// skuPart turns a name or an attribute value into a piece of a SKU.
+func skuPart(s string) string {
+	return strings.Trim(skuUnsafe.ReplaceAllString(strings.ToUpper(s), "-"), "-")
+}
+
+// attachVariants puts the variants of the listed products under them. The stock of a product with variants
+// is the stock of all its variants.
+func attachVariants(q sqlx.Queryer, products []entity.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(products))
+	byID := map[string]*entity.Product{}
+	for i := range products {
+		ids = append(ids, products[i].ID)
+		byID[products[i].ID] = &products[i]
+	}
+
+	var variants []entity.Product
+	err := sqlx.Select(q, &variants, `SELECT `+productColumns+` FROM products
+	                                  WHERE parent_id = ANY($1) ORDER BY name`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to list variants: %w", err)
+	}
+
+	for _, v := range variants {
+		parent := byID[v.ParentID]
+		parent.Variants = append(parent.Variants, v)
+		parent.TotalCount += v.TotalCount
+	}
+
+	return nil
+}
+
+// rejectVariantParents fails when any of the products has variants: those are sold and bought through
+// their variants only.
+func rejectVariantParents(q sqlx.Queryer, productIDs []string) error {
+	var names []string
+	err := sqlx.Select(q, &names, `SELECT p.name FROM products p
+	                               WHERE p.id = ANY($1) AND EXISTS (SELECT 1 FROM products v WHERE v.parent_id = p.id)
+	                               ORDER BY p.name`, pq.Array(productIDs))
+	if err != nil {
+		return fmt.Errorf("failed to check product variants: %w", err)
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("%s has variants, choose a variant instead", strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// lockVariantParent locks a product variants are added to. A variant cannot have variants of its own, and
+// the stock of a product must be moved to its variants before it gets any.
+func lockVariantParent(tx *sqlx.Tx, productID string) (*entity.Product, error) {
+	parent := &entity.Product{}
+	err := tx.Get(parent, `SELECT `+productColumns+` FROM products WHERE id = $1 FOR UPDATE`, productID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("product not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	if parent.ParentID != "" {
+		return nil, errors.New("a variant cannot have variants")
+	}
+	if parent.TotalCount != 0 {
+		return nil, fmt.Errorf("%s has %d in stock, move it to variants before adding them", parent.Name,
+			parent.TotalCount)
+	}
+
+	return parent, nil
+}
+
+// insertVariant adds a variant with the given attribute values. The variant is named after its product and
+// the values, its SKU is made from those of the product unless given, and prices left empty follow the product.
+func insertVariant(tx *sqlx.Tx, parent *entity.Product, in *entity.VariantRequest) (*entity.Product, error) {
+	keys := make([]string, 0, len(in.Attributes))
+	for key := range in.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make([]string, 0, len(keys))
+	sku := parent.SKU
+	if sku == "" {
+		sku = skuPart(parent.Name)
+	}
+	for _, key := range keys {
+		values = append(values, in.Attributes[key])
+		sku += "-" + skuPart(in.Attributes[key])
+	}
+	if in.SKU != "" {
+		sku = in.SKU
+	}
+
+	attributes, err := json.Marshal(in.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode variant attributes: %w", err)
+	}
+
+	incoming, standard := parent.IncomingPrice, parent.StandardPrice
+	ownPrice := in.IncomingPrice != nil || in.StandardPrice != nil
+	if in.IncomingPrice != nil {
+		incoming = *in.IncomingPrice
+	}
+	if in.StandardPrice != nil {
+		standard = *in.StandardPrice
+	}
+
+	variant := &entity.Product{}
+	err = tx.QueryRowx(`INSERT INTO products (category_id, name, bill_format, incoming_price, standard_price, sku,
+	                                          barcode, location, parent_id, attributes, own_price, created_by)
+	                    VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, $10, $11, $12)
+	                    RETURNING `+productColumns,
+		parent.CategoryID, fmt.Sprintf("%s (%s)", parent.Name, strings.Join(values, ", ")), parent.BillFormat,
+		incoming, standard, sku, in.Barcode, parent.Location, parent.ID, string(attributes), ownPrice,
+		in.CreatedBy).StructScan(variant)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, fmt.Errorf("variant %s already exists or SKU %s is taken", strings.Join(values, ", "), sku)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create variant: %w", err)
+	}
+
+	return variant, nil
+}
+
+func (p *productRepo) CreateVariant(in *entity.VariantRequest) (*entity.Product, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	parent, err := lockVariantParent(tx, in.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := insertVariant(tx, parent, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit variant: %w", err)
+	}
+
+	return variant, nil
+}
+
+// GenerateVariants saves the attribute options of a product and adds a variant for every combination
+// of their values that does not exist yet.
+func (p *productRepo) GenerateVariants(in *entity.VariantGenerateRequest) (*entity.Product, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	parent, err := lockVariantParent(tx, in.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := json.Marshal(in.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode variant options: %w", err)
+	}
+	_, err = tx.Exec(`UPDATE products SET variant_options = $1 WHERE id = $2`, string(options), parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save variant options: %w", err)
+	}
+
+	keys := make([]string, 0, len(in.Options))
+	for key := range in.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	combinations := []map[string]string{{}}
+	for _, key := range keys {
+		var next []map[string]string
+		for _, combination := range combinations {
+			for _, value := range in.Options[key] {
+				c := map[string]string{key: value}
+				for k, v := range combination {
+					c[k] = v
+				}
+				next = append(next, c)
+			}
+		}
+		combinations = next
+	}
+
+	for _, combination := range combinations {
+		attributes, err := json.Marshal(combination)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode variant attributes: %w", err)
+		}
+
+		var exists bool
+		err = tx.Get(&exists, `SELECT EXISTS (SELECT 1 FROM products WHERE parent_id = $1 AND attributes = $2::jsonb)`,
+			parent.ID, string(attributes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check variant: %w", err)
+		}
+		if exists {
+			continue
+		}
+
+		_, err = insertVariant(tx, parent, &entity.VariantRequest{Attributes: combination, CreatedBy: in.CreatedBy})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit variants: %w", err)
+	}
+
+	return p.GetProduct(&entity.ProductID{ID: parent.ID})
+}
+
+// SetVariantPrice gives a variant prices of its own, or makes it follow the prices of its product again.
+func (p *productRepo) SetVariantPrice(in *entity.VariantPrice) (*entity.Product, error) {
+	query := `UPDATE products SET incoming_price = $1, standard_price = $2, own_price = TRUE
+	          WHERE id = $3 AND parent_id IS NOT NULL
+	          RETURNING ` + productColumns
+	args := []interface{}{in.IncomingPrice, in.StandardPrice, in.ID}
+	if in.Inherit {
+		query = `UPDATE products
+		         SET (incoming_price, standard_price) = (SELECT p.incoming_price, p.standard_price
+		                                                 FROM products p WHERE p.id = products.parent_id),
+		             own_price = FALSE
+		         WHERE id = $1 AND parent_id IS NOT NULL
+		         RETURNING ` + productColumns
+		args = []interface{}{in.ID}
+	}
+
+	variant := &entity.Product{}
+	err := p.db.QueryRowx(query, args...).StructScan(variant)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("variant not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to set variant price: %w", err)
+	}
+
+	return variant, nil
+}
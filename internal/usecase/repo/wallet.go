@@ -0,0 +1,284 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+type walletsRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewWalletsRepo(db *sqlx.DB) usecase.WalletsRepo {
+	return &walletsRepoImpl{db: db}
+}
+
+const walletColumns = `id, name, currency, payment_method, COALESCE(is_default, FALSE) AS is_default, balance, created_at`
+
+const walletTransferColumns = `id, from_wallet_id, to_wallet_id, amount, to_amount, user_id,
+	COALESCE(description, '') AS description, created_at`
+
+// clearDefaultWallet drops the default flag of other wallets with the same payment method.
+func clearDefaultWallet(tx *sqlx.Tx, paymentMethod, exceptID string) error {
+	_, err := tx.Exec(`UPDATE wallets SET is_default = FALSE
+	                   WHERE payment_method = $1 AND is_default AND id::text <> $2`, paymentMethod, exceptID)
+	if err != nil {
+		return fmt.Errorf("failed to reset default wallet: %w", err)
+	}
+
+	return nil
+}
+
+func (r *walletsRepoImpl) CreateWallet(in *entity.WalletRequest) (*entity.Wallet, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if in.IsDefault {
+		if err := clearDefaultWallet(tx, in.PaymentMethod, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	wallet := &entity.Wallet{}
+	query := `INSERT INTO wallets (name, currency, payment_method, is_default)
+	          VALUES ($1, $2, $3, $4) RETURNING ` + walletColumns
+	err = tx.QueryRowx(query, in.Name, in.Currency, in.PaymentMethod, in.IsDefault).StructScan(wallet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit wallet: %w", err)
+	}
+
+	return wallet, nil
+}
+
+func (r *walletsRepoImpl) UpdateWallet(in *entity.WalletRequest) (*entity.Wallet, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Currency and payment method are fixed once the wallet exists, only the name and default flag change
+	wallet := &entity.Wallet{}
+	query := `UPDATE wallets
+	          SET name = COALESCE(NULLIF($1, ''), name),
+	              is_default = $2
+	          WHERE id = $3
+	          RETURNING ` + walletColumns
+	err = tx.QueryRowx(query, in.Name, in.IsDefault, in.ID).StructScan(wallet)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("wallet not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update wallet: %w", err)
+	}
+
+	if in.IsDefault {
+		if err := clearDefaultWallet(tx, wallet.PaymentMethod, wallet.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit wallet: %w", err)
+	}
+
+	return wallet, nil
+}
+
+func (r *walletsRepoImpl) DeleteWallet(in *entity.WalletID) (*entity.Message, error) {
+	var used bool
+	err := r.db.Get(&used, `SELECT EXISTS (SELECT 1 FROM cash_flow WHERE wallet_id = $1)
+	                        OR EXISTS (SELECT 1 FROM wallet_transfers WHERE from_wallet_id = $1 OR to_wallet_id = $1)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check wallet usage: %w", err)
+	}
+	if used {
+		return nil, errors.New("wallet has entries and cannot be deleted")
+	}
+
+	result, err := r.db.Exec(`DELETE FROM wallets WHERE id = $1 AND NOT COALESCE(is_default, FALSE)`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete wallet: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, errors.New("wallet not found or is a default wallet")
+	}
+
+	return &entity.Message{Message: "Wallet deleted successfully"}, nil
+}
+
+func (r *walletsRepoImpl) GetWallet(in *entity.WalletID) (*entity.Wallet, error) {
+	wallet := &entity.Wallet{}
+	err := r.db.Get(wallet, `SELECT `+walletColumns+` FROM wallets WHERE id = $1`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	return wallet, nil
+}
+
+func (r *walletsRepoImpl) GetWallets() (*entity.WalletList, error) {
+	wallets := &entity.WalletList{}
+	err := r.db.Select(&wallets.Wallets, `SELECT `+walletColumns+` FROM wallets ORDER BY payment_method, name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+
+	return wallets, nil
+}
+
+func (r *walletsRepoImpl) Transfer(in *entity.WalletTransferRequest) (*entity.WalletTransfer, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock both wallets in a fixed order so concurrent transfers cannot deadlock
+	var wallets []entity.Wallet
+	err = tx.Select(&wallets, `SELECT `+walletColumns+` FROM wallets WHERE id IN ($1, $2) ORDER BY id FOR UPDATE`,
+		in.FromWalletID, in.ToWalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock wallets: %w", err)
+	}
+	if len(wallets) != 2 {
+		return nil, errors.New("wallet not found")
+	}
+
+	from, to := wallets[0], wallets[1]
+	if from.ID != in.FromWalletID {
+		from, to = to, from
+	}
+
+	if from.Balance < in.Amount {
+		return nil, fmt.Errorf("insufficient funds in %s: balance %.2f, transfer %.2f", from.Name, from.Balance, in.Amount)
+	}
+
+	// Without an explicit received amount, a cross-currency transfer goes at today's rates
+	if in.ToAmount == 0 {
+		in.ToAmount = in.Amount
+		if from.Currency != to.Currency {
+			_, fromRate, err := resolveCurrency(tx, from.Currency)
+			if err != nil {
+				return nil, err
+			}
+			_, toRate, err := resolveCurrency(tx, to.Currency)
+			if err != nil {
+				return nil, err
+			}
+			in.ToAmount = convertAmount(in.Amount, fromRate, toRate)
+		}
+	}
+
+	transfer := &entity.WalletTransfer{}
+	query := `INSERT INTO wallet_transfers (from_wallet_id, to_wallet_id, amount, to_amount, user_id, description)
+	          VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + walletTransferColumns
+	err = tx.QueryRowx(query, in.FromWalletID, in.ToWalletID, in.Amount, in.ToAmount, in.UserID, in.Description).
+		StructScan(transfer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record transfer: %w", err)
+	}
+
+	legs := []*entity.CashFlowRequest{
+		{WalletID: from.ID, Amount: in.Amount, TransactionType: "expense", Description: "Transfer to " + to.Name},
+		{WalletID: to.ID, Amount: in.ToAmount, TransactionType: "income", Description: "Transfer from " + from.Name},
+	}
+	for _, leg := range legs {
+		leg.UserID = in.UserID
+		leg.ReferenceType = "transfer"
+		leg.ReferenceID = transfer.ID
+		if err := postCashFlow(tx, "transfer", leg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+func (r *walletsRepoImpl) GetTransfers(in *entity.WalletTransferFilter) (*entity.WalletTransferList, error) {
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + walletTransferColumns + ` FROM wallet_transfers WHERE 1=1`)
+
+	if in.WalletID != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND (from_wallet_id = $%d OR to_wallet_id = $%d)", argIndex, argIndex))
+		args = append(args, in.WalletID)
+		argIndex++
+	}
+	if in.From != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND created_at >= $%d::date", argIndex))
+		args = append(args, in.From)
+		argIndex++
+	}
+	if in.To != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND created_at < $%d::date + 1", argIndex))
+		args = append(args, in.To)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY created_at DESC")
+
+	transfers := &entity.WalletTransferList{}
+	err := r.db.Select(&transfers.Transfers, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+func (r *walletsRepoImpl) GetBalancesAt(in *entity.WalletBalanceFilter) (*entity.WalletBalanceList, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each balance is valued in the base currency at the latest rate known on that date
+	query := `SELECT b.wallet_id, b.name, b.currency, b.payment_method, b.balance,
+	                 COALESCE(x.rate, 1) AS exchange_rate,
+	                 ROUND(b.balance * COALESCE(x.rate, 1), 2) AS base_balance
+	          FROM (
+	              SELECT w.id AS wallet_id, w.name, w.currency, w.payment_method,
+	                     COALESCE(SUM(CASE WHEN f.transaction_type = 'income' THEN f.amount ELSE -f.amount END), 0) AS balance
+	              FROM wallets w
+	              LEFT JOIN cash_flow f ON f.wallet_id = w.id AND f.transaction_date < $1::date + 1
+	              GROUP BY w.id, w.name, w.currency, w.payment_method
+	          ) b
+	          LEFT JOIN LATERAL (
+	              SELECT rate FROM exchange_rates
+	              WHERE currency = b.currency AND b.currency <> $2 AND rate_date <= $1::date
+	              ORDER BY rate_date DESC LIMIT 1
+	          ) x ON TRUE
+	          ORDER BY b.payment_method, b.name`
+
+	balances := &entity.WalletBalanceList{Date: in.Date, Currency: base}
+	err = r.db.Select(&balances.Wallets, query, in.Date, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet balances: %w", err)
+	}
+
+	for _, w := range balances.Wallets {
+		balances.Total += w.BaseBalance
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,346 @@
+package repo
+
+import (
+	"crm-admin/internal/entity"
+	"crm-admin/internal/usecase"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"strings"
+)
+
+type writeOffsRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewWriteOffsRepo(db *sqlx.DB) usecase.WriteOffsRepo {
+	return &writeOffsRepoImpl{db: db}
+}
+
+const writeOffColumns = `id, reason, COALESCE(note, '') AS note, status, total_cost, created_by, created_at,
+	COALESCE(approved_by::text, '') AS approved_by,
+	COALESCE(TO_CHAR(approved_at, 'YYYY-MM-DD"T"HH24:MI:SS'), '') AS approved_at,
+	COALESCE(reject_reason, '') AS reject_reason`
+
+const writeOffItemColumns = `wi.id, wi.write_off_id, wi.product_id, p.name AS product_name, wi.quantity, wi.unit_cost,
+	wi.total_cost`
+
+// writeOffThreshold returns the cost in the base currency above which a write-off needs approval.
+func writeOffThreshold(q sqlx.Queryer) (float64, error) {
+	var threshold float64
+	err := sqlx.Get(q, &threshold, `SELECT value::numeric FROM settings WHERE key = 'write_off_approval_threshold'`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get write-off approval threshold: %w", err)
+	}
+
+	return threshold, nil
+}
+
+// postWriteOff takes the goods of a write-off out of stock and their oldest cost layers and marks the
+// write-off approved by the user. With FIFO every line is valued at the cost of the layers it took,
+// with the average method at the current average cost of its product.
+func postWriteOff(tx *sqlx.Tx, id, userID string) error {
+	method, err := costingMethod(tx)
+	if err != nil {
+		return err
+	}
+
+	var items []entity.WriteOffItem
+	err = tx.Select(&items, `SELECT `+writeOffItemColumns+`
+	                          FROM write_off_items wi JOIN products p ON p.id = wi.product_id
+	                          WHERE wi.write_off_id = $1 ORDER BY wi.product_id`, id)
+	if err != nil {
+		return fmt.Errorf("failed to get write-off lines: %w", err)
+	}
+
+	var total float64
+	for _, item := range items {
+		var unitCost float64
+		err := tx.Get(&unitCost, `SELECT average_cost FROM products WHERE id = $1 FOR UPDATE`, item.ProductID)
+		if err != nil {
+			return fmt.Errorf("failed to get product cost: %w", err)
+		}
+
+		_, err = moveStock(tx, &entity.InventoryMovementRequest{
+			ProductID:     item.ProductID,
+			MovementType:  "write_off",
+			Quantity:      -item.Quantity,
+			ReferenceType: "write_off",
+			ReferenceID:   id,
+			UserID:        userID,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot write off %s: %w", item.ProductName, err)
+		}
+
+		value, err := shrinkCostLayers(tx, item.ProductID, item.Quantity)
+		if err != nil {
+			return err
+		}
+		if err := shrinkLots(tx, item.ProductID, item.Quantity); err != nil {
+			return err
+		}
+
+		cost := math.Round(float64(item.Quantity)*unitCost*100) / 100
+		if method == "fifo" {
+			cost, unitCost = value, math.Round(value/float64(item.Quantity)*10000)/10000
+		}
+		_, err = tx.Exec(`UPDATE write_off_items SET unit_cost = $1, total_cost = $2 WHERE id = $3`,
+			unitCost, cost, item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to value write-off line: %w", err)
+		}
+		total += cost
+	}
+
+	_, err = tx.Exec(`UPDATE write_offs
+	                  SET status = 'approved', total_cost = $1, approved_by = $2, approved_at = NOW()
+	                  WHERE id = $3`, math.Round(total*100)/100, userID, id)
+	if err != nil {
+		return fmt.Errorf("failed to approve write-off: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWriteOff records a write-off estimated at the average cost of its goods. When the estimate is within
+// the approval threshold it is posted straight away, otherwise stock is only checked and it waits for approval.
+// Posting values it by the costing method, so with FIFO the final cost may differ from the estimate.
+func (r *writeOffsRepoImpl) CreateWriteOff(in *entity.WriteOffRequest) (*entity.WriteOff, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.Get(&id, `INSERT INTO write_offs (reason, note, total_cost, created_by)
+	                   VALUES ($1, NULLIF($2, ''), 0, $3) RETURNING id`, in.Reason, in.Note, in.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create write-off: %w", err)
+	}
+
+	for _, item := range in.Items {
+		_, err := tx.Exec(`INSERT INTO write_off_items (write_off_id, product_id, quantity, unit_cost, total_cost)
+		                   SELECT $1, id, $3, average_cost, ROUND($3 * average_cost, 2) FROM products WHERE id = $2`,
+			id, item.ProductID, item.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save write-off line: %w", err)
+		}
+	}
+
+	// Lines of the same product are checked together against the stock
+	var short []string
+	err = tx.Select(&short, `SELECT p.name FROM write_off_items wi JOIN products p ON p.id = wi.product_id
+	                         WHERE wi.write_off_id = $1
+	                         GROUP BY p.id, p.name, p.total_count HAVING SUM(wi.quantity) > COALESCE(p.total_count, 0)
+	                         ORDER BY p.name`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check stock: %w", err)
+	}
+	if len(short) > 0 {
+		return nil, fmt.Errorf("not enough stock to write off %s", strings.Join(short, ", "))
+	}
+
+	var total float64
+	var lines int
+	err = tx.QueryRowx(`SELECT COALESCE(SUM(total_cost), 0), COUNT(*) FROM write_off_items WHERE write_off_id = $1`,
+		id).Scan(&total, &lines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to value write-off: %w", err)
+	}
+	if lines != len(in.Items) {
+		return nil, errors.New("product not found")
+	}
+
+	threshold, err := writeOffThreshold(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if total > threshold {
+		_, err = tx.Exec(`UPDATE write_offs SET total_cost = $1 WHERE id = $2`, total, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to value write-off: %w", err)
+		}
+	} else if err := postWriteOff(tx, id, in.CreatedBy); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit write-off: %w", err)
+	}
+
+	return r.GetWriteOff(&entity.WriteOffID{ID: id})
+}
+
+// lockPendingWriteOff locks a write-off waiting for approval and checks that the user deciding is an owner.
+func lockPendingWriteOff(tx *sqlx.Tx, in *entity.WriteOffDecision) error {
+	var status string
+	err := tx.Get(&status, `SELECT status FROM write_offs WHERE id = $1 FOR UPDATE`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("write-off not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock write-off: %w", err)
+	}
+	if status != "pending" {
+		return fmt.Errorf("write-off is already %s", status)
+	}
+
+	var role string
+	err = tx.Get(&role, `SELECT role FROM users WHERE user_id = $1`, in.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get deciding user: %w", err)
+	}
+	if role != "admin" {
+		return errors.New("only an owner can approve or reject a write-off")
+	}
+
+	return nil
+}
+
+func (r *writeOffsRepoImpl) ApproveWriteOff(in *entity.WriteOffDecision) (*entity.WriteOff, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockPendingWriteOff(tx, in); err != nil {
+		return nil, err
+	}
+
+	if err := postWriteOff(tx, in.ID, in.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit write-off approval: %w", err)
+	}
+
+	return r.GetWriteOff(&entity.WriteOffID{ID: in.ID})
+}
+
+func (r *writeOffsRepoImpl) RejectWriteOff(in *entity.WriteOffDecision) (*entity.WriteOff, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockPendingWriteOff(tx, in); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`UPDATE write_offs
+	                  SET status = 'rejected', approved_by = $1, approved_at = NOW(), reject_reason = $2
+	                  WHERE id = $3`, in.UserID, in.Reason, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject write-off: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit write-off rejection: %w", err)
+	}
+
+	return r.GetWriteOff(&entity.WriteOffID{ID: in.ID})
+}
+
+func (r *writeOffsRepoImpl) GetWriteOff(in *entity.WriteOffID) (*entity.WriteOff, error) {
+	writeOff := &entity.WriteOff{}
+	err := r.db.Get(writeOff, `SELECT `+writeOffColumns+` FROM write_offs WHERE id = $1`, in.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("write-off not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get write-off: %w", err)
+	}
+
+	err = r.db.Select(&writeOff.Items, `SELECT `+writeOffItemColumns+`
+	                                    FROM write_off_items wi JOIN products p ON p.id = wi.product_id
+	                                    WHERE wi.write_off_id = $1 ORDER BY p.name`, in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get write-off lines: %w", err)
+	}
+
+	return writeOff, nil
+}
+
+func (r *writeOffsRepoImpl) GetWriteOffList(in *entity.WriteOffFilter) (*entity.WriteOffList, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryBuilder strings.Builder
+	var args []interface{}
+	argIndex := 1
+
+	queryBuilder.WriteString(`SELECT ` + writeOffColumns + ` FROM write_offs WHERE 1=1`)
+
+	if in.Status != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND status = $%d", argIndex))
+		args = append(args, in.Status)
+		argIndex++
+	}
+	if in.Reason != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND reason = $%d", argIndex))
+		args = append(args, in.Reason)
+		argIndex++
+	}
+	if in.From != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND created_at >= $%d::date", argIndex))
+		args = append(args, in.From)
+		argIndex++
+	}
+	if in.To != "" {
+		queryBuilder.WriteString(fmt.Sprintf(" AND created_at < $%d::date + 1", argIndex))
+		args = append(args, in.To)
+		argIndex++
+	}
+
+	queryBuilder.WriteString(" ORDER BY created_at DESC")
+
+	list := &entity.WriteOffList{Currency: base}
+	err = r.db.Select(&list.WriteOffs, queryBuilder.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list write-offs: %w", err)
+	}
+
+	for _, writeOff := range list.WriteOffs {
+		if writeOff.Status == "approved" {
+			list.Total += writeOff.TotalCost
+		}
+	}
+	list.Total = math.Round(list.Total*100) / 100
+
+	return list, nil
+}
+
+func (r *writeOffsRepoImpl) GetThreshold() (*entity.WriteOffThreshold, error) {
+	base, err := baseCurrency(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold, err := writeOffThreshold(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.WriteOffThreshold{Amount: threshold, Currency: base}, nil
+}
+
+// SetThreshold changes the approval threshold for write-offs created from now on.
+func (r *writeOffsRepoImpl) SetThreshold(in *entity.WriteOffThreshold) (*entity.WriteOffThreshold, error) {
+	_, err := r.db.Exec(`INSERT INTO settings (key, value) VALUES ('write_off_approval_threshold', $1)
+	                     ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value`, fmt.Sprint(in.Amount))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set write-off approval threshold: %w", err)
+	}
+
+	return r.GetThreshold()
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+)
+
+type ReportsUseCase struct {
+	repo ReportsRepo
+	log  *slog.Logger
+}
+
+func NewReportsUseCase(repo ReportsRepo, log *slog.Logger) *ReportsUseCase {
+	return &ReportsUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+// GetProfitAndLoss builds the P&L of a period, the current month by default, broken down by branch,
+// product category or seller and compared with the previous period of the same length.
+func (r *ReportsUseCase) GetProfitAndLoss(in *entity.PnLFilter) (*entity.PnLReport, error) {
+	if in.GroupBy == "" {
+		in.GroupBy = "branch"
+	}
+	if in.GroupBy != "branch" && in.GroupBy != "category" && in.GroupBy != "seller" {
+		return nil, fmt.Errorf("group_by must be branch, category or seller")
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	var err error
+	if in.From != "" {
+		if from, err = time.ParseInLocation("2006-01-02", in.From, time.Local); err != nil {
+			return nil, fmt.Errorf("invalid from date %q", in.From)
+		}
+	}
+	if in.To != "" {
+		if to, err = time.ParseInLocation("2006-01-02", in.To, time.Local); err != nil {
+			return nil, fmt.Errorf("invalid to date %q", in.To)
+		}
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("to date is before the from date")
+	}
+
+	// Both periods are half-open: the end is the day after the last one
+	end := to.AddDate(0, 0, 1)
+	days := int(end.Sub(from).Hours()/24 + 0.5)
+	prevFrom := from.AddDate(0, 0, -days)
+
+	base, err := r.repo.GetBaseCurrency()
+	if err != nil {
+		r.log.Error("Error fetching base currency", "error", err.Error())
+		return nil, fmt.Errorf("error fetching base currency: %w", err)
+	}
+
+	report := &entity.PnLReport{
+		Currency:     base,
+		From:         from.Format("2006-01-02"),
+		To:           to.Format("2006-01-02"),
+		PreviousFrom: prevFrom.Format("2006-01-02"),
+		PreviousTo:   from.AddDate(0, 0, -1).Format("2006-01-02"),
+		GroupBy:      in.GroupBy,
+		Expenses:     []entity.PnLExpense{},
+		Lines:        []entity.PnLLine{},
+	}
+
+	lines := map[string]*entity.PnLLine{}
+	line := func(key, name string) *entity.PnLLine {
+		l, ok := lines[key]
+		if !ok {
+			l = &entity.PnLLine{Key: key, Name: name}
+			lines[key] = l
+		}
+		return l
+	}
+	expenses := map[string]*entity.PnLExpense{}
+
+	for _, period := range []struct {
+		from, to time.Time
+		current  bool
+	}{{from, end, true}, {prevFrom, from, false}} {
+		sales, err := r.repo.GetPnLSales(period.from, period.to, in.GroupBy)
+		if err != nil {
+			r.log.Error("Error fetching sales for profit and loss", "error", err.Error())
+			return nil, fmt.Errorf("error fetching sales for profit and loss: %w", err)
+		}
+		for _, row := range sales {
+			figures := pnlFigures(line(row.Key, row.Name), period.current)
+			figures.Revenue += row.Revenue
+			figures.COGS += row.COGS
+		}
+
+		returns, err := r.repo.GetPnLReturns(period.from, period.to, in.GroupBy)
+		if err != nil {
+			r.log.Error("Error fetching returns for profit and loss", "error", err.Error())
+			return nil, fmt.Errorf("error fetching returns for profit and loss: %w", err)
+		}
+		for _, row := range returns {
+			figures := pnlFigures(line(row.Key, row.Name), period.current)
+			figures.Returns += row.Returns
+			figures.COGS -= row.COGS
+		}
+
+		spent, err := r.repo.GetPnLExpenses(period.from, period.to, in.GroupBy)
+		if err != nil {
+			r.log.Error("Error fetching expenses for profit and loss", "error", err.Error())
+			return nil, fmt.Errorf("error fetching expenses for profit and loss: %w", err)
+		}
+		for _, row := range spent {
+			figures := pnlFigures(line(row.Key, row.Name), period.current)
+			figures.Expenses += row.Amount
+
+			e, ok := expenses[row.CategoryID]
+			if !ok {
+				e = &entity.PnLExpense{CategoryID: row.CategoryID, CategoryName: row.CategoryName}
+				expenses[row.CategoryID] = e
+			}
+			if period.current {
+				e.Amount += row.Amount
+			} else {
+				e.Previous += row.Amount
+			}
+		}
+	}
+
+	for _, l := range lines {
+		finishPnL(&l.Current)
+		finishPnL(&l.Previous)
+		addPnL(&report.Current, &l.Current)
+		addPnL(&report.Previous, &l.Previous)
+		report.Lines = append(report.Lines, *l)
+	}
+	finishPnL(&report.Current)
+	finishPnL(&report.Previous)
+
+	// Lines with the most revenue come first, unattributed expenses last
+	sort.Slice(report.Lines, func(i, j int) bool {
+		a, b := report.Lines[i], report.Lines[j]
+		if (a.Key == "") != (b.Key == "") {
+			return b.Key == ""
+		}
+		return a.Current.NetRevenue > b.Current.NetRevenue
+	})
+
+	for _, e := range expenses {
+		e.Amount, e.Previous = round2(e.Amount), round2(e.Previous)
+		report.Expenses = append(report.Expenses, *e)
+	}
+	sort.Slice(report.Expenses, func(i, j int) bool {
+		return report.Expenses[i].Amount > report.Expenses[j].Amount
+	})
+
+	report.Change = entity.PnLChange{
+		Revenue:     pnlChange(report.Current.NetRevenue, report.Previous.NetRevenue),
+		GrossProfit: pnlChange(report.Current.GrossProfit, report.Previous.GrossProfit),
+		Expenses:    pnlChange(report.Current.Expenses, report.Previous.Expenses),
+		NetProfit:   pnlChange(report.Current.NetProfit, report.Previous.NetProfit),
+	}
+
+	return report, nil
+}
+
+func pnlFigures(line *entity.PnLLine, current bool) *entity.PnLFigures {
+	if current {
+		return &line.Current
+	}
+	return &line.Previous
+}
+
+// finishPnL derives the totals of figures whose revenue, returns, cost and expenses are set.
+func finishPnL(f *entity.PnLFigures) {
+	f.Revenue, f.Returns, f.COGS, f.Expenses = round2(f.Revenue), round2(f.Returns), round2(f.COGS), round2(f.Expenses)
+	f.NetRevenue = round2(f.Revenue - f.Returns)
+	f.GrossProfit = round2(f.NetRevenue - f.COGS)
+	f.NetProfit = round2(f.GrossProfit - f.Expenses)
+	f.GrossMargin = 0
+	if f.NetRevenue != 0 {
+		f.GrossMargin = math.Round(f.GrossProfit/f.NetRevenue*10000) / 10000
+	}
+}
+
+func addPnL(total, f *entity.PnLFigures) {
+	total.Revenue += f.Revenue
+	total.Returns += f.Returns
+	total.COGS += f.COGS
+	total.Expenses += f.Expenses
+}
+
+// pnlChange returns the change from previous to current as a fraction of the previous amount.
+func pnlChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := math.Round((current-previous)/math.Abs(previous)*10000) / 10000
+	return &change
+}
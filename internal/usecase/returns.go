@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+)
+
+type ReturnsUseCase struct {
+	repo ReturnedProductsRepo
+	log  *slog.Logger
+}
+
+func NewReturnsUseCase(repo ReturnedProductsRepo, log *slog.Logger) *ReturnsUseCase {
+	return &ReturnsUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateReturn takes back some or all lines of a sale, restocking or writing off the goods, and settles
+// the value of the return against the sale debt, then as a refund or store credit.
+func (r *ReturnsUseCase) CreateReturn(in *entity.SaleReturnRequest) (*entity.SaleReturn, error) {
+	if len(in.Items) == 0 {
+		return nil, fmt.Errorf("return must have at least one line")
+	}
+	for i := range in.Items {
+		item := &in.Items[i]
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("returned quantities must be positive")
+		}
+		if item.Disposition == "" {
+			item.Disposition = "restock"
+		}
+		if item.Disposition != "restock" && item.Disposition != "write_off" {
+			return nil, fmt.Errorf("disposition must be restock or write_off")
+		}
+	}
+	switch in.RefundMethod {
+	case "", "uzs", "usd", "card", "store_credit":
+	default:
+		return nil, fmt.Errorf("refund method must be uzs, usd, card or store_credit")
+	}
+
+	res, err := r.repo.CreateReturn(in)
+	if err != nil {
+		r.log.Error("Error creating sale return", "error", err.Error())
+		return nil, fmt.Errorf("error creating sale return: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetReturn returns a sale return with its lines.
+func (r *ReturnsUseCase) GetReturn(in *entity.SaleReturnID) (*entity.SaleReturn, error) {
+	res, err := r.repo.GetReturn(in)
+	if err != nil {
+		r.log.Error("Error fetching sale return", "error", err.Error())
+		return nil, fmt.Errorf("error fetching sale return: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetReturnList lists sale returns by sale, client, user, refund method and dates.
+func (r *ReturnsUseCase) GetReturnList(in *entity.SaleReturnFilter) (*entity.SaleReturnList, error) {
+	res, err := r.repo.GetReturnList(in)
+	if err != nil {
+		r.log.Error("Error fetching sale returns", "error", err.Error())
+		return nil, fmt.Errorf("error fetching sale returns: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetReturnableItems lists the lines of a sale with how much of each can still be returned.
+func (r *ReturnsUseCase) GetReturnableItems(in *entity.SaleID) (*entity.ReturnableItemList, error) {
+	res, err := r.repo.GetReturnableItems(in)
+	if err != nil {
+		r.log.Error("Error fetching returnable lines", "error", err.Error())
+		return nil, fmt.Errorf("error fetching returnable lines: %w", err)
+	}
+
+	return res, nil
+}
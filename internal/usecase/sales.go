@@ -4,20 +4,19 @@ import (
 	"crm-admin/internal/entity"
 	"fmt"
 	"log/slog"
-	"sync"
+	"strings"
+	"time"
 )
 
 type SalesUseCase struct {
-	repo    SalesRepo
-	product ProductQuantity
-	log     *slog.Logger
+	repo SalesRepo
+	log  *slog.Logger
 }
 
-func NewSalesUseCase(repo SalesRepo, pr ProductQuantity, log *slog.Logger) *SalesUseCase {
+func NewSalesUseCase(repo SalesRepo, log *slog.Logger) *SalesUseCase {
 	return &SalesUseCase{
-		repo:    repo,
-		product: pr,
-		log:     log,
+		repo: repo,
+		log:  log,
 	}
 }
 
@@ -42,12 +41,66 @@ func (s *SalesUseCase) CalculateTotalSales(in *entity.SaleRequest) (*entity.Sale
 		SoldBy:         in.SoldBy,
 		TotalSalePrice: totalPrice,
 		PaymentMethod:  in.PaymentMethod,
+		Currency:       strings.ToUpper(in.Currency),
+		PaidAmount:     in.PaidAmount,
+		OnCredit:       in.OnCredit,
+		NextPayment:    in.NextPayment,
+		OverrideBy:     in.OverrideBy,
+		OverrideReason: in.OverrideReason,
 		SoldProducts:   soldProducts,
 	}, nil
 }
 
+// salePayments returns the payments of a sale. Without a payments list the sale is paid with its
+// single payment method: in full, or the upfront amount of a credit sale.
+func salePayments(in *entity.SaleRequest, total float64) ([]entity.SalePayment, error) {
+	if len(in.Payments) == 0 {
+		amount := total
+		if in.OnCredit {
+			if in.PaidAmount < 0 || in.PaidAmount > total {
+				return nil, fmt.Errorf("paid amount must be between 0 and the sale total %.2f", total)
+			}
+			amount = in.PaidAmount
+		}
+		if amount == 0 {
+			return nil, nil
+		}
+		return []entity.SalePayment{{PaymentMethod: in.PaymentMethod, Amount: amount}}, nil
+	}
+
+	payments := make([]entity.SalePayment, 0, len(in.Payments))
+	for _, p := range in.Payments {
+		if p.PaymentMethod == "" {
+			p.PaymentMethod = "uzs"
+		}
+		switch p.PaymentMethod {
+		case "uzs", "usd", "card":
+		default:
+			return nil, fmt.Errorf("unknown payment method %q", p.PaymentMethod)
+		}
+		if p.Amount <= 0 {
+			return nil, fmt.Errorf("payment amounts must be positive")
+		}
+		payments = append(payments, entity.SalePayment{
+			PaymentMethod: p.PaymentMethod,
+			Currency:      strings.ToUpper(p.Currency),
+			Amount:        p.Amount,
+		})
+	}
+
+	return payments, nil
+}
+
 // CreateSales creates a sale record.
 func (s *SalesUseCase) CreateSales(in *entity.SaleRequest) (*entity.SaleResponse, error) {
+	if in.PaymentMethod == "" {
+		in.PaymentMethod = "uzs"
+	}
+
+	if len(in.SoldProducts) == 0 {
+		return nil, fmt.Errorf("sale must have at least one product")
+	}
+
 	// Calculate total sale cost
 	total, err := s.CalculateTotalSales(in)
 	if err != nil {
@@ -55,35 +108,43 @@ func (s *SalesUseCase) CreateSales(in *entity.SaleRequest) (*entity.SaleResponse
 		return nil, fmt.Errorf("error calculating total sale cost: %w", err)
 	}
 
-	// Create sale in the database
-	res, err := s.repo.CreateSale(total)
+	// Payments may mix methods and currencies, what they leave unpaid of a credit sale becomes a debt
+	total.Payments, err = salePayments(in, total.TotalSalePrice)
 	if err != nil {
-		s.log.Error("Error creating sale", "error", err.Error())
-		return nil, fmt.Errorf("error creating sale: %w", err)
+		s.log.Error("Invalid sale payments", "error", err.Error())
+		return nil, fmt.Errorf("invalid sale payments: %w", err)
+	}
+	if len(total.Payments) > 0 {
+		total.PaymentMethod = total.Payments[0].PaymentMethod
 	}
 
-	// Update product stock
-	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, 10) // limit to 10 goroutines for product updates
-
-	for _, item := range res.SoldProducts {
-		wg.Add(1)
-		go func(item entity.SalesItem) {
-			defer wg.Done()
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			productQuantityReq := &entity.CountProductReq{
-				Id:    item.ProductID,
-				Count: item.Quantity,
+	// The debt can be spread over monthly installments starting at the next payment date.
+	// Its amount is known upfront only when everything is paid in the sale currency.
+	if in.OnCredit && in.Installments > 0 {
+		var paid float64
+		for _, p := range total.Payments {
+			if p.Currency != "" && p.Currency != total.Currency {
+				return nil, fmt.Errorf("installment plans require payments in the sale currency")
 			}
-			if _, err := s.product.RemoveProduct(productQuantityReq); err != nil {
-				s.log.Error("Error removing product quantity during sale", "error", err.Error())
+			paid += p.Amount
+		}
+
+		if paid < total.TotalSalePrice {
+			schedule := &entity.DebtScheduleRequest{Installments: in.Installments, FirstDueDate: in.NextPayment}
+			total.Schedule, err = buildInstallments(total.TotalSalePrice-paid, schedule)
+			if err != nil {
+				s.log.Error("Error building installment schedule", "error", err.Error())
+				return nil, fmt.Errorf("error building installment schedule: %w", err)
 			}
-		}(item)
+		}
 	}
 
-	wg.Wait()
+	// Create sale in the database, its stock is taken out in the same transaction
+	res, err := s.repo.CreateSale(total)
+	if err != nil {
+		s.log.Error("Error creating sale", "error", err.Error())
+		return nil, fmt.Errorf("error creating sale: %w", err)
+	}
 
 	return res, nil
 }
@@ -124,40 +185,24 @@ func (s *SalesUseCase) GetListSales(req *entity.SaleFilter) (*entity.SaleList, e
 	return res, nil
 }
 
-// DeleteSales deletes a sale record from the system.
-func (s *SalesUseCase) DeleteSales(req *entity.SaleID) (*entity.Message, error) {
-	// Fetch the sale to be deleted
-	sale, err := s.repo.GetSale(req)
-	if err != nil {
-		s.log.Error("Error fetching sale for deletion", "error", err.Error())
-		return nil, fmt.Errorf("error fetching sale for deletion: %w", err)
+// GetDailyReport summarizes the sales of a day, today by default, with a breakdown of payments.
+func (s *SalesUseCase) GetDailyReport(in *entity.DailySalesFilter) (*entity.DailySalesReport, error) {
+	if in.Date == "" {
+		in.Date = time.Now().Format("2006-01-02")
 	}
 
-	// Add logic to restore product stock if needed
-	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, 10)
-
-	for _, item := range sale.SoldProducts {
-		wg.Add(1)
-		go func(item entity.SalesItem) {
-			defer wg.Done()
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			productQuantityReq := &entity.CountProductReq{
-				Id:    item.ProductID,
-				Count: item.Quantity,
-			}
-
-			if _, err := s.product.AddProduct(productQuantityReq); err != nil {
-				s.log.Error("Error restoring product stock after sale deletion", "error", err.Error())
-			}
-		}(item)
+	res, err := s.repo.GetDailyReport(in)
+	if err != nil {
+		s.log.Error("Error building daily sales report", "error", err.Error())
+		return nil, fmt.Errorf("error building daily sales report: %w", err)
 	}
 
-	wg.Wait()
+	return res, nil
+}
 
-	// Delete the sale from the database
+// DeleteSales deletes a sale record from the system.
+func (s *SalesUseCase) DeleteSales(req *entity.SaleID) (*entity.Message, error) {
+	// Stock sold with the sale is returned in the same transaction
 	res, err := s.repo.DeleteSale(req)
 	if err != nil {
 		s.log.Error("Error deleting sale", "error", err.Error())
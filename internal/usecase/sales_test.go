@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"reflect"
+	"testing"
+)
+
+func TestSalePayments(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      entity.SaleRequest
+		total   float64
+		want    []entity.SalePayment
+		wantErr bool
+	}{
+		{
+			name:  "single method pays in full",
+			in:    entity.SaleRequest{PaymentMethod: "card"},
+			total: 100,
+			want:  []entity.SalePayment{{PaymentMethod: "card", Amount: 100}},
+		},
+		{
+			name:  "credit sale pays the upfront amount",
+			in:    entity.SaleRequest{PaymentMethod: "uzs", OnCredit: true, PaidAmount: 30},
+			total: 100,
+			want:  []entity.SalePayment{{PaymentMethod: "uzs", Amount: 30}},
+		},
+		{
+			name:  "credit sale without upfront payment",
+			in:    entity.SaleRequest{PaymentMethod: "uzs", OnCredit: true},
+			total: 100,
+			want:  nil,
+		},
+		{
+			name:    "credit upfront above the total",
+			in:      entity.SaleRequest{PaymentMethod: "uzs", OnCredit: true, PaidAmount: 120},
+			total:   100,
+			wantErr: true,
+		},
+		{
+			name: "split payments in several currencies",
+			in: entity.SaleRequest{Payments: []entity.SalePaymentRequest{
+				{PaymentMethod: "card", Amount: 60},
+				{PaymentMethod: "usd", Currency: "usd", Amount: 5},
+				{Amount: 10},
+			}},
+			total: 100,
+			want: []entity.SalePayment{
+				{PaymentMethod: "card", Amount: 60},
+				{PaymentMethod: "usd", Currency: "USD", Amount: 5},
+				{PaymentMethod: "uzs", Amount: 10},
+			},
+		},
+		{
+			name:    "unknown payment method",
+			in:      entity.SaleRequest{Payments: []entity.SalePaymentRequest{{PaymentMethod: "cheque", Amount: 100}}},
+			total:   100,
+			wantErr: true,
+		},
+		{
+			name:    "zero payment",
+			in:      entity.SaleRequest{Payments: []entity.SalePaymentRequest{{PaymentMethod: "uzs", Amount: 0}}},
+			total:   100,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := salePayments(&tt.in, tt.total)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("salePayments() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("salePayments() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("salePayments() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+)
+
+type ShiftsUseCase struct {
+	repo ShiftsRepo
+	log  *slog.Logger
+}
+
+func NewShiftsUseCase(repo ShiftsRepo, log *slog.Logger) *ShiftsUseCase {
+	return &ShiftsUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateBranch adds a branch of the organization.
+func (s *ShiftsUseCase) CreateBranch(in *entity.BranchRequest) (*entity.Branch, error) {
+	if in.Name == "" {
+		return nil, fmt.Errorf("branch name is required")
+	}
+
+	res, err := s.repo.CreateBranch(in)
+	if err != nil {
+		s.log.Error("Error creating branch", "error", err.Error())
+		return nil, fmt.Errorf("error creating branch: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetBranches retrieves all branches.
+func (s *ShiftsUseCase) GetBranches() (*entity.BranchList, error) {
+	res, err := s.repo.GetBranches()
+	if err != nil {
+		s.log.Error("Error fetching branches", "error", err.Error())
+		return nil, fmt.Errorf("error fetching branches: %w", err)
+	}
+
+	return res, nil
+}
+
+// CreateRegister adds a cash register whose drawer is the given wallet.
+func (s *ShiftsUseCase) CreateRegister(in *entity.RegisterRequest) (*entity.Register, error) {
+	if in.Name == "" || in.WalletID == "" {
+		return nil, fmt.Errorf("register name and wallet are required")
+	}
+
+	res, err := s.repo.CreateRegister(in)
+	if err != nil {
+		s.log.Error("Error creating register", "error", err.Error())
+		return nil, fmt.Errorf("error creating register: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetRegisters retrieves all cash registers.
+func (s *ShiftsUseCase) GetRegisters() (*entity.RegisterList, error) {
+	res, err := s.repo.GetRegisters()
+	if err != nil {
+		s.log.Error("Error fetching registers", "error", err.Error())
+		return nil, fmt.Errorf("error fetching registers: %w", err)
+	}
+
+	return res, nil
+}
+
+// OpenShift starts a shift for a user at a register with the counted opening float.
+func (s *ShiftsUseCase) OpenShift(in *entity.ShiftOpen) (*entity.Shift, error) {
+	if in.OpeningCash < 0 {
+		return nil, fmt.Errorf("opening cash cannot be negative")
+	}
+
+	res, err := s.repo.OpenShift(in)
+	if err != nil {
+		s.log.Error("Error opening shift", "error", err.Error())
+		return nil, fmt.Errorf("error opening shift: %w", err)
+	}
+
+	return res, nil
+}
+
+// CloseShift closes a shift with the counted cash and returns its Z-report.
+func (s *ShiftsUseCase) CloseShift(in *entity.ShiftClose) (*entity.ShiftReport, error) {
+	if in.CountedCash < 0 {
+		return nil, fmt.Errorf("counted cash cannot be negative")
+	}
+
+	res, err := s.repo.CloseShift(in)
+	if err != nil {
+		s.log.Error("Error closing shift", "error", err.Error())
+		return nil, fmt.Errorf("error closing shift: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetShift retrieves a shift by ID.
+func (s *ShiftsUseCase) GetShift(in *entity.ShiftID) (*entity.Shift, error) {
+	res, err := s.repo.GetShift(in)
+	if err != nil {
+		s.log.Error("Error fetching shift", "error", err.Error())
+		return nil, fmt.Errorf("error fetching shift: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetShifts retrieves shifts by register, user or status.
+func (s *ShiftsUseCase) GetShifts(in *entity.ShiftFilter) (*entity.ShiftList, error) {
+	res, err := s.repo.GetShifts(in)
+	if err != nil {
+		s.log.Error("Error fetching shifts", "error", err.Error())
+		return nil, fmt.Errorf("error fetching shifts: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetShiftReport returns the X-report of an open shift or the Z-report of a closed one.
+func (s *ShiftsUseCase) GetShiftReport(in *entity.ShiftID) (*entity.ShiftReport, error) {
+	res, err := s.repo.GetShiftReport(in)
+	if err != nil {
+		s.log.Error("Error building shift report", "error", err.Error())
+		return nil, fmt.Errorf("error building shift report: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+)
+
+type StocktakesUseCase struct {
+	repo StocktakesRepo
+	log  *slog.Logger
+}
+
+func NewStocktakesUseCase(repo StocktakesRepo, log *slog.Logger) *StocktakesUseCase {
+	return &StocktakesUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateStocktake starts a stocktake with the expected stock of the products in scope.
+func (s *StocktakesUseCase) CreateStocktake(in *entity.StocktakeRequest) (*entity.Stocktake, error) {
+	res, err := s.repo.CreateStocktake(in)
+	if err != nil {
+		s.log.Error("Error creating stocktake", "error", err.Error())
+		return nil, fmt.Errorf("error creating stocktake: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetStocktake returns a stocktake with how many of its products were counted.
+func (s *StocktakesUseCase) GetStocktake(in *entity.StocktakeID) (*entity.Stocktake, error) {
+	res, err := s.repo.GetStocktake(in)
+	if err != nil {
+		s.log.Error("Error fetching stocktake", "error", err.Error())
+		return nil, fmt.Errorf("error fetching stocktake: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetStocktakes lists stocktakes, optionally by status.
+func (s *StocktakesUseCase) GetStocktakes(in *entity.StocktakeFilter) (*entity.StocktakeList, error) {
+	switch in.Status {
+	case "", "counting", "posted", "cancelled":
+	default:
+		return nil, fmt.Errorf("status must be counting, posted or cancelled")
+	}
+
+	res, err := s.repo.GetStocktakes(in)
+	if err != nil {
+		s.log.Error("Error fetching stocktakes", "error", err.Error())
+		return nil, fmt.Errorf("error fetching stocktakes: %w", err)
+	}
+
+	return res, nil
+}
+
+// AddCounts adds counted quantities to a stocktake.
+func (s *StocktakesUseCase) AddCounts(in *entity.StocktakeCountRequest) (*entity.Stocktake, error) {
+	if len(in.Items) == 0 {
+		return nil, fmt.Errorf("at least one count is required")
+	}
+	for _, item := range in.Items {
+		if item.ProductID == "" && item.Barcode == "" {
+			return nil, fmt.Errorf("every count needs a product ID or a barcode")
+		}
+	}
+
+	res, err := s.repo.AddCounts(in)
+	if err != nil {
+		s.log.Error("Error adding stocktake counts", "error", err.Error())
+		return nil, fmt.Errorf("error adding stocktake counts: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetVariance returns the variance list of a stocktake with its cost impact.
+func (s *StocktakesUseCase) GetVariance(in *entity.StocktakeLineFilter) (*entity.StocktakeVariance, error) {
+	res, err := s.repo.GetVariance(in)
+	if err != nil {
+		s.log.Error("Error fetching stocktake variance", "error", err.Error())
+		return nil, fmt.Errorf("error fetching stocktake variance: %w", err)
+	}
+
+	return res, nil
+}
+
+// PostStocktake posts the variances of a stocktake as inventory adjustments.
+func (s *StocktakesUseCase) PostStocktake(in *entity.StocktakePost) (*entity.StocktakeVariance, error) {
+	res, err := s.repo.PostStocktake(in)
+	if err != nil {
+		s.log.Error("Error posting stocktake", "error", err.Error())
+		return nil, fmt.Errorf("error posting stocktake: %w", err)
+	}
+
+	return res, nil
+}
+
+// CancelStocktake drops a stocktake that was not posted, stock stays as it is.
+func (s *StocktakesUseCase) CancelStocktake(in *entity.StocktakeID) (*entity.Stocktake, error) {
+	res, err := s.repo.CancelStocktake(in)
+	if err != nil {
+		s.log.Error("Error cancelling stocktake", "error", err.Error())
+		return nil, fmt.Errorf("error cancelling stocktake: %w", err)
+	}
+
+	return res, nil
+}
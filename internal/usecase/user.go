@@ -96,7 +96,7 @@ func (u *UserUseCase) DeleteUser(in entity.UserID) (entity.Message, error) {
 }
 
 func (u *UserUseCase) LogIn(in entity.LogIn) (entity.Token, error) {
-	phone := entity.PhoneNumber{in.PhoneNumber}
+	phone := entity.PhoneNumber{PhoneNumber: in.PhoneNumber}
 
 	res, err := u.repo.LogIn(phone)
 	if err != nil {
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type WalletsUseCase struct {
+	repo WalletsRepo
+	log  *slog.Logger
+}
+
+func NewWalletsUseCase(repo WalletsRepo, log *slog.Logger) *WalletsUseCase {
+	return &WalletsUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateWallet adds a place where money is kept, e.g. a drawer, a safe or an acquiring account.
+func (w *WalletsUseCase) CreateWallet(in *entity.WalletRequest) (*entity.Wallet, error) {
+	if in.Name == "" {
+		return nil, fmt.Errorf("wallet name is required")
+	}
+	switch in.PaymentMethod {
+	case "uzs", "usd", "card":
+	default:
+		return nil, fmt.Errorf("unknown payment method %q", in.PaymentMethod)
+	}
+	in.Currency = strings.ToUpper(in.Currency)
+	if len(in.Currency) != 3 {
+		return nil, fmt.Errorf("currency must be a 3-letter code")
+	}
+
+	res, err := w.repo.CreateWallet(in)
+	if err != nil {
+		w.log.Error("Error creating wallet", "error", err.Error())
+		return nil, fmt.Errorf("error creating wallet: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateWallet renames a wallet or makes it the default one for its payment method.
+func (w *WalletsUseCase) UpdateWallet(in *entity.WalletRequest) (*entity.Wallet, error) {
+	res, err := w.repo.UpdateWallet(in)
+	if err != nil {
+		w.log.Error("Error updating wallet", "error", err.Error())
+		return nil, fmt.Errorf("error updating wallet: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteWallet removes a wallet that was never used.
+func (w *WalletsUseCase) DeleteWallet(in *entity.WalletID) (*entity.Message, error) {
+	res, err := w.repo.DeleteWallet(in)
+	if err != nil {
+		w.log.Error("Error deleting wallet", "error", err.Error())
+		return nil, fmt.Errorf("error deleting wallet: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetWallet retrieves a wallet with its current balance.
+func (w *WalletsUseCase) GetWallet(in *entity.WalletID) (*entity.Wallet, error) {
+	res, err := w.repo.GetWallet(in)
+	if err != nil {
+		w.log.Error("Error fetching wallet", "error", err.Error())
+		return nil, fmt.Errorf("error fetching wallet: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetWallets retrieves all wallets with their current balances.
+func (w *WalletsUseCase) GetWallets() (*entity.WalletList, error) {
+	res, err := w.repo.GetWallets()
+	if err != nil {
+		w.log.Error("Error fetching wallets", "error", err.Error())
+		return nil, fmt.Errorf("error fetching wallets: %w", err)
+	}
+
+	return res, nil
+}
+
+// Transfer moves money between wallets, e.g. collecting the drawer into the safe.
+func (w *WalletsUseCase) Transfer(in *entity.WalletTransferRequest) (*entity.WalletTransfer, error) {
+	if in.Amount <= 0 || in.ToAmount < 0 {
+		return nil, fmt.Errorf("transfer amount must be positive")
+	}
+	if in.FromWalletID == in.ToWalletID {
+		return nil, fmt.Errorf("cannot transfer to the same wallet")
+	}
+
+	res, err := w.repo.Transfer(in)
+	if err != nil {
+		w.log.Error("Error transferring between wallets", "error", err.Error())
+		return nil, fmt.Errorf("error transferring between wallets: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetTransfers retrieves transfers between wallets.
+func (w *WalletsUseCase) GetTransfers(in *entity.WalletTransferFilter) (*entity.WalletTransferList, error) {
+	res, err := w.repo.GetTransfers(in)
+	if err != nil {
+		w.log.Error("Error fetching transfers", "error", err.Error())
+		return nil, fmt.Errorf("error fetching transfers: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetBalancesAt calculates wallet balances at the end of the given day, today by default.
+func (w *WalletsUseCase) GetBalancesAt(in *entity.WalletBalanceFilter) (*entity.WalletBalanceList, error) {
+	if in.Date == "" {
+		in.Date = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", in.Date); err != nil {
+		return nil, fmt.Errorf("date must be in YYYY-MM-DD format")
+	}
+
+	res, err := w.repo.GetBalancesAt(in)
+	if err != nil {
+		w.log.Error("Error fetching wallet balances", "error", err.Error())
+		return nil, fmt.Errorf("error fetching wallet balances: %w", err)
+	}
+
+	return res, nil
+}
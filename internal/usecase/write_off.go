@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"crm-admin/internal/entity"
+	"fmt"
+	"log/slog"
+)
+
+type WriteOffsUseCase struct {
+	repo WriteOffsRepo
+	log  *slog.Logger
+}
+
+func NewWriteOffsUseCase(repo WriteOffsRepo, log *slog.Logger) *WriteOffsUseCase {
+	return &WriteOffsUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateWriteOff writes off goods, posting at once or waiting for approval depending on their cost.
+func (w *WriteOffsUseCase) CreateWriteOff(in *entity.WriteOffRequest) (*entity.WriteOff, error) {
+	switch in.Reason {
+	case "damaged", "expired", "lost", "other":
+	default:
+		return nil, fmt.Errorf("reason must be damaged, expired, lost or other")
+	}
+	if in.Reason == "other" && in.Note == "" {
+		return nil, fmt.Errorf("a note is required when the reason is other")
+	}
+	if len(in.Items) == 0 {
+		return nil, fmt.Errorf("write-off must have at least one line")
+	}
+	for _, item := range in.Items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("written off quantities must be positive")
+		}
+	}
+
+	res, err := w.repo.CreateWriteOff(in)
+	if err != nil {
+		w.log.Error("Error creating write-off", "error", err.Error())
+		return nil, fmt.Errorf("error creating write-off: %w", err)
+	}
+
+	return res, nil
+}
+
+// ApproveWriteOff posts a pending write-off on behalf of an owner.
+func (w *WriteOffsUseCase) ApproveWriteOff(in *entity.WriteOffDecision) (*entity.WriteOff, error) {
+	res, err := w.repo.ApproveWriteOff(in)
+	if err != nil {
+		w.log.Error("Error approving write-off", "error", err.Error())
+		return nil, fmt.Errorf("error approving write-off: %w", err)
+	}
+
+	return res, nil
+}
+
+// RejectWriteOff turns down a pending write-off, stock stays as it is.
+func (w *WriteOffsUseCase) RejectWriteOff(in *entity.WriteOffDecision) (*entity.WriteOff, error) {
+	if in.Reason == "" {
+		return nil, fmt.Errorf("reason is required to reject a write-off")
+	}
+
+	res, err := w.repo.RejectWriteOff(in)
+	if err != nil {
+		w.log.Error("Error rejecting write-off", "error", err.Error())
+		return nil, fmt.Errorf("error rejecting write-off: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetWriteOff returns a write-off with its lines.
+func (w *WriteOffsUseCase) GetWriteOff(in *entity.WriteOffID) (*entity.WriteOff, error) {
+	res, err := w.repo.GetWriteOff(in)
+	if err != nil {
+		w.log.Error("Error fetching write-off", "error", err.Error())
+		return nil, fmt.Errorf("error fetching write-off: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetWriteOffList lists write-offs by status, reason and dates.
+func (w *WriteOffsUseCase) GetWriteOffList(in *entity.WriteOffFilter) (*entity.WriteOffList, error) {
+	res, err := w.repo.GetWriteOffList(in)
+	if err != nil {
+		w.log.Error("Error fetching write-offs", "error", err.Error())
+		return nil, fmt.Errorf("error fetching write-offs: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetThreshold returns the cost above which write-offs need approval.
+func (w *WriteOffsUseCase) GetThreshold() (*entity.WriteOffThreshold, error) {
+	res, err := w.repo.GetThreshold()
+	if err != nil {
+		w.log.Error("Error fetching write-off threshold", "error", err.Error())
+		return nil, fmt.Errorf("error fetching write-off threshold: %w", err)
+	}
+
+	return res, nil
+}
+
+// SetThreshold changes the cost above which write-offs need approval.
+func (w *WriteOffsUseCase) SetThreshold(in *entity.WriteOffThreshold) (*entity.WriteOffThreshold, error) {
+	if in.Amount < 0 {
+		return nil, fmt.Errorf("threshold cannot be negative")
+	}
+
+	res, err := w.repo.SetThreshold(in)
+	if err != nil {
+		w.log.Error("Error setting write-off threshold", "error", err.Error())
+		return nil, fmt.Errorf("error setting write-off threshold: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_debt_payments_debt_id;
+DROP INDEX IF EXISTS idx_debts_order_id;
+
+ALTER TABLE debt_payments
+    DROP COLUMN IF EXISTS payment_method;
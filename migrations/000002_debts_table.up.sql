@@ -0,0 +1,6 @@
+-- Способ оплаты для платежей по долгам
+ALTER TABLE debt_payments
+    ADD COLUMN payment_method payment_method DEFAULT 'uzs';
+
+CREATE INDEX idx_debts_order_id ON debts (order_id);
+CREATE INDEX idx_debt_payments_debt_id ON debt_payments (debt_id);
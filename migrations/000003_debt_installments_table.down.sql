@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_debt_installments_debt_id;
+
+ALTER TABLE debts
+    DROP COLUMN IF EXISTS overdue_since,
+    DROP COLUMN IF EXISTS is_overdue;
+
+DROP TABLE IF EXISTS debt_payment_allocations;
+DROP TABLE IF EXISTS debt_installments;
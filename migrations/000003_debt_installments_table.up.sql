@@ -0,0 +1,29 @@
+-- График погашения долга по частям
+CREATE TABLE debt_installments
+(
+    id             UUID           DEFAULT gen_random_uuid() PRIMARY KEY,
+    debt_id        UUID REFERENCES debts (id) NOT NULL,
+    installment_no INT                        NOT NULL, -- Порядковый номер взноса
+    due_date       DATE                       NOT NULL, -- Дата, до которой нужно оплатить
+    amount         DECIMAL(10, 2)             NOT NULL, -- Сумма взноса
+    amount_paid    DECIMAL(10, 2) DEFAULT 0   NOT NULL, -- Сколько уже погашено
+    is_paid        BOOLEAN        DEFAULT FALSE,
+    paid_at        TIMESTAMP,
+    UNIQUE (debt_id, installment_no)
+);
+
+-- Распределение платежей по взносам
+CREATE TABLE debt_payment_allocations
+(
+    id             UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    payment_id     UUID REFERENCES debt_payments (id)     NOT NULL,
+    installment_id UUID REFERENCES debt_installments (id) NOT NULL,
+    amount         DECIMAL(10, 2)                         NOT NULL
+);
+
+-- Признак просрочки, обновляется ежедневной задачей
+ALTER TABLE debts
+    ADD COLUMN is_overdue    BOOLEAN DEFAULT FALSE,
+    ADD COLUMN overdue_since DATE;
+
+CREATE INDEX idx_debt_installments_debt_id ON debt_installments (debt_id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_reminder_logs_debt_id;
+
+DROP TABLE IF EXISTS reminder_logs;
+DROP TABLE IF EXISTS reminder_rules;
+
+ALTER TABLE clients
+    DROP COLUMN IF EXISTS telegram_chat_id,
+    DROP COLUMN IF EXISTS language;
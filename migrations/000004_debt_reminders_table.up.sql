@@ -0,0 +1,47 @@
+-- Контакты клиента для напоминаний
+ALTER TABLE clients
+    ADD COLUMN language         VARCHAR(2) DEFAULT 'uz', -- uz или ru
+    ADD COLUMN telegram_chat_id VARCHAR(32);
+
+-- Правила напоминаний о платежах по долгам
+CREATE TABLE reminder_rules
+(
+    id          UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    name        VARCHAR(50)   NOT NULL,
+    kind        VARCHAR(10)   NOT NULL, -- before_due, on_due, overdue
+    days        INT DEFAULT 0 NOT NULL, -- за N дней до срока или каждые N дней просрочки
+    channel     VARCHAR(10)   NOT NULL, -- sms или telegram
+    template_uz TEXT          NOT NULL,
+    template_ru TEXT          NOT NULL,
+    is_active   BOOLEAN   DEFAULT TRUE,
+    created_at  TIMESTAMP DEFAULT NOW()
+);
+
+-- Журнал всех попыток отправки напоминаний
+CREATE TABLE reminder_logs
+(
+    id             UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    rule_id        UUID REFERENCES reminder_rules (id),
+    debt_id        UUID REFERENCES debts (id) NOT NULL,
+    installment_id UUID REFERENCES debt_installments (id),
+    due_date       DATE,
+    channel        VARCHAR(10)                NOT NULL,
+    recipient      VARCHAR(50),
+    message        TEXT                       NOT NULL,
+    status         VARCHAR(10)                NOT NULL, -- sent или failed
+    error          TEXT,
+    created_at     TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_reminder_logs_debt_id ON reminder_logs (debt_id);
+
+INSERT INTO reminder_rules (name, kind, days, channel, template_uz, template_ru)
+VALUES ('3 kun oldin', 'before_due', 3, 'sms',
+        'Hurmatli {client}, {due_date} gacha {amount} to''lov qilishingiz kerakligini eslatamiz.',
+        'Уважаемый(ая) {client}, напоминаем об оплате {amount} до {due_date}.'),
+       ('To''lov kuni', 'on_due', 0, 'sms',
+        'Hurmatli {client}, bugun {amount} to''lov qilish muddati.',
+        'Уважаемый(ая) {client}, сегодня срок оплаты {amount}.'),
+       ('Har 7 kunda kechikish', 'overdue', 7, 'sms',
+        'Hurmatli {client}, {due_date} dagi {amount} to''lov {days_overdue} kunga kechikdi.',
+        'Уважаемый(ая) {client}, платёж {amount} от {due_date} просрочен на {days_overdue} дн.');
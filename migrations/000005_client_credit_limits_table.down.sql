@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_credit_limit_overrides_client_id;
+
+DROP TABLE IF EXISTS credit_limit_overrides;
+
+ALTER TABLE clients
+    DROP COLUMN IF EXISTS credit_limit;
@@ -0,0 +1,19 @@
+-- Кредитный лимит клиента, NULL — без ограничения
+ALTER TABLE clients
+    ADD COLUMN credit_limit DECIMAL(10, 2);
+
+-- Продажи в долг сверх лимита, разрешённые владельцем
+CREATE TABLE credit_limit_overrides
+(
+    id           UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    client_id    UUID REFERENCES clients (id)    NOT NULL,
+    sale_id      UUID REFERENCES sales (id)      NOT NULL,
+    approved_by  UUID REFERENCES users (user_id) NOT NULL, -- Владелец, разрешивший превышение
+    reason       TEXT                            NOT NULL,
+    credit_limit DECIMAL(10, 2)                  NOT NULL, -- Лимит на момент продажи
+    outstanding  DECIMAL(10, 2)                  NOT NULL, -- Долг клиента до продажи
+    sale_debt    DECIMAL(10, 2)                  NOT NULL, -- Новый долг по продаже
+    created_at   TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_credit_limit_overrides_client_id ON credit_limit_overrides (client_id);
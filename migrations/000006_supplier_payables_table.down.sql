@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_supplier_payments_supplier_id;
+DROP INDEX IF EXISTS idx_supplier_payments_purchase_id;
+
+DROP TABLE IF EXISTS supplier_payments;
+
+ALTER TABLE purchases
+    DROP COLUMN IF EXISTS due_date,
+    DROP COLUMN IF EXISTS amount_paid;
+
+ALTER TABLE clients
+    DROP COLUMN IF EXISTS payment_terms_days;
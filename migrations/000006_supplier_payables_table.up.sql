@@ -0,0 +1,28 @@
+-- Отсрочка платежа поставщику в днях
+ALTER TABLE clients
+    ADD COLUMN payment_terms_days INT DEFAULT 0;
+
+-- Оплаченная часть закупки и срок оплаты остатка
+ALTER TABLE purchases
+    ADD COLUMN amount_paid DECIMAL(10, 2) DEFAULT 0 NOT NULL,
+    ADD COLUMN due_date    DATE;
+
+-- Существующие закупки считаются полностью оплаченными
+UPDATE purchases
+SET amount_paid = total_cost;
+
+-- Платежи поставщикам
+CREATE TABLE supplier_payments
+(
+    id             UUID           DEFAULT gen_random_uuid() PRIMARY KEY,
+    purchase_id    UUID REFERENCES purchases (id)  NOT NULL,
+    supplier_id    UUID REFERENCES clients (id)    NOT NULL,
+    amount         DECIMAL(10, 2)                  NOT NULL,
+    payment_method payment_method DEFAULT 'uzs',
+    paid_by        UUID REFERENCES users (user_id) NOT NULL, -- Кто произвёл оплату
+    description    TEXT,
+    payment_date   TIMESTAMP      DEFAULT NOW()
+);
+
+CREATE INDEX idx_supplier_payments_purchase_id ON supplier_payments (purchase_id);
+CREATE INDEX idx_supplier_payments_supplier_id ON supplier_payments (supplier_id);
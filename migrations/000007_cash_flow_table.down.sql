@@ -0,0 +1,16 @@
+DELETE FROM cash_flow
+WHERE category_id IN (SELECT id FROM cash_category WHERE code IS NOT NULL);
+
+DELETE FROM cash_category
+WHERE code IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_cash_flow_reference;
+DROP INDEX IF EXISTS idx_cash_flow_transaction_date;
+
+ALTER TABLE cash_flow
+    DROP COLUMN IF EXISTS reference_id,
+    DROP COLUMN IF EXISTS reference_type;
+
+ALTER TABLE cash_category
+    DROP COLUMN IF EXISTS transaction_type,
+    DROP COLUMN IF EXISTS code;
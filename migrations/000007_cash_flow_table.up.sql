@@ -0,0 +1,17 @@
+-- Системные категории определяются кодом, пользовательские — без кода
+ALTER TABLE cash_category
+    ADD COLUMN code             VARCHAR(30) UNIQUE,
+    ADD COLUMN transaction_type transaction_type;
+
+-- Документ, по которому проведена запись (NULL — ручная запись)
+ALTER TABLE cash_flow
+    ADD COLUMN reference_type VARCHAR(30),
+    ADD COLUMN reference_id   UUID;
+
+CREATE INDEX idx_cash_flow_transaction_date ON cash_flow (transaction_date);
+CREATE INDEX idx_cash_flow_reference ON cash_flow (reference_type, reference_id);
+
+INSERT INTO cash_category (name, code, transaction_type)
+VALUES ('Продажи', 'sales', 'income'),
+       ('Погашение долгов', 'debt_payment', 'income'),
+       ('Оплата поставщикам', 'supplier_payment', 'expense');
@@ -0,0 +1,16 @@
+DELETE FROM cash_flow
+WHERE reference_type = 'transfer';
+
+DELETE FROM cash_category
+WHERE code = 'transfer';
+
+DROP INDEX IF EXISTS idx_cash_flow_wallet_id;
+
+ALTER TABLE cash_flow
+    DROP COLUMN IF EXISTS wallet_id;
+
+DROP TABLE IF EXISTS wallet_transfers;
+
+DROP INDEX IF EXISTS idx_wallets_default;
+
+DROP TABLE IF EXISTS wallets;
@@ -0,0 +1,55 @@
+-- Кошельки: касса, сейф, конверт USD, эквайринг
+CREATE TABLE wallets
+(
+    id             UUID           DEFAULT gen_random_uuid() PRIMARY KEY,
+    name           VARCHAR(100)   NOT NULL,
+    currency       VARCHAR(3)     NOT NULL,
+    payment_method payment_method NOT NULL,
+    is_default     BOOLEAN        DEFAULT FALSE,        -- Кошелёк по умолчанию для способа оплаты
+    balance        DECIMAL(14, 2) DEFAULT 0 NOT NULL,   -- Текущий остаток
+    created_at     TIMESTAMP      DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_wallets_default ON wallets (payment_method) WHERE is_default;
+
+INSERT INTO wallets (name, currency, payment_method, is_default)
+VALUES ('Касса UZS', 'UZS', 'uzs', TRUE),
+       ('Конверт USD', 'USD', 'usd', TRUE),
+       ('Эквайринг', 'UZS', 'card', TRUE),
+       ('Сейф', 'UZS', 'uzs', FALSE);
+
+-- Переводы между кошельками, в т.ч. инкассация кассы в сейф
+CREATE TABLE wallet_transfers
+(
+    id             UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    from_wallet_id UUID REFERENCES wallets (id)    NOT NULL,
+    to_wallet_id   UUID REFERENCES wallets (id)    NOT NULL,
+    amount         DECIMAL(14, 2)                  NOT NULL, -- Списано с источника
+    to_amount      DECIMAL(14, 2)                  NOT NULL, -- Зачислено получателю (отличается при обмене валют)
+    user_id        UUID REFERENCES users (user_id) NOT NULL,
+    description    TEXT,
+    created_at     TIMESTAMP DEFAULT NOW()
+);
+
+-- Каждая запись денежного потока привязана к кошельку
+ALTER TABLE cash_flow
+    ADD COLUMN wallet_id UUID REFERENCES wallets (id);
+
+UPDATE cash_flow f
+SET wallet_id = w.id
+FROM wallets w
+WHERE w.is_default
+  AND w.payment_method = COALESCE(f.payment_method, 'uzs');
+
+ALTER TABLE cash_flow
+    ALTER COLUMN wallet_id SET NOT NULL;
+
+CREATE INDEX idx_cash_flow_wallet_id ON cash_flow (wallet_id);
+
+UPDATE wallets w
+SET balance = COALESCE((SELECT SUM(CASE WHEN f.transaction_type = 'income' THEN f.amount ELSE -f.amount END)
+                        FROM cash_flow f
+                        WHERE f.wallet_id = w.id), 0);
+
+INSERT INTO cash_category (name, code)
+VALUES ('Перевод между кошельками', 'transfer');
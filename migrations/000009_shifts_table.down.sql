@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_cash_flow_shift_id;
+DROP INDEX IF EXISTS idx_sales_shift_id;
+
+ALTER TABLE cash_flow
+    DROP COLUMN IF EXISTS shift_id;
+
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS shift_id;
+
+DROP INDEX IF EXISTS idx_shifts_open_user;
+DROP INDEX IF EXISTS idx_shifts_open_register;
+
+DROP TABLE IF EXISTS shifts;
+DROP TABLE IF EXISTS registers;
@@ -0,0 +1,43 @@
+-- Кассовые аппараты, наличные которых лежат в кошельке
+CREATE TABLE registers
+(
+    id         UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    name       VARCHAR(100)                 NOT NULL,
+    wallet_id  UUID REFERENCES wallets (id) NOT NULL, -- Денежный ящик кассы
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+INSERT INTO registers (name, wallet_id)
+SELECT 'Касса 1', id
+FROM wallets
+WHERE is_default
+  AND payment_method = 'uzs';
+
+-- Смены кассиров
+CREATE TABLE shifts
+(
+    id            UUID           DEFAULT gen_random_uuid() PRIMARY KEY,
+    register_id   UUID REFERENCES registers (id)  NOT NULL,
+    user_id       UUID REFERENCES users (user_id) NOT NULL,
+    status        VARCHAR(10)    DEFAULT 'open'   NOT NULL, -- open / closed
+    opening_cash  DECIMAL(14, 2) DEFAULT 0        NOT NULL, -- Размен на начало смены
+    opened_at     TIMESTAMP      DEFAULT NOW(),
+    expected_cash DECIMAL(14, 2),                           -- Ожидаемые наличные при закрытии
+    counted_cash  DECIMAL(14, 2),                           -- Пересчитанные наличные
+    discrepancy   DECIMAL(14, 2),                           -- Излишек (+) или недостача (-)
+    note          TEXT,
+    closed_at     TIMESTAMP
+);
+
+-- Одна открытая смена на кассу и на пользователя
+CREATE UNIQUE INDEX idx_shifts_open_register ON shifts (register_id) WHERE status = 'open';
+CREATE UNIQUE INDEX idx_shifts_open_user ON shifts (user_id) WHERE status = 'open';
+
+ALTER TABLE sales
+    ADD COLUMN shift_id UUID REFERENCES shifts (id);
+
+ALTER TABLE cash_flow
+    ADD COLUMN shift_id UUID REFERENCES shifts (id);
+
+CREATE INDEX idx_sales_shift_id ON sales (shift_id);
+CREATE INDEX idx_cash_flow_shift_id ON cash_flow (shift_id);
@@ -0,0 +1,13 @@
+ALTER TABLE cash_flow
+    DROP COLUMN IF EXISTS exchange_rate;
+
+ALTER TABLE purchases
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS currency;
+
+DROP TABLE IF EXISTS exchange_rates;
+DROP TABLE IF EXISTS settings;
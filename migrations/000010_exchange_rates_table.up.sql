@@ -0,0 +1,35 @@
+-- Настройки организации
+CREATE TABLE settings
+(
+    key   VARCHAR(50) PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+INSERT INTO settings (key, value)
+VALUES ('base_currency', 'UZS');
+
+-- Курсы валют: сколько единиц базовой валюты стоит единица валюты на дату
+CREATE TABLE exchange_rates
+(
+    id         UUID        DEFAULT gen_random_uuid() PRIMARY KEY,
+    currency   VARCHAR(3)                 NOT NULL,
+    rate       DECIMAL(18, 6)             NOT NULL,
+    rate_date  DATE                       NOT NULL,
+    source     VARCHAR(20) DEFAULT 'manual' NOT NULL, -- manual / provider
+    created_at TIMESTAMP   DEFAULT NOW(),
+    UNIQUE (currency, rate_date)
+);
+
+-- Валюта документа и курс, зафиксированный в момент операции.
+-- Старые документы считались в одной валюте, поэтому остаются в базовой с курсом 1
+ALTER TABLE sales
+    ADD COLUMN currency      VARCHAR(3)     DEFAULT 'UZS' NOT NULL,
+    ADD COLUMN exchange_rate DECIMAL(18, 6) DEFAULT 1     NOT NULL;
+
+ALTER TABLE purchases
+    ADD COLUMN currency      VARCHAR(3)     DEFAULT 'UZS' NOT NULL,
+    ADD COLUMN exchange_rate DECIMAL(18, 6) DEFAULT 1     NOT NULL;
+
+-- Сумма записи в валюте кошелька, курс валюты кошелька на момент проводки
+ALTER TABLE cash_flow
+    ADD COLUMN exchange_rate DECIMAL(18, 6) DEFAULT 1 NOT NULL;
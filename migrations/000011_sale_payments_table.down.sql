@@ -0,0 +1,4 @@
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS change_amount;
+
+DROP TABLE IF EXISTS sale_payments;
@@ -0,0 +1,26 @@
+-- Оплаты продажи: одна продажа может быть оплачена несколькими способами
+CREATE TABLE sale_payments
+(
+    id             UUID           DEFAULT gen_random_uuid() PRIMARY KEY,
+    sale_id        UUID REFERENCES sales (id) NOT NULL,
+    payment_method payment_method             NOT NULL,
+    currency       VARCHAR(3)                 NOT NULL,
+    amount         DECIMAL(10, 2)             NOT NULL, -- сумма, полученная от клиента
+    change_amount  DECIMAL(10, 2) DEFAULT 0   NOT NULL, -- сдача в валюте оплаты
+    exchange_rate  DECIMAL(18, 6) DEFAULT 1   NOT NULL,
+    created_at     TIMESTAMP      DEFAULT NOW()
+);
+
+CREATE INDEX idx_sale_payments_sale ON sale_payments (sale_id);
+
+-- Сдача по продаже в валюте продажи
+ALTER TABLE sales
+    ADD COLUMN change_amount DECIMAL(10, 2) DEFAULT 0 NOT NULL;
+
+-- Старые продажи оплачивались одним способом: всё, что не ушло в долг
+INSERT INTO sale_payments (sale_id, payment_method, currency, amount, exchange_rate, created_at)
+SELECT s.id, COALESCE(s.payment_method, 'uzs'), s.currency,
+       s.total_sale_price - COALESCE(d.total_debt, 0), s.exchange_rate, s.created_at
+FROM sales s
+LEFT JOIN debts d ON d.order_id = s.id
+WHERE s.total_sale_price - COALESCE(d.total_debt, 0) > 0;
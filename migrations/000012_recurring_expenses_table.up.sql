@@ -0,0 +1,27 @@
+-- Шаблоны регулярных расходов: аренда, интернет, зарплаты
+CREATE TABLE recurring_expenses
+(
+    id          UUID           DEFAULT gen_random_uuid() PRIMARY KEY,
+    name        VARCHAR(100)                       NOT NULL,
+    amount      DECIMAL(10, 2)                     NOT NULL,
+    category_id UUID REFERENCES cash_category (id) NOT NULL,
+    wallet_id   UUID REFERENCES wallets (id)       NOT NULL,
+    schedule    VARCHAR(100)                       NOT NULL, -- cron "0 9 1 * *" или "monthly:1"
+    start_date  DATE           DEFAULT CURRENT_DATE NOT NULL,
+    end_date    DATE,
+    description VARCHAR(255),
+    is_active   BOOLEAN        DEFAULT TRUE        NOT NULL,
+    created_by  UUID REFERENCES users (user_id)    NOT NULL,
+    created_at  TIMESTAMP      DEFAULT NOW()
+);
+
+-- Проведённые запуски: уникальность по времени запуска защищает от дублей при догоняющих проводках
+CREATE TABLE recurring_expense_runs
+(
+    id           UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    expense_id   UUID REFERENCES recurring_expenses (id) NOT NULL,
+    scheduled_at TIMESTAMP                               NOT NULL,
+    cash_flow_id UUID REFERENCES cash_flow (id) ON DELETE SET NULL,
+    created_at   TIMESTAMP DEFAULT NOW(),
+    UNIQUE (expense_id, scheduled_at)
+);
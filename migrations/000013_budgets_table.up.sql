@@ -0,0 +1,26 @@
+-- Бюджеты расходов по статьям на месяц, в базовой валюте
+CREATE TABLE budgets
+(
+    id          UUID           DEFAULT gen_random_uuid() PRIMARY KEY,
+    category_id UUID REFERENCES cash_category (id) NOT NULL,
+    period      DATE                               NOT NULL, -- первое число месяца
+    amount      DECIMAL(12, 2)                     NOT NULL,
+    created_at  TIMESTAMP      DEFAULT NOW(),
+    UNIQUE (category_id, period)
+);
+
+-- Уведомления о перерасходе: порог 80 или 100 процентов
+CREATE TABLE budget_alerts
+(
+    id        UUID           DEFAULT gen_random_uuid() PRIMARY KEY,
+    budget_id UUID REFERENCES budgets (id) ON DELETE CASCADE NOT NULL,
+    threshold INT                                            NOT NULL,
+    spent     DECIMAL(12, 2)                                 NOT NULL,
+    channel   VARCHAR(20)                                    NOT NULL,
+    recipient VARCHAR(100)                                   NOT NULL,
+    status    VARCHAR(10)                                    NOT NULL, -- sent / failed
+    error     TEXT,
+    sent_at   TIMESTAMP      DEFAULT NOW()
+);
+
+CREATE INDEX idx_budget_alerts_budget ON budget_alerts (budget_id, threshold);
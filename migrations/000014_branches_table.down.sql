@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_purchase_items_product_id;
+DROP INDEX IF EXISTS idx_sales_items_sale_id;
+
+ALTER TABLE registers
+    DROP COLUMN IF EXISTS branch_id;
+
+DROP TABLE IF EXISTS branches;
@@ -0,0 +1,24 @@
+-- Филиалы (магазины) организации
+CREATE TABLE branches
+(
+    id         UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+INSERT INTO branches (name)
+VALUES ('Основной филиал');
+
+-- Каждая касса стоит в филиале; продажи и расходы смены относятся к филиалу её кассы
+ALTER TABLE registers
+    ADD COLUMN branch_id UUID REFERENCES branches (id);
+
+UPDATE registers
+SET branch_id = (SELECT id FROM branches LIMIT 1);
+
+ALTER TABLE registers
+    ALTER COLUMN branch_id SET NOT NULL;
+
+-- Индексы для отчёта о прибылях и убытках
+CREATE INDEX idx_sales_items_sale_id ON sales_items (sale_id);
+CREATE INDEX idx_purchase_items_product_id ON purchase_items (product_id);
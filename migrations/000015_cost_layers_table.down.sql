@@ -0,0 +1,10 @@
+ALTER TABLE sales_items
+    DROP COLUMN IF EXISTS cogs;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS average_cost;
+
+DROP TABLE IF EXISTS cost_layer_consumptions;
+DROP TABLE IF EXISTS cost_layers;
+
+DELETE FROM settings WHERE key = 'costing_method';
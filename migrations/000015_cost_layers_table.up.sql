@@ -0,0 +1,53 @@
+-- Метод списания себестоимости: fifo или average (скользящая средняя)
+INSERT INTO settings (key, value)
+VALUES ('costing_method', 'fifo');
+
+-- Партии себестоимости: каждая строка закупки приходует количество по цене в базовой валюте
+CREATE TABLE cost_layers
+(
+    id               UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    product_id       UUID REFERENCES products (id) NOT NULL,
+    purchase_item_id UUID REFERENCES purchase_items (id),  -- NULL — начальный остаток
+    quantity         INT                           NOT NULL, -- Оприходовано
+    remaining        INT                           NOT NULL, -- Осталось на складе
+    unit_cost        DECIMAL(14, 4)                NOT NULL,
+    created_at       TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_cost_layers_product ON cost_layers (product_id, created_at) WHERE remaining > 0;
+CREATE INDEX idx_cost_layers_purchase_item ON cost_layers (purchase_item_id);
+
+-- Списание партий продажами, чтобы вернуть их при удалении продажи
+CREATE TABLE cost_layer_consumptions
+(
+    id            UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    layer_id      UUID REFERENCES cost_layers (id),             -- NULL — продано сверх учтённых партий
+    sales_item_id UUID REFERENCES sales_items (id) NOT NULL,
+    quantity      INT                              NOT NULL,
+    unit_cost     DECIMAL(14, 4)                   NOT NULL
+);
+
+CREATE INDEX idx_cost_layer_consumptions_item ON cost_layer_consumptions (sales_item_id);
+CREATE INDEX idx_cost_layer_consumptions_layer ON cost_layer_consumptions (layer_id);
+
+-- Скользящая средняя себестоимость товара в базовой валюте
+ALTER TABLE products
+    ADD COLUMN average_cost DECIMAL(14, 4) DEFAULT 0 NOT NULL;
+
+UPDATE products
+SET average_cost = incoming_price;
+
+-- Текущий остаток становится начальной партией по приходной цене
+INSERT INTO cost_layers (product_id, quantity, remaining, unit_cost)
+SELECT id, total_count, total_count, incoming_price
+FROM products
+WHERE total_count > 0;
+
+-- Себестоимость проданной строки в базовой валюте; старые продажи — по приходной цене
+ALTER TABLE sales_items
+    ADD COLUMN cogs DECIMAL(14, 2) DEFAULT 0 NOT NULL;
+
+UPDATE sales_items si
+SET cogs = si.quantity * p.incoming_price
+FROM products p
+WHERE p.id = si.product_id;
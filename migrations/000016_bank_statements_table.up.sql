@@ -0,0 +1,30 @@
+-- Загруженные банковские выписки по кошельку (карта / расчётный счёт)
+CREATE TABLE bank_statements
+(
+    id          UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    wallet_id   UUID REFERENCES wallets (id)    NOT NULL,
+    file_name   VARCHAR(255)                    NOT NULL,
+    format      VARCHAR(10)                     NOT NULL, -- csv / mt940
+    imported_by UUID REFERENCES users (user_id) NOT NULL,
+    created_at  TIMESTAMP DEFAULT NOW()
+);
+
+-- Строки выписки и их сверка с денежным потоком
+CREATE TABLE bank_statement_lines
+(
+    id           UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    statement_id UUID REFERENCES bank_statements (id)           NOT NULL,
+    line_date    DATE                                           NOT NULL,
+    amount       DECIMAL(14, 2)                                 NOT NULL, -- Поступление (+) или списание (-) в валюте кошелька
+    reference    VARCHAR(100),
+    description  TEXT,
+    cash_flow_id UUID REFERENCES cash_flow (id) ON DELETE SET NULL,       -- Сопоставленная запись
+    confirmed    BOOLEAN   DEFAULT FALSE                        NOT NULL, -- FALSE — предложено автоматически
+    matched_by   UUID REFERENCES users (user_id),
+    matched_at   TIMESTAMP,
+    created_at   TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_bank_statement_lines_statement ON bank_statement_lines (statement_id);
+-- Запись денежного потока сопоставляется не более чем с одной строкой
+CREATE UNIQUE INDEX idx_bank_statement_lines_cash_flow ON bank_statement_lines (cash_flow_id) WHERE cash_flow_id IS NOT NULL;
@@ -0,0 +1,24 @@
+-- Журнал движения товара: каждое изменение остатка с документом-основанием
+CREATE TABLE inventory_movements
+(
+    id             UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    product_id     UUID REFERENCES products (id) NOT NULL,
+    movement_type  VARCHAR(20)                   NOT NULL CHECK (movement_type IN
+        ('purchase', 'sale', 'return', 'adjustment', 'transfer', 'write_off')),
+    quantity       INT                           NOT NULL, -- Приход со знаком плюс, расход со знаком минус
+    balance        INT                           NOT NULL, -- Остаток после движения
+    reference_type VARCHAR(30),                             -- Документ-основание: purchase, sale, ...
+    reference_id   UUID,
+    user_id        UUID REFERENCES users (user_id),
+    note           TEXT,
+    created_at     TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_movements_product ON inventory_movements (product_id, created_at);
+CREATE INDEX idx_inventory_movements_reference ON inventory_movements (reference_type, reference_id);
+
+-- Текущий остаток становится начальной корректировкой
+INSERT INTO inventory_movements (product_id, movement_type, quantity, balance, note)
+SELECT id, 'adjustment', total_count, total_count, 'Начальный остаток'
+FROM products
+WHERE total_count <> 0;
@@ -0,0 +1,11 @@
+DELETE FROM cash_flow
+WHERE reference_type = 'sale_return';
+
+DELETE FROM cash_category
+WHERE code = 'sales_return';
+
+ALTER TABLE clients
+    DROP COLUMN IF EXISTS store_credit;
+
+DROP TABLE IF EXISTS sale_return_items;
+DROP TABLE IF EXISTS sale_returns;
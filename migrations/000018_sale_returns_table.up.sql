@@ -0,0 +1,40 @@
+-- Возвраты покупателей по продажам
+CREATE TABLE sale_returns
+(
+    id             UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    sale_id        UUID REFERENCES sales (id)      NOT NULL,
+    returned_by    UUID REFERENCES users (user_id) NOT NULL, -- Кто оформил возврат
+    reason         TEXT,
+    total_amount   DECIMAL(14, 2)                  NOT NULL, -- Стоимость возвращённых строк в валюте продажи
+    debt_reduction DECIMAL(14, 2) DEFAULT 0        NOT NULL, -- Насколько уменьшен долг по продаже
+    refund_amount  DECIMAL(14, 2) DEFAULT 0        NOT NULL, -- Возвращено покупателю
+    refund_method  VARCHAR(20)                     NOT NULL CHECK (refund_method IN ('uzs', 'usd', 'card', 'store_credit', 'none')),
+    created_at     TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_sale_returns_sale ON sale_returns (sale_id);
+CREATE INDEX idx_sale_returns_created_at ON sale_returns (created_at);
+
+-- Возвращённые строки продажи: restock — обратно на склад, write_off — списание
+CREATE TABLE sale_return_items
+(
+    id            UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    return_id     UUID REFERENCES sale_returns (id) NOT NULL,
+    sales_item_id UUID REFERENCES sales_items (id)  NOT NULL,
+    product_id    UUID REFERENCES products (id)     NOT NULL,
+    quantity      INT                               NOT NULL CHECK (quantity > 0),
+    unit_price    DECIMAL(14, 2)                    NOT NULL,
+    total_price   DECIMAL(14, 2)                    NOT NULL,
+    cogs          DECIMAL(14, 2)                    NOT NULL, -- Себестоимость возвращённого количества в базовой валюте
+    disposition   VARCHAR(10)                       NOT NULL CHECK (disposition IN ('restock', 'write_off'))
+);
+
+CREATE INDEX idx_sale_return_items_return ON sale_return_items (return_id);
+CREATE INDEX idx_sale_return_items_sales_item ON sale_return_items (sales_item_id);
+
+-- Депозит покупателя в базовой валюте, пополняется возвратами
+ALTER TABLE clients
+    ADD COLUMN store_credit DECIMAL(14, 2) DEFAULT 0 NOT NULL;
+
+INSERT INTO cash_category (name, code, transaction_type)
+VALUES ('Возвраты покупателям', 'sales_return', 'expense');
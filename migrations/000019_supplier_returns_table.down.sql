@@ -0,0 +1,11 @@
+DELETE FROM cash_flow
+WHERE reference_type = 'supplier_return';
+
+DELETE FROM cash_category
+WHERE code = 'supplier_refund';
+
+DROP TABLE IF EXISTS supplier_return_items;
+DROP TABLE IF EXISTS supplier_returns;
+
+ALTER TABLE purchases
+    DROP COLUMN IF EXISTS returned_amount;
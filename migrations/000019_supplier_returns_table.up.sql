@@ -0,0 +1,38 @@
+-- Часть возвратов поставщику, на которую уменьшена задолженность по закупке
+ALTER TABLE purchases
+    ADD COLUMN returned_amount DECIMAL(10, 2) DEFAULT 0 NOT NULL;
+
+-- Возвраты поставщикам по строкам закупки
+CREATE TABLE supplier_returns
+(
+    id                UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    purchase_id       UUID REFERENCES purchases (id)  NOT NULL,
+    supplier_id       UUID REFERENCES clients (id)    NOT NULL,
+    returned_by       UUID REFERENCES users (user_id) NOT NULL, -- Кто оформил возврат
+    reason            TEXT,
+    total_amount      DECIMAL(14, 2)                  NOT NULL, -- Стоимость возвращённого в валюте закупки
+    payable_reduction DECIMAL(14, 2) DEFAULT 0        NOT NULL, -- Насколько уменьшена задолженность поставщику
+    refund_amount     DECIMAL(14, 2) DEFAULT 0        NOT NULL, -- Возвращено поставщиком деньгами
+    refund_method     VARCHAR(10)                     NOT NULL CHECK (refund_method IN ('uzs', 'usd', 'card', 'none')),
+    created_at        TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_supplier_returns_purchase ON supplier_returns (purchase_id);
+CREATE INDEX idx_supplier_returns_supplier ON supplier_returns (supplier_id);
+
+CREATE TABLE supplier_return_items
+(
+    id               UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    return_id        UUID REFERENCES supplier_returns (id) NOT NULL,
+    purchase_item_id UUID REFERENCES purchase_items (id)   NOT NULL,
+    product_id       UUID REFERENCES products (id)         NOT NULL,
+    quantity         INT                                   NOT NULL CHECK (quantity > 0),
+    unit_price       DECIMAL(14, 2)                        NOT NULL,
+    total_price      DECIMAL(14, 2)                        NOT NULL
+);
+
+CREATE INDEX idx_supplier_return_items_return ON supplier_return_items (return_id);
+CREATE INDEX idx_supplier_return_items_purchase_item ON supplier_return_items (purchase_item_id);
+
+INSERT INTO cash_category (name, code, transaction_type)
+VALUES ('Возвраты от поставщиков', 'supplier_refund', 'income');
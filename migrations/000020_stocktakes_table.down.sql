@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS stocktake_counts;
+DROP TABLE IF EXISTS stocktake_items;
+DROP TABLE IF EXISTS stocktakes;
+
+DROP INDEX IF EXISTS idx_products_barcode;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS barcode,
+    DROP COLUMN IF EXISTS location;
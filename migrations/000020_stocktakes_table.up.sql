@@ -0,0 +1,44 @@
+-- Штрихкод и место хранения товара (склад, зал, полка)
+ALTER TABLE products
+    ADD COLUMN barcode  VARCHAR(64),
+    ADD COLUMN location VARCHAR(50);
+
+CREATE UNIQUE INDEX idx_products_barcode ON products (barcode) WHERE barcode IS NOT NULL;
+
+-- Инвентаризации: снимок ожидаемых остатков, пересчёт и проводка расхождений
+CREATE TABLE stocktakes
+(
+    id          UUID        DEFAULT gen_random_uuid() PRIMARY KEY,
+    status      VARCHAR(10) DEFAULT 'counting'      NOT NULL CHECK (status IN ('counting', 'posted', 'cancelled')),
+    category_id UUID REFERENCES product_categories (id),          -- Инвентаризация одной категории
+    location    VARCHAR(50),                                      -- Инвентаризация одного места хранения
+    note        TEXT,
+    created_by  UUID REFERENCES users (user_id)     NOT NULL,
+    created_at  TIMESTAMP   DEFAULT NOW(),
+    posted_by   UUID REFERENCES users (user_id),
+    posted_at   TIMESTAMP
+);
+
+-- Ожидаемые остатки на момент начала инвентаризации
+CREATE TABLE stocktake_items
+(
+    id           UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    stocktake_id UUID REFERENCES stocktakes (id) NOT NULL,
+    product_id   UUID REFERENCES products (id)   NOT NULL,
+    expected     INT                             NOT NULL,
+    unit_cost    DECIMAL(14, 4)                  NOT NULL, -- Средняя себестоимость в базовой валюте
+    UNIQUE (stocktake_id, product_id)
+);
+
+-- Подсчёты: товар можно считать частями и несколькими сотрудниками, итог — сумма подсчётов
+CREATE TABLE stocktake_counts
+(
+    id           UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    stocktake_id UUID REFERENCES stocktakes (id)      NOT NULL,
+    product_id   UUID REFERENCES products (id)        NOT NULL,
+    quantity     INT                                  NOT NULL, -- Отрицательное значение исправляет ошибку подсчёта
+    counted_by   UUID REFERENCES users (user_id)      NOT NULL,
+    created_at   TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_stocktake_counts_product ON stocktake_counts (stocktake_id, product_id);
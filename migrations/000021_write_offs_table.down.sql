@@ -0,0 +1,8 @@
+DELETE FROM cash_category
+WHERE code = 'write_off';
+
+DELETE FROM settings
+WHERE key = 'write_off_approval_threshold';
+
+DROP TABLE IF EXISTS write_off_items;
+DROP TABLE IF EXISTS write_offs;
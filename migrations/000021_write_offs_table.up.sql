@@ -0,0 +1,36 @@
+-- Списания испорченного, просроченного или утерянного товара
+CREATE TABLE write_offs
+(
+    id            UUID        DEFAULT gen_random_uuid() PRIMARY KEY,
+    reason        VARCHAR(10)                     NOT NULL CHECK (reason IN ('damaged', 'expired', 'lost', 'other')),
+    note          TEXT,
+    status        VARCHAR(10) DEFAULT 'pending'   NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    total_cost    DECIMAL(14, 2)                  NOT NULL, -- Себестоимость списанного в базовой валюте
+    created_by    UUID REFERENCES users (user_id) NOT NULL,
+    created_at    TIMESTAMP   DEFAULT NOW(),
+    approved_by   UUID REFERENCES users (user_id),          -- Кто утвердил или отклонил списание
+    approved_at   TIMESTAMP,
+    reject_reason TEXT
+);
+
+CREATE INDEX idx_write_offs_status ON write_offs (status, created_at);
+
+CREATE TABLE write_off_items
+(
+    id           UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    write_off_id UUID REFERENCES write_offs (id) NOT NULL,
+    product_id   UUID REFERENCES products (id)   NOT NULL,
+    quantity     INT                             NOT NULL CHECK (quantity > 0),
+    unit_cost    DECIMAL(14, 4)                  NOT NULL,
+    total_cost   DECIMAL(14, 2)                  NOT NULL
+);
+
+CREATE INDEX idx_write_off_items_write_off ON write_off_items (write_off_id);
+
+-- Списания дороже порога (в базовой валюте) ждут утверждения владельцем
+INSERT INTO settings (key, value)
+VALUES ('write_off_approval_threshold', '1000000');
+
+-- Убытки от списаний попадают в расходы отчёта о прибылях и убытках по этой категории
+INSERT INTO cash_category (name, code, transaction_type)
+VALUES ('Списание товаров', 'write_off', 'expense');
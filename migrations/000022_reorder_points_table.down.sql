@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS stock_alerts;
+
+DROP INDEX IF EXISTS idx_products_low_stock;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS min_stock,
+    DROP COLUMN IF EXISTS reorder_qty,
+    DROP COLUMN IF EXISTS preferred_supplier_id;
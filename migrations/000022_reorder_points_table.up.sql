@@ -0,0 +1,19 @@
+-- Точка заказа товара: минимальный остаток, размер заказа и основной поставщик
+ALTER TABLE products
+    ADD COLUMN min_stock             INT CHECK (min_stock >= 0),  -- Пусто: остаток не отслеживается
+    ADD COLUMN reorder_qty           INT CHECK (reorder_qty > 0), -- Сколько заказывать при падении до минимума
+    ADD COLUMN preferred_supplier_id UUID REFERENCES clients (id);
+
+CREATE INDEX idx_products_low_stock ON products (id) WHERE min_stock IS NOT NULL;
+
+-- Журнал ежедневных уведомлений о низких остатках
+CREATE TABLE stock_alerts
+(
+    id        UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    products  INT                NOT NULL, -- Сколько товаров было ниже минимума
+    channel   VARCHAR(20)        NOT NULL,
+    recipient VARCHAR(100)       NOT NULL,
+    status    VARCHAR(10)        NOT NULL, -- sent / failed
+    error     TEXT,
+    sent_at   TIMESTAMP DEFAULT NOW()
+);
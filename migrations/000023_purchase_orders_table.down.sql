@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS purchase_order_receipt_items;
+DROP TABLE IF EXISTS purchase_order_receipts;
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
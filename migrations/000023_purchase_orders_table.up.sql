@@ -0,0 +1,59 @@
+-- Заказы поставщикам: товар приходует только приёмка по заказу
+CREATE TABLE purchase_orders
+(
+    id            UUID        DEFAULT gen_random_uuid() PRIMARY KEY,
+    order_number  SERIAL                          NOT NULL UNIQUE, -- Номер для печатной формы
+    supplier_id   UUID REFERENCES clients (id)    NOT NULL,
+    created_by    UUID REFERENCES users (user_id) NOT NULL,
+    status        VARCHAR(20) DEFAULT 'draft'     NOT NULL
+        CHECK (status IN ('draft', 'sent', 'partially_received', 'received', 'closed', 'cancelled')),
+    currency      VARCHAR(3)                      NOT NULL,
+    expected_date DATE,
+    description   TEXT,
+    total_cost    DECIMAL(14, 2)                  NOT NULL,
+    created_at    TIMESTAMP   DEFAULT NOW(),
+    sent_at       TIMESTAMP,
+    closed_at     TIMESTAMP                                        -- Полная приёмка, закрытие или отмена
+);
+
+CREATE INDEX idx_purchase_orders_supplier ON purchase_orders (supplier_id, created_at);
+CREATE INDEX idx_purchase_orders_status ON purchase_orders (status);
+
+CREATE TABLE purchase_order_items
+(
+    id         UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    order_id   UUID REFERENCES purchase_orders (id) NOT NULL,
+    product_id UUID REFERENCES products (id)        NOT NULL,
+    quantity   INT                                  NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(14, 2)                       NOT NULL,
+    received   INT  DEFAULT 0                       NOT NULL -- Сколько уже принято по всем приёмкам
+);
+
+CREATE INDEX idx_purchase_order_items_order ON purchase_order_items (order_id);
+
+-- Каждая приёмка по заказу оформляется закупкой
+CREATE TABLE purchase_order_receipts
+(
+    id              UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    order_id        UUID REFERENCES purchase_orders (id) NOT NULL,
+    purchase_id     UUID REFERENCES purchases (id)       NOT NULL,
+    received_by     UUID REFERENCES users (user_id)      NOT NULL,
+    has_differences BOOLEAN   DEFAULT FALSE              NOT NULL,
+    note            TEXT,
+    created_at      TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_purchase_order_receipts_order ON purchase_order_receipts (order_id);
+
+CREATE TABLE purchase_order_receipt_items
+(
+    id            UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    receipt_id    UUID REFERENCES purchase_order_receipts (id) NOT NULL,
+    order_item_id UUID REFERENCES purchase_order_items (id)    NOT NULL,
+    product_id    UUID REFERENCES products (id)                NOT NULL,
+    expected      INT                                          NOT NULL, -- Остаток по строке заказа до приёмки
+    received      INT                                          NOT NULL CHECK (received >= 0),
+    note          TEXT
+);
+
+CREATE INDEX idx_purchase_order_receipt_items_receipt ON purchase_order_receipt_items (receipt_id);
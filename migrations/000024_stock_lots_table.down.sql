@@ -0,0 +1,5 @@
+DELETE FROM settings
+WHERE key = 'block_expired_sales';
+
+DROP TABLE IF EXISTS lot_allocations;
+DROP TABLE IF EXISTS stock_lots;
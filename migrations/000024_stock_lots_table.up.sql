@@ -0,0 +1,30 @@
+-- Партии товара с номером и сроком годности, приходуются закупкой
+CREATE TABLE stock_lots
+(
+    id               UUID      DEFAULT gen_random_uuid() PRIMARY KEY,
+    product_id       UUID REFERENCES products (id) NOT NULL,
+    purchase_item_id UUID REFERENCES purchase_items (id),
+    lot_number       VARCHAR(50),
+    expiry_date      DATE,
+    quantity         INT                           NOT NULL CHECK (quantity > 0),
+    remaining        INT                           NOT NULL CHECK (remaining >= 0), -- Остаток партии на складе
+    created_at       TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_lots_product ON stock_lots (product_id, expiry_date) WHERE remaining > 0;
+CREATE INDEX idx_stock_lots_expiry ON stock_lots (expiry_date) WHERE remaining > 0;
+
+-- Из каких партий продана строка продажи (первым истекает — первым продаётся)
+CREATE TABLE lot_allocations
+(
+    id            UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    lot_id        UUID REFERENCES stock_lots (id)  NOT NULL,
+    sales_item_id UUID REFERENCES sales_items (id) NOT NULL,
+    quantity      INT                              NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_lot_allocations_sales_item ON lot_allocations (sales_item_id);
+
+-- Запрет продажи просроченных партий
+INSERT INTO settings (key, value)
+VALUES ('block_expired_sales', 'false');
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_products_variant;
+DROP INDEX IF EXISTS idx_products_sku;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS own_price,
+    DROP COLUMN IF EXISTS variant_options,
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS sku,
+    DROP COLUMN IF EXISTS parent_id;
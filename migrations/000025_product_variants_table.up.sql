@@ -0,0 +1,12 @@
+-- Варианты товара (размер, цвет): каждый вариант — отдельная строка products со ссылкой на родителя,
+-- поэтому остатки, себестоимость, продажи и закупки ведутся по варианту
+ALTER TABLE products
+    ALTER COLUMN name TYPE VARCHAR(100),
+    ADD COLUMN parent_id       UUID REFERENCES products (id),
+    ADD COLUMN sku             VARCHAR(64),
+    ADD COLUMN attributes      JSONB,                       -- Значения атрибутов варианта: {"size": "M", "color": "red"}
+    ADD COLUMN variant_options JSONB,                       -- Атрибуты товара-родителя: {"size": ["S", "M"], ...}
+    ADD COLUMN own_price       BOOLEAN DEFAULT FALSE NOT NULL; -- Цены варианта не берутся у родителя
+
+CREATE UNIQUE INDEX idx_products_sku ON products (sku) WHERE sku IS NOT NULL;
+CREATE UNIQUE INDEX idx_products_variant ON products (parent_id, attributes) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,193 @@
+// Package schedule parses the schedules of recurring jobs: five-field cron expressions
+// ("minute hour day-of-month month day-of-week") and the "monthly:<day>" shorthand.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns the first time it fires strictly after the given time,
+// or the zero time if it never fires again.
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// Parse reads a cron expression or "monthly:<day>". Monthly days past the end of a month fire on its last day.
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	var s Schedule
+	if day, ok := strings.CutPrefix(spec, "monthly:"); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(day))
+		if err != nil || n < 1 || n > 31 {
+			return nil, fmt.Errorf("invalid monthly day %q, expected 1-31", day)
+		}
+		s = monthly(n)
+	} else {
+		c, err := parseCron(spec)
+		if err != nil {
+			return nil, err
+		}
+		s = c
+	}
+
+	if s.Next(time.Now()).IsZero() {
+		return nil, errors.New("schedule never fires")
+	}
+
+	return s, nil
+}
+
+type monthly int
+
+func (m monthly) Next(after time.Time) time.Time {
+	for i := 0; i < 2; i++ {
+		first := time.Date(after.Year(), after.Month()+time.Month(i), 1, 0, 0, 0, 0, after.Location())
+		last := first.AddDate(0, 1, -1).Day()
+		day := min(int(m), last)
+		if t := first.AddDate(0, 0, day-1); t.After(after) {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// bits holds the allowed values of a cron field.
+type bits uint64
+
+func (b bits) has(v int) bool {
+	return b&(1<<uint(v)) != 0
+}
+
+type cron struct {
+	minute, hour, dom, month, dow bits
+	anyDom, anyDow                bool
+}
+
+var fields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+func parseCron(spec string) (*cron, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression %q must have %d fields", spec, len(fields))
+	}
+
+	var set [5]bits
+	for i, part := range parts {
+		b, err := parseField(part, fields[i].min, fields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", fields[i].name, part, err)
+		}
+		set[i] = b
+	}
+
+	// Sunday is both 0 and 7
+	if set[4].has(7) {
+		set[4] |= 1
+	}
+
+	return &cron{
+		minute: set[0],
+		hour:   set[1],
+		dom:    set[2],
+		month:  set[3],
+		dow:    set[4],
+		anyDom: parts[2] == "*",
+		anyDow: parts[4] == "*",
+	}, nil
+}
+
+// parseField reads a comma-separated list of "*", "a", "a-b", each optionally followed by "/step".
+func parseField(field string, lo, hi int) (bits, error) {
+	var b bits
+	for _, item := range strings.Split(field, ",") {
+		rng, stepText, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("bad step %q", stepText)
+			}
+			step = n
+		}
+
+		from, to := lo, hi
+		if rng != "*" {
+			a, c, isRange := strings.Cut(rng, "-")
+			n, err := strconv.Atoi(a)
+			if err != nil {
+				return 0, fmt.Errorf("bad value %q", a)
+			}
+			from, to = n, n
+			if isRange {
+				if to, err = strconv.Atoi(c); err != nil {
+					return 0, fmt.Errorf("bad value %q", c)
+				}
+			} else if hasStep {
+				to = hi
+			}
+		}
+		if from < lo || to > hi || from > to {
+			return 0, fmt.Errorf("%q is out of range %d-%d", rng, lo, hi)
+		}
+
+		for v := from; v <= to; v += step {
+			b |= 1 << uint(v)
+		}
+	}
+
+	return b, nil
+}
+
+// dayMatches follows cron: when both day fields are restricted, either one may match.
+func (c *cron) dayMatches(t time.Time) bool {
+	dom := c.dom.has(t.Day())
+	dow := c.dow.has(int(t.Weekday()))
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+func (c *cron) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !c.month.has(int(t.Month())) || !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.hour.has(t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.minute.has(t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,96 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func at(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", s, time.UTC)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  string
+		after string
+		want  string
+	}{
+		{"monthly later this month", "monthly:31", "2024-01-15 10:00", "2024-01-31 00:00"},
+		{"monthly clamps to leap February", "monthly:31", "2024-01-31 00:00", "2024-02-29 00:00"},
+		{"monthly clamps to February", "monthly:31", "2023-01-31 00:00", "2023-02-28 00:00"},
+		{"monthly after a clamped day", "monthly:30", "2024-02-29 00:00", "2024-03-30 00:00"},
+		{"monthly strictly after", "monthly:15", "2024-03-15 00:00", "2024-04-15 00:00"},
+		{"monthly over the year end", "monthly:1", "2024-12-01 00:00", "2025-01-01 00:00"},
+		{"daily strictly after", "30 9 * * *", "2024-03-01 09:30", "2024-03-02 09:30"},
+		{"daily same day", "30 9 * * *", "2024-03-01 09:29", "2024-03-01 09:30"},
+		{"minute step", "*/15 * * * *", "2024-03-01 10:07", "2024-03-01 10:15"},
+		{"step from a value", "5/20 * * * *", "2024-03-01 10:25", "2024-03-01 10:45"},
+		{"hour range with step", "0 9-17/4 * * *", "2024-03-01 13:00", "2024-03-01 17:00"},
+		{"hour range wraps to next day", "0 9-17/4 * * *", "2024-03-01 17:00", "2024-03-02 09:00"},
+		{"list of minutes", "10,40 * * * *", "2024-03-01 10:10", "2024-03-01 10:40"},
+		{"Sunday as 0", "0 0 * * 0", "2024-03-01 00:00", "2024-03-03 00:00"},
+		{"Sunday as 7", "0 0 * * 7", "2024-03-01 00:00", "2024-03-03 00:00"},
+		{"weekdays only", "0 0 * * 1-5", "2024-03-01 00:00", "2024-03-04 00:00"},
+		{"day of month only", "0 0 1 * *", "2024-03-01 00:00", "2024-04-01 00:00"},
+		{"day of month or weekday, weekday first", "0 0 13 * 5", "2024-03-01 00:00", "2024-03-08 00:00"},
+		{"day of month or weekday, day first", "0 0 13 * 5", "2024-03-08 00:00", "2024-03-13 00:00"},
+		{"listed months", "0 0 1 1,7 *", "2024-03-01 00:00", "2024-07-01 00:00"},
+		{"leap day", "0 0 29 2 *", "2024-03-01 00:00", "2028-02-29 00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.spec, err)
+			}
+			if got := s.Next(at(tt.after)); !got.Equal(at(tt.want)) {
+				t.Errorf("Next(%s) = %s, want %s", tt.after, got.Format("2006-01-02 15:04"), tt.want)
+			}
+		})
+	}
+}
+
+func TestNeverFires(t *testing.T) {
+	c, err := parseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("parseCron error: %v", err)
+	}
+	if got := c.Next(at("2024-01-01 00:00")); !got.IsZero() {
+		t.Errorf("Next() = %s, want zero time", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1-a * * * *",
+		"0 0 31 2 *",
+		"0 0 30,31 2 *",
+		"monthly:0",
+		"monthly:32",
+		"monthly:x",
+	}
+
+	for _, spec := range specs {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) should fail", spec)
+		}
+	}
+}
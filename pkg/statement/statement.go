@@ -0,0 +1,353 @@
+// Package statement reads bank statements exported as CSV or in the SWIFT MT940 format.
+package statement
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Line is one transaction of a statement. Amount is positive for money received and negative for money paid.
+type Line struct {
+	Date        time.Time
+	Amount      float64
+	Reference   string
+	Description string
+}
+
+// Parse reads a statement in the given format: csv or mt940.
+func Parse(format string, r io.Reader) ([]Line, error) {
+	switch strings.ToLower(format) {
+	case "csv":
+		return parseCSV(r)
+	case "mt940":
+		return parseMT940(r)
+	default:
+		return nil, fmt.Errorf("unknown statement format %q, expected csv or mt940", format)
+	}
+}
+
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"02.01.2006",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02/01/2006",
+	"02/01/2006 15:04:05",
+}
+
+func parseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// parseAmount reads amounts like "1 234,56", "1,234.56", "1,234", "-15.00" or "(15.00)".
+// A comma is the decimal separator unless a dot follows it, or it groups thousands: there is more
+// than one, or exactly three digits follow the last one with no dot before it.
+func parseAmount(s string) (float64, error) {
+	s = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\u00a0' || r == '\'' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+	if s == "" {
+		return 0, nil
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative, s = true, s[1:len(s)-1]
+	}
+	if strings.HasSuffix(s, "-") {
+		negative, s = true, strings.TrimSuffix(s, "-")
+	}
+
+	comma, dot := strings.LastIndex(s, ","), strings.LastIndex(s, ".")
+	grouped := strings.Count(s, ",") > 1 || (dot < 0 && len(s)-comma-1 == 3 && isDigits(s[comma+1:]))
+	switch {
+	case comma >= 0 && (dot > comma || grouped):
+		s = strings.ReplaceAll(s, ",", "")
+	case comma >= 0:
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.Replace(s, ",", ".", 1)
+	}
+
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// csvColumns lists the header names recognised for each field, in English and Russian.
+var csvColumns = map[string][]string{
+	"date":        {"date", "дата", "value date", "booking date"},
+	"amount":      {"amount", "сумма"},
+	"credit":      {"credit", "кредит", "приход", "deposit", "income"},
+	"debit":       {"debit", "дебет", "расход", "withdrawal", "expense"},
+	"reference":   {"reference", "ref", "номер документа", "документ", "document", "transaction id"},
+	"description": {"description", "details", "purpose", "назначение", "narrative", "memo", "comment"},
+}
+
+// csvHeader maps the fields of a statement to the indexes of its columns.
+func csvHeader(header []string) (map[string]int, error) {
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for field, names := range csvColumns {
+			if _, ok := columns[field]; ok {
+				continue
+			}
+			for _, candidate := range names {
+				if name == candidate || strings.HasPrefix(name, candidate+" ") {
+					columns[field] = i
+					break
+				}
+			}
+		}
+	}
+
+	if _, ok := columns["date"]; !ok {
+		return nil, errors.New("statement has no date column")
+	}
+	_, amount := columns["amount"]
+	_, credit := columns["credit"]
+	_, debit := columns["debit"]
+	if !amount && !credit && !debit {
+		return nil, errors.New("statement has no amount, credit or debit column")
+	}
+
+	return columns, nil
+}
+
+// parseCSV reads a statement with a header row, separated by commas, semicolons or tabs.
+// Amounts come either from a signed amount column or from separate credit and debit columns.
+func parseCSV(r io.Reader) ([]Line, error) {
+	br := bufio.NewReader(r)
+	first, err := br.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	firstLine, _, _ := strings.Cut(string(first), "\n")
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.Comma = ','
+	for _, sep := range []rune{';', '\t'} {
+		if strings.Count(firstLine, string(sep)) > strings.Count(firstLine, string(reader.Comma)) {
+			reader.Comma = sep
+		}
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("statement is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement header: %w", err)
+	}
+	columns, err := csvHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var lines []Line
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		if field(record, "date") == "" {
+			continue
+		}
+
+		line := Line{Reference: field(record, "reference"), Description: field(record, "description")}
+		if line.Date, err = parseDate(field(record, "date")); err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+
+		if _, ok := columns["amount"]; ok {
+			line.Amount, err = parseAmount(field(record, "amount"))
+		} else {
+			var credit, debit float64
+			if credit, err = parseAmount(field(record, "credit")); err == nil {
+				debit, err = parseAmount(field(record, "debit"))
+			}
+			line.Amount = credit - abs(debit)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		if line.Amount == 0 {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// parseMT940 reads the statement lines (:61:) of an MT940 file with the information to the
+// account owner (:86:) that follows each of them as the description.
+func parseMT940(r io.Reader) ([]Line, error) {
+	var lines []Line
+	var tag, value string
+
+	flush := func() error {
+		switch tag {
+		case "61":
+			line, err := parseMT940Line(value)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, line)
+		case "86":
+			if len(lines) > 0 && lines[len(lines)-1].Description == "" {
+				lines[len(lines)-1].Description = strings.TrimSpace(value)
+			}
+		}
+		tag, value = "", ""
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(text, ":") {
+			if end := strings.Index(text[1:], ":"); end > 0 {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+				tag, value = text[1:end+1], text[end+2:]
+				continue
+			}
+		}
+		if text == "-" || strings.HasPrefix(text, "-}") {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if tag != "" {
+			value += " " + strings.TrimSpace(text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("statement has no transactions")
+	}
+
+	return lines, nil
+}
+
+// parseMT940Line reads a :61: field: value date YYMMDD, optional entry date MMDD, debit/credit mark
+// (C, D, RC, RD), optional funds code, amount with a decimal comma, transaction type and references.
+func parseMT940Line(s string) (Line, error) {
+	var line Line
+	if len(s) < 6 {
+		return line, fmt.Errorf("invalid statement line %q", s)
+	}
+
+	date, err := time.ParseInLocation("060102", s[:6], time.Local)
+	if err != nil {
+		return line, fmt.Errorf("invalid statement line date %q", s[:6])
+	}
+	line.Date = date
+	s = s[6:]
+	if len(s) >= 4 && isDigits(s[:4]) {
+		s = s[4:]
+	}
+
+	sign := 1.0
+	switch {
+	case strings.HasPrefix(s, "RC"):
+		sign, s = -1, s[2:]
+	case strings.HasPrefix(s, "RD"):
+		s = s[2:]
+	case strings.HasPrefix(s, "C"):
+		s = s[1:]
+	case strings.HasPrefix(s, "D"):
+		sign, s = -1, s[1:]
+	default:
+		return line, fmt.Errorf("invalid debit/credit mark in statement line %q", s)
+	}
+	if s != "" && (s[0] < '0' || s[0] > '9') {
+		s = s[1:]
+	}
+
+	end := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != ',' })
+	if end < 0 {
+		end = len(s)
+	}
+	// MT940 amounts always have a decimal comma, e.g. "1500," or "1234,567"
+	amount, err := strconv.ParseFloat(strings.Replace(s[:end], ",", ".", 1), 64)
+	if err != nil {
+		return line, fmt.Errorf("invalid amount in statement line %q", s[:end])
+	}
+	line.Amount = sign * amount
+	s = s[end:]
+
+	// Transaction type code: N, F or S followed by three characters
+	if len(s) >= 4 {
+		s = s[4:]
+	}
+	reference, _, _ := strings.Cut(s, "//")
+	if reference = strings.TrimSpace(reference); reference != "NONREF" {
+		line.Reference = reference
+	}
+
+	return line, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,179 @@
+package statement
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: "15", want: 15},
+		{in: "-15.00", want: -15},
+		{in: "(15.00)", want: -15},
+		{in: "15.00-", want: -15},
+		{in: "1234,56", want: 1234.56},
+		{in: "1 234,56", want: 1234.56},
+		{in: "1 234,56", want: 1234.56},
+		{in: "1'234.56", want: 1234.56},
+		{in: "1.234,56", want: 1234.56},
+		{in: "1.234.567,89", want: 1234567.89},
+		{in: "1,234.56", want: 1234.56},
+		{in: "1,234,567.89", want: 1234567.89},
+		{in: "1,234", want: 1234},
+		{in: "-1,234", want: -1234},
+		{in: "1,234,567", want: 1234567},
+		{in: "1,5", want: 1.5},
+		{in: "12,34", want: 12.34},
+		{in: "1,2345", want: 1.2345},
+		{in: "1.234,567", want: 1234.567},
+		{in: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAmount(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseAmount(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseAmount(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAmount(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []float64
+		wantErr bool
+	}{
+		{
+			name: "comma separated, grouped amounts",
+			in:   "Date,Amount,Reference,Description\n2024-03-01,\"1,234\",R1,Invoice 7\n2024-03-02,\"-1,234,567.50\",R2,Rent\n",
+			want: []float64{1234, -1234567.5},
+		},
+		{
+			name: "semicolon separated, decimal comma",
+			in:   "Дата;Сумма;Назначение\n01.03.2024;1 234,56;Оплата\n02.03.2024;-15,5;Комиссия\n",
+			want: []float64{1234.56, -15.5},
+		},
+		{
+			name: "tab separated, credit and debit columns",
+			in:   "Date\tCredit\tDebit\n2024-03-01\t100.00\t\n2024-03-02\t\t40.00\n2024-03-03\t0\t0\n",
+			want: []float64{100, -40},
+		},
+		{
+			name:    "no amount column",
+			in:      "Date,Reference\n2024-03-01,R1\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid date",
+			in:      "Date,Amount\n2024/13/45,10\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := Parse("csv", strings.NewReader(tt.in))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse() = %v, want error", lines)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			if len(lines) != len(tt.want) {
+				t.Fatalf("Parse() returned %d lines, want %d", len(lines), len(tt.want))
+			}
+			for i, line := range lines {
+				if line.Amount != tt.want[i] {
+					t.Errorf("line %d amount = %v, want %v", i, line.Amount, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseMT940Line(t *testing.T) {
+	tests := []struct {
+		in        string
+		date      string
+		amount    float64
+		reference string
+		wantErr   bool
+	}{
+		{in: "240301C1234,56NTRFINV-7//BANK1", date: "2024-03-01", amount: 1234.56, reference: "INV-7"},
+		{in: "2403010302D50,00NMSCREF1", date: "2024-03-01", amount: -50, reference: "REF1"},
+		{in: "240301RC10,NTRFREF2", date: "2024-03-01", amount: -10, reference: "REF2"},
+		{in: "240301RD10,5NTRFREF3", date: "2024-03-01", amount: 10.5, reference: "REF3"},
+		{in: "240301CR1500,NTRFREF4", date: "2024-03-01", amount: 1500, reference: "REF4"},
+		{in: "2403010301DR1,234NCHKNONREF//B2", date: "2024-03-01", amount: -1.234},
+		{in: "240301C100,00NTRFNONREF", date: "2024-03-01", amount: 100},
+		{in: "240301X100,00NTRF", wantErr: true},
+		{in: "241301C100,00NTRF", wantErr: true},
+		{in: "2403", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		line, err := parseMT940Line(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseMT940Line(%q) = %+v, want error", tt.in, line)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseMT940Line(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got := line.Date.Format(time.DateOnly); got != tt.date {
+			t.Errorf("parseMT940Line(%q) date = %s, want %s", tt.in, got, tt.date)
+		}
+		if line.Amount != tt.amount {
+			t.Errorf("parseMT940Line(%q) amount = %v, want %v", tt.in, line.Amount, tt.amount)
+		}
+		if line.Reference != tt.reference {
+			t.Errorf("parseMT940Line(%q) reference = %q, want %q", tt.in, line.Reference, tt.reference)
+		}
+	}
+}
+
+func TestParseMT940(t *testing.T) {
+	in := ":20:STMT1\r\n:25:12345678\r\n:61:240301C1234,56NTRFINV-7\r\n:86:Payment for\r\ninvoice 7\r\n" +
+		":61:240302D20,NMSCNONREF\r\n:62F:C240302EUR1214,56\r\n-}\r\n"
+
+	lines, err := Parse("mt940", strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("Parse() returned %d lines, want 2", len(lines))
+	}
+	if lines[0].Amount != 1234.56 || lines[0].Description != "Payment for invoice 7" {
+		t.Errorf("first line = %+v", lines[0])
+	}
+	if lines[1].Amount != -20 || lines[1].Reference != "" {
+		t.Errorf("second line = %+v", lines[1])
+	}
+
+	if _, err := Parse("mt940", strings.NewReader(":20:STMT1\n-}\n")); err == nil {
+		t.Error("Parse() of a statement without transactions should fail")
+	}
+}